@@ -0,0 +1,100 @@
+// Команда cfr решает игру методом CFR (или CFR+) и записывает таблицу стратегии в формате JSON,
+// которую загружает bots.SolvedStrategy.
+//
+//	cfr -game kuhn -iterations 10000 -plus
+//	cfr -game pushfold -stack 10 -out pushfold10.json
+//	cfr -game river -board "AH KD 7C 2S 2D" -oop "AA,KK,AK,72o" -ip "QQ,JJ,AQs" -pot 4 -bet 2 -out river.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"hands/src/cfr"
+	"hands/src/models"
+)
+
+func main() {
+	gameFlag := flag.String("game", cfr.KuhnGame, "game to solve: kuhn, leduc, pushfold, river")
+	iterations := flag.Int("iterations", 1000, "number of iterations")
+	plus := flag.Bool("plus", true, "use CFR+")
+	out := flag.String("out", "", "file to write the strategy table to (stdout if empty)")
+	seed := flag.Int64("seed", 1, "random seed for equity estimation")
+	stack := flag.Float64("stack", 10, "pushfold: effective stack in big blinds")
+	samples := flag.Int("samples", cfr.DefaultPushFoldSamples, "pushfold: deals per hand class matchup")
+	board := flag.String("board", "", "river: five board cards separated by spaces")
+	oop := flag.String("oop", "", "river: range of the first player to act, e.g. AA,KK,AKs")
+	ip := flag.String("ip", "", "river: range of the player in position")
+	pot := flag.Float64("pot", 2, "river: pot size")
+	bet := flag.Float64("bet", 1, "river: bet size")
+	maxBets := flag.Int("maxbets", cfr.DefaultRiverMaxBets, "river: bets and raises cap")
+
+	flag.Parse()
+
+	var (
+		game cfr.Game
+		err  error
+	)
+
+	switch *gameFlag {
+	case cfr.KuhnGame:
+		game = cfr.Kuhn{}
+	case cfr.LeducGame:
+		game = cfr.Leduc{}
+	case cfr.PushFoldGame:
+		game, err = cfr.NewPushFold(*stack, *samples, rand.New(rand.NewSource(*seed)))
+	case cfr.RiverGame:
+		game, err = newRiver(*board, *oop, *ip, *pot, *bet, *maxBets)
+	default:
+		err = fmt.Errorf("Unknown game %s", *gameFlag)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	table := cfr.NewSolver(game, *plus).Solve(*iterations).Strategy()
+
+	fmt.Fprintf(os.Stderr, "%s: %d iterations, %d info sets, first player value %.4f\n",
+		game.Name(), table.Iterations, len(table.InfoSets), cfr.Value(game, table))
+
+	w := os.Stdout
+
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	if err := table.Save(w); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func newRiver(board, oop, ip string, pot, bet float64, maxBets int) (*cfr.River, error) {
+	cards, err := models.ParseCards(board)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := cfr.ParseRange(oop, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := cfr.ParseRange(ip, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfr.NewRiver(cards, [cfr.PlayersNum][][]*models.Card{first, second}, pot, bet, maxBets)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"hands/src/models"
+)
+
+// holdingResult - рука игрока на вскрытии. Place - место руки, равные руки делят место
+type holdingResult struct {
+	Name        string
+	Pocket      []*models.Card
+	Best        *models.Hand
+	Rank        models.HandRank
+	Description string
+	Place       int
+	Winner      bool
+}
+
+// compareResult - руки на общих картах Board от сильнейшей к слабейшей и имена победителей
+type compareResult struct {
+	Board   []*models.Card
+	Hands   []*holdingResult
+	Winners []string
+}
+
+func (r *compareResult) writeText(w io.Writer) {
+	fmt.Fprintf(w, "Board: %s\n\n", cardsText(r.Board))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PLACE\tNAME\tPOCKET\tBEST\tHAND")
+
+	for _, h := range r.Hands {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n",
+			h.Place, h.Name, cardsText(h.Pocket), cardsText(h.Best.Slice()), h.Description)
+	}
+
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nWinners: %s\n", strings.Join(r.Winners, ", "))
+}
+
+// runCompare сравнивает руки из аргументов на общих картах и определяет победителей так же,
+// как Table.ResolveWinner: банк делят все обладатели сильнейшей руки
+func runCompare(args []string, w io.Writer) error {
+	fs, out := newFlagSet("compare", w)
+	board := fs.String("board", "", "board cards, e.g. \"Ah Kd 7c 2s 2d\"")
+
+	args = parseFlags(fs, args)
+
+	lang, err := out.language()
+	if err != nil {
+		return err
+	}
+
+	boardCards, err := models.ParseCards(*board)
+	if err != nil {
+		return err
+	}
+
+	holdings, err := parseHoldings(args)
+	if err != nil {
+		return err
+	}
+
+	if len(holdings) == 0 {
+		return fmt.Errorf("No hands to compare")
+	}
+
+	names := make([]string, len(holdings))
+	pockets := make([][]*models.Card, len(holdings))
+
+	for i, h := range holdings {
+		names[i] = h.name
+
+		if pockets[i], err = models.ParseCards(h.spec); err != nil {
+			return err
+		}
+	}
+
+	r, err := rankHoldings(boardCards, names, pockets, lang)
+	if err != nil {
+		return err
+	}
+
+	return out.write(r)
+}
+
+// rankHoldings оценивает лучшие руки игроков names с карманными картами pockets на общих картах board
+// и упорядочивает их от сильнейшей к слабейшей
+func rankHoldings(board []*models.Card, names []string, pockets [][]*models.Card, lang models.Language) (*compareResult, error) {
+	all := append(make([]*models.Card, 0, len(board)), board...)
+
+	for _, pocket := range pockets {
+		all = append(all, pocket...)
+	}
+
+	if err := checkDistinct(all); err != nil {
+		return nil, err
+	}
+
+	r := &compareResult{Board: board}
+
+	for i, pocket := range pockets {
+		best, err := models.GetBestHand(append(append(make([]*models.Card, 0, len(board)+len(pocket)), board...), pocket...))
+		if err != nil {
+			return nil, fmt.Errorf("Can't evaluate hand %s of %d cards on board of %d cards: %s",
+				names[i], len(pocket), len(board), err)
+		}
+
+		r.Hands = append(r.Hands, &holdingResult{
+			Name:        names[i],
+			Pocket:      pocket,
+			Best:        best,
+			Rank:        best.Rank(),
+			Description: best.Describe(lang),
+		})
+	}
+
+	sort.SliceStable(r.Hands, func(i, j int) bool {
+		return r.Hands[i].Rank > r.Hands[j].Rank
+	})
+
+	for i, h := range r.Hands {
+		h.Place = i + 1
+		if i > 0 && h.Rank == r.Hands[i-1].Rank {
+			h.Place = r.Hands[i-1].Place
+		}
+
+		if h.Place == 1 {
+			h.Winner = true
+			r.Winners = append(r.Winners, h.Name)
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"hands/src/models"
+)
+
+// maxDealPlayers - наибольшее количество игроков, которым хватает колоды вместе с общими картами
+const maxDealPlayers = (models.DeckLength - models.BoardSize) / models.PocketSize
+
+// dealResult - случайная раздача холдема со вскрытием. Seed позволяет повторить раздачу
+type dealResult struct {
+	Seed int64
+	*compareResult
+}
+
+func (r *dealResult) writeText(w io.Writer) {
+	fmt.Fprintf(w, "Seed: %d\n", r.Seed)
+	r.compareResult.writeText(w)
+}
+
+// runDeal тасует колоду, раздает игрокам карманные карты по одной по кругу, затем общие карты,
+// и определяет победителей
+func runDeal(args []string, w io.Writer) error {
+	fs, out := newFlagSet("deal", w)
+	players := fs.Int("players", 6, "number of players")
+	seed := fs.Int64("seed", 0, "random seed (0 - seed from the current time)")
+
+	if args = parseFlags(fs, args); len(args) > 0 {
+		return fmt.Errorf("Command deal takes no arguments, got %q", args)
+	}
+
+	lang, err := out.language()
+	if err != nil {
+		return err
+	}
+
+	if *players < 2 || *players > maxDealPlayers {
+		return fmt.Errorf("Wrong number of players %d, must be from 2 to %d", *players, maxDealPlayers)
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	deck := models.NewDeck().ShuffleWithRandom(rand.New(rand.NewSource(*seed)))
+
+	names := make([]string, *players)
+	pockets := make([][]*models.Card, *players)
+
+	for i := range names {
+		names[i] = fmt.Sprintf("p%d", i+1)
+	}
+
+	for round := 0; round < models.PocketSize; round++ {
+		for i := range pockets {
+			c, err := deck.Card()
+			if err != nil {
+				return err
+			}
+
+			pockets[i] = append(pockets[i], c)
+		}
+	}
+
+	board := make([]*models.Card, 0, models.BoardSize)
+
+	for len(board) < models.BoardSize {
+		c, err := deck.Card()
+		if err != nil {
+			return err
+		}
+
+		board = append(board, c)
+	}
+
+	r, err := rankHoldings(board, names, pockets, lang)
+	if err != nil {
+		return err
+	}
+
+	return out.write(&dealResult{Seed: *seed, compareResult: r})
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"text/tabwriter"
+
+	"hands/src/cfr"
+	"hands/src/helpers"
+	"hands/src/models"
+)
+
+// defaultEquitySamples - количество случайных раздач по умолчанию, если хотя бы одна рука задана диапазоном
+const defaultEquitySamples = 20000
+
+// maxComboTries - количество попыток сдать руки из диапазонов без общих карт в одной случайной раздаче
+const maxComboTries = 1000
+
+// randomRange - запись диапазона из всех рук
+const randomRange = "random"
+
+// playerEquity - эквити руки или диапазона Hand. Combos - количество сочетаний карманных карт в диапазоне
+type playerEquity struct {
+	Name   string
+	Hand   string
+	Combos int
+	Equity float64
+}
+
+// equityResult - эквити игроков на общих картах Board. Samples - количество случайных раздач,
+// 0 - эквити известных рук рассчитано models.Equity
+type equityResult struct {
+	Board   []*models.Card
+	Players []*playerEquity
+	Samples int
+}
+
+func (r *equityResult) writeText(w io.Writer) {
+	fmt.Fprintf(w, "Board: %s\n", cardsText(r.Board))
+
+	if r.Samples > 0 {
+		fmt.Fprintf(w, "Samples: %d\n", r.Samples)
+	}
+
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tHAND\tCOMBOS\tEQUITY")
+
+	for _, p := range r.Players {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f%%\n", p.Name, p.Hand, p.Combos, 100*p.Equity)
+	}
+
+	tw.Flush()
+}
+
+// runEquity рассчитывает эквити рук и диапазонов из аргументов. Рука задается картами ("AhKd"),
+// диапазон - классами через запятую ("QQ,JJ,AQs") или словом random
+func runEquity(args []string, w io.Writer) error {
+	fs, out := newFlagSet("equity", w)
+	board := fs.String("board", "", "board cards, e.g. \"Ah Kd 7c\"")
+	samples := fs.Int("samples", defaultEquitySamples, "random deals when some hands are ranges")
+	seed := fs.Int64("seed", 0, "random seed (0 - seed from the current time)")
+
+	args = parseFlags(fs, args)
+
+	boardCards, err := models.ParseCards(*board)
+	if err != nil {
+		return err
+	}
+
+	if len(boardCards) > models.BoardSize {
+		return fmt.Errorf("Wrong board size %d to calculate equity", len(boardCards))
+	}
+
+	holdings, err := parseHoldings(args)
+	if err != nil {
+		return err
+	}
+
+	if len(holdings) < 2 {
+		return fmt.Errorf("At least two hands are required to calculate equity, got %d", len(holdings))
+	}
+
+	ranges, err := parseRanges(holdings, boardCards)
+	if err != nil {
+		return err
+	}
+
+	r := helpers.NewRandom()
+	if *seed != 0 {
+		r = rand.New(rand.NewSource(*seed))
+	}
+
+	res := &equityResult{Board: boardCards}
+
+	for i, h := range holdings {
+		res.Players = append(res.Players, &playerEquity{Name: h.name, Hand: h.spec, Combos: len(ranges[i])})
+	}
+
+	var equities []float64
+
+	if known(ranges) {
+		equities, err = knownEquity(holdings, ranges, boardCards, r)
+	} else {
+		res.Samples = *samples
+		equities, err = rangeEquity(ranges, boardCards, *samples, r)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for i, e := range equities {
+		res.Players[i].Equity = e
+	}
+
+	return out.write(res)
+}
+
+// parseRanges возвращает сочетания карманных карт каждой руки. Карты известных рук и общие карты
+// исключаются из диапазонов
+func parseRanges(holdings []holding, board []*models.Card) ([][][]*models.Card, error) {
+	ranges := make([][][]*models.Card, len(holdings))
+	dead := append(make([]*models.Card, 0, len(board)), board...)
+
+	for i, h := range holdings {
+		if strings.EqualFold(h.spec, randomRange) {
+			continue
+		}
+
+		cards, err := models.ParseCards(h.spec)
+		if err != nil {
+			continue
+		}
+
+		if len(cards) != models.PocketSize {
+			return nil, fmt.Errorf("Hand %s must have %d cards, got %d", h.name, models.PocketSize, len(cards))
+		}
+
+		ranges[i] = [][]*models.Card{cards}
+		dead = append(dead, cards...)
+	}
+
+	if err := checkDistinct(dead); err != nil {
+		return nil, err
+	}
+
+	for i, h := range holdings {
+		if ranges[i] != nil {
+			continue
+		}
+
+		if strings.EqualFold(h.spec, randomRange) {
+			ranges[i] = models.UniformRange(dead)
+		} else {
+			combos, err := cfr.ParseRange(h.spec, dead)
+			if err != nil {
+				return nil, err
+			}
+
+			ranges[i] = combos
+		}
+
+		if len(ranges[i]) == 0 {
+			return nil, fmt.Errorf("Range %s of hand %s has no combos left", h.spec, h.name)
+		}
+	}
+
+	return ranges, nil
+}
+
+// known проверяет, что карты всех рук известны
+func known(ranges [][][]*models.Card) bool {
+	for _, combos := range ranges {
+		if len(combos) != 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// knownEquity рассчитывает эквити известных рук с помощью models.Equity
+func knownEquity(holdings []holding, ranges [][][]*models.Card, board []*models.Card, r *rand.Rand) ([]float64, error) {
+	pockets := make(map[string][]*models.Card, len(holdings))
+	used := models.NewCardSet(board...)
+
+	for i, h := range holdings {
+		pockets[h.name] = ranges[i][0]
+		used = used.Union(models.NewCardSet(ranges[i][0]...))
+	}
+
+	byName, err := models.EquityWithRandom(pockets, board, models.FullDeck.Difference(used).Cards(), r)
+	if err != nil {
+		return nil, err
+	}
+
+	equities := make([]float64, len(holdings))
+	for i, h := range holdings {
+		equities[i] = byName[h.name]
+	}
+
+	return equities, nil
+}
+
+// rangeEquity рассчитывает эквити диапазонов методом Монте-Карло: в каждой из samples раздач игрокам
+// сдаются случайные сочетания их диапазонов без общих карт, затем недостающие общие карты
+func rangeEquity(ranges [][][]*models.Card, board []*models.Card, samples int, r *rand.Rand) ([]float64, error) {
+	if samples < 1 {
+		return nil, fmt.Errorf("Wrong number of samples %d", samples)
+	}
+
+	missing := models.BoardSize - len(board)
+	boardSet := models.NewCardSet(board...)
+	deck := models.NewDeck()
+
+	wins := make([]float64, len(ranges))
+	pockets := make([][]*models.Card, len(ranges))
+	ranks := make([]models.HandRank, len(ranges))
+	full := make([]*models.Card, 0, models.BoardSize)
+	cards := make([]*models.Card, 0, models.BoardSize+models.PocketSize)
+
+	for s := 0; s < samples; s++ {
+		used, ok := boardSet, false
+
+		for try := 0; try < maxComboTries && !ok; try++ {
+			used, ok = boardSet, true
+
+			for i, combos := range ranges {
+				pockets[i] = combos[r.Intn(len(combos))]
+
+				set := models.NewCardSet(pockets[i]...)
+				if used.Intersect(set) != 0 {
+					ok = false
+
+					break
+				}
+
+				used = used.Union(set)
+			}
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("Can't deal hands from the ranges without shared cards")
+		}
+
+		deck.ShuffleSet(models.FullDeck.Difference(used), r)
+		full = append(full[:0], board...)
+
+		for j := 0; j < missing; j++ {
+			c, err := deck.Card()
+			if err != nil {
+				return nil, err
+			}
+
+			full = append(full, c)
+		}
+
+		best := models.HandRank(0)
+
+		for i, pocket := range pockets {
+			ranks[i] = models.RankCards(append(append(cards[:0], full...), pocket...))
+			if ranks[i] > best {
+				best = ranks[i]
+			}
+		}
+
+		ties := 0
+		for _, rank := range ranks {
+			if rank == best {
+				ties++
+			}
+		}
+
+		for i, rank := range ranks {
+			if rank == best {
+				wins[i] += 1 / float64(ties)
+			}
+		}
+	}
+
+	for i := range wins {
+		wins[i] /= float64(samples)
+	}
+
+	return wins, nil
+}
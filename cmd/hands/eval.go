@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"hands/src/models"
+)
+
+// evalResult - лучшая рука из пяти карт среди Cards
+type evalResult struct {
+	Cards       []*models.Card
+	Best        *models.Hand
+	Rank        models.HandRank
+	Value       models.HandValue
+	Description string
+}
+
+func (r *evalResult) writeText(w io.Writer) {
+	fmt.Fprintf(w, "Cards: %s\n", cardsText(r.Cards))
+	fmt.Fprintf(w, "Best:  %s\n", cardsText(r.Best.Slice()))
+	fmt.Fprintf(w, "Hand:  %s (%s)\n", r.Description, r.Rank.Code())
+}
+
+// runEval определяет лучшую руку из общих карт, карманных карт и карт, перечисленных в аргументах
+func runEval(args []string, w io.Writer) error {
+	fs, out := newFlagSet("eval", w)
+	board := fs.String("board", "", "board cards, e.g. \"Ah Kd 7c 2s 2d\"")
+	pocket := fs.String("pocket", "", "pocket cards, e.g. \"As Ks\"")
+
+	args = parseFlags(fs, args)
+
+	lang, err := out.language()
+	if err != nil {
+		return err
+	}
+
+	boardCards, err := models.ParseCards(*board)
+	if err != nil {
+		return err
+	}
+
+	pocketCards, err := models.ParseCards(strings.Join(append([]string{*pocket}, args...), " "))
+	if err != nil {
+		return err
+	}
+
+	cards := append(append(make([]*models.Card, 0, len(boardCards)+len(pocketCards)), boardCards...), pocketCards...)
+
+	if err := checkDistinct(cards); err != nil {
+		return err
+	}
+
+	var best *models.Hand
+
+	if len(cards) == models.BoardSize+models.PocketSize {
+		best, err = models.GetMaxHandWithBoard(models.NewStringSliceFromCards(cards[:models.BoardSize]),
+			models.NewStringSliceFromCards(cards[models.BoardSize:]))
+	} else {
+		best, err = models.GetBestHand(cards)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Can't evaluate %d cards: %s", len(cards), err)
+	}
+
+	return out.write(&evalResult{
+		Cards:       cards,
+		Best:        best,
+		Rank:        best.Rank(),
+		Value:       best.Rank().Value(),
+		Description: best.Describe(lang),
+	})
+}
+
+// checkDistinct проверяет, что карты не повторяются
+func checkDistinct(cards []*models.Card) error {
+	var seen models.CardSet
+
+	for _, c := range cards {
+		if seen.Contains(c) {
+			return models.NewParseError(cardsText([]*models.Card{c}), models.DuplicateCardError)
+		}
+
+		seen = seen.Add(c)
+	}
+
+	return nil
+}
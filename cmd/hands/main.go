@@ -0,0 +1,197 @@
+// Команда hands оценивает руки и раздачи без написания кода: лучшая рука из набора карт, сравнение
+// рук на общих картах с определением победителей, эквити рук и диапазонов, пример случайной раздачи.
+// Карты записываются в любой нотации ParseCards: "Ah Kd", "AhKd", "AH,10D". Руке в compare и equity
+// можно дать имя: "alice=AhKd". Флаг -json выводит результат в формате JSON (см. docs/encoding.md).
+// Флаги можно записывать и после аргументов; аргументы после "--" флагами не считаются.
+//
+//	hands eval -board "Ah Kd 7c 2s 2d" -pocket "As Ks"
+//	hands compare -board "Ah Kd 7c 2s 2d" alice=AsKs bob=7h7d
+//	hands equity -board "Ah Kd 7c" AsKs QQ,JJ,AQs random
+//	hands deal -players 6 -seed 42 -json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"hands/src/models"
+)
+
+// command - подкоманда, которая разбирает свои аргументы и выводит результат
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, w io.Writer) error
+}
+
+// report - результат подкоманды, который выводится текстом или в формате JSON
+type report interface {
+	writeText(w io.Writer)
+}
+
+var commands = []command{
+	{"eval", "best five-card hand from the given cards", runEval},
+	{"compare", "rank holdings on a board and show the winners", runCompare},
+	{"equity", "equities of hands and ranges", runEquity},
+	{"deal", "shuffle and deal a sample hand", runDeal},
+}
+
+// output - общие флаги вывода подкоманд и получатель результата
+type output struct {
+	w    io.Writer
+	json *bool
+	lang *string
+}
+
+func newFlagSet(name string, w io.Writer) (*flag.FlagSet, output) {
+	fs := flag.NewFlagSet("hands "+name, flag.ExitOnError)
+
+	return fs, output{
+		w:    w,
+		json: fs.Bool("json", false, "write the result as JSON"),
+		lang: fs.String("lang", string(models.English), "language of hand descriptions: en or ru"),
+	}
+}
+
+// parseFlags разбирает флаги подкоманды и возвращает остальные аргументы. В отличие от fs.Parse
+// флаги разбираются и после аргументов: "hands eval AsKs -json", иначе "-json" был бы принят за карты
+func parseFlags(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+
+	for {
+		fs.Parse(args)
+
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional
+		}
+
+		// разбор остановлен на "--": все, что после него, - аргументы
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...)
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func (o output) language() (models.Language, error) {
+	switch lang := models.Language(*o.lang); lang {
+	case models.English, models.Russian:
+		return lang, nil
+	}
+
+	return "", fmt.Errorf("Unknown language %s", *o.lang)
+}
+
+// write выводит результат r текстом или в формате JSON
+func (o output) write(r report) error {
+	if !*o.json {
+		r.writeText(o.w)
+
+		return nil
+	}
+
+	enc := json.NewEncoder(o.w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// cardsText возвращает карты через пробел в записи "Ah Td", или "-", если карт нет
+func cardsText(cards []*models.Card) string {
+	if len(cards) == 0 {
+		return "-"
+	}
+
+	names := make([]string, 0, len(cards))
+
+	for _, c := range cards {
+		text, err := c.MarshalText()
+		if err != nil {
+			names = append(names, c.String())
+
+			continue
+		}
+
+		names = append(names, string(text))
+	}
+
+	return strings.Join(names, " ")
+}
+
+// holding - рука из аргумента вида "AhKd" или "alice=AhKd". Без имени рука называется своей записью
+type holding struct {
+	name string
+	spec string
+}
+
+func parseHolding(arg string) holding {
+	if name, spec, ok := strings.Cut(arg, "="); ok {
+		return holding{name: strings.TrimSpace(name), spec: strings.TrimSpace(spec)}
+	}
+
+	return holding{name: arg, spec: arg}
+}
+
+// parseHoldings разбирает руки из аргументов. Имена рук должны быть разными
+func parseHoldings(args []string) ([]holding, error) {
+	holdings := make([]holding, 0, len(args))
+	names := make(map[string]bool, len(args))
+
+	for _, arg := range args {
+		h := parseHolding(arg)
+		if h.name == "" || h.spec == "" {
+			return nil, fmt.Errorf("Wrong hand %q", arg)
+		}
+
+		if names[h.name] {
+			return nil, fmt.Errorf("Hand %s is given twice", h.name)
+		}
+
+		names[h.name] = true
+		holdings = append(holdings, h)
+	}
+
+	return holdings, nil
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: hands <command> [flags] [arguments]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c.name, c.summary)
+	}
+
+	fmt.Fprintln(os.Stderr, "\nRun hands <command> -h for the flags of a command.")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, c := range commands {
+		if c.name != os.Args[1] {
+			continue
+		}
+
+		if err := c.run(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Unknown command %s\n\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
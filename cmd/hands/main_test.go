@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// run выполняет подкоманду и возвращает ее вывод
+func run(t *testing.T, cmd func(args []string, w io.Writer) error, args ...string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := cmd(args, &buf); err != nil {
+		t.Fatalf("%q: %s", args, err)
+	}
+
+	return buf.String()
+}
+
+// runJSON выполняет подкоманду с флагом -json и разбирает вывод в v
+func runJSON(t *testing.T, cmd func(args []string, w io.Writer) error, v interface{}, args ...string) {
+	t.Helper()
+
+	if err := json.Unmarshal([]byte(run(t, cmd, append(args, "-json")...)), v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		args  []string
+		want  []string
+		json  bool
+		board string
+	}{
+		{args: []string{"AsKs", "-json"}, want: []string{"AsKs"}, json: true},
+		{args: []string{"-board", "Ah Kd 7c", "AsKs", "-json", "QQ"}, want: []string{"AsKs", "QQ"}, json: true, board: "Ah Kd 7c"},
+		{args: []string{"AsKs", "QQ", "-board=Ah Kd 7c"}, want: []string{"AsKs", "QQ"}, board: "Ah Kd 7c"},
+		// после "--" флагов нет
+		{args: []string{"AsKs", "--", "-json"}, want: []string{"AsKs", "-json"}},
+		{args: []string{"-json"}, json: true},
+	}
+
+	for _, tt := range tests {
+		fs, out := newFlagSet("test", io.Discard)
+		board := fs.String("board", "", "")
+
+		got := parseFlags(fs, tt.args)
+
+		if !reflect.DeepEqual(got, tt.want) || *out.json != tt.json || *board != tt.board {
+			t.Errorf("parseFlags(%q) = %q, json %t, board %q", tt.args, got, *out.json, *board)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{
+			args: []string{"-board", "Ah Kd 7c 2s 2d", "-pocket", "As Ks"},
+			want: "Hand:  Two Pair, Aces and Kings with a Seven kicker (2P:AK7)",
+		},
+		// флаг после карт не принимается за карту
+		{args: []string{"As", "Ks", "-board", "Ah Kd 7c 2s 2d"}, want: "Best:  Ah Kd 7c As Ks"},
+		{args: []string{"AhKhQhJhTh", "-lang", "ru"}, want: "Роял-флэш"},
+	}
+
+	for _, tt := range tests {
+		if got := run(t, runEval, tt.args...); !strings.Contains(got, tt.want) {
+			t.Errorf("eval %q = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	var res struct {
+		Cards       []string
+		Best        string
+		Rank        string
+		Value       string
+		Description string
+	}
+
+	runJSON(t, runEval, &res, "AsKs", "-board", "Ah Kd 7c 2s 2d")
+
+	if len(res.Cards) != 7 || res.Best != "Ah Kd 7c As Ks" || res.Rank != "2P:AK7" || res.Value != "2P" {
+		t.Errorf("eval -json = %+v", res)
+	}
+
+	for _, args := range [][]string{{"As Ks As"}, {"As Xs"}, {"As", "-lang", "de"}} {
+		if err := runEval(args, io.Discard); err == nil {
+			t.Errorf("eval %q succeeded", args)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	type hand struct {
+		Name   string
+		Pocket []string
+		Rank   string
+		Place  int
+		Winner bool
+	}
+
+	var res struct {
+		Board   []string
+		Hands   []hand
+		Winners []string
+	}
+
+	runJSON(t, runCompare, &res, "alice=AsKs", "bob=7h7d", "carol=QcJc", "-board", "Ah Kd 7c 2s 2d")
+
+	places := make([]string, 0, len(res.Hands))
+	for _, h := range res.Hands {
+		places = append(places, h.Name)
+	}
+
+	if !reflect.DeepEqual(places, []string{"bob", "alice", "carol"}) || !reflect.DeepEqual(res.Winners, []string{"bob"}) {
+		t.Errorf("compare = %v, winners %v, want bob, alice, carol and bob winning", places, res.Winners)
+	}
+
+	// стрит-флэш на столе: банк делят все
+	runJSON(t, runCompare, &res, "-board", "Ah Kh Qh Jh Th", "2c3c", "4d5d")
+
+	if len(res.Winners) != 2 || res.Hands[0].Place != 1 || res.Hands[1].Place != 1 {
+		t.Errorf("compare on a royal flush board = %+v, want a split", res)
+	}
+
+	if got := run(t, runCompare, "-board", "Ah Kd 7c 2s 2d", "alice=AsKs", "bob=7h7d"); !strings.Contains(got, "Winners: bob") {
+		t.Errorf("compare = %q, want bob winning", got)
+	}
+
+	for _, args := range [][]string{
+		{"-board", "Ah Kd 7c 2s 2d"},
+		{"-board", "Ah Kd 7c 2s 2d", "a=AsKs", "a=7h7d"},
+		{"-board", "Ah Kd 7c 2s 2d", "AhKs", "7h7d"},
+	} {
+		if err := runCompare(args, io.Discard); err == nil {
+			t.Errorf("compare %q succeeded", args)
+		}
+	}
+}
+
+func TestEquity(t *testing.T) {
+	type player struct {
+		Name   string
+		Hand   string
+		Combos int
+		Equity float64
+	}
+
+	var res struct {
+		Board   []string
+		Players []player
+		Samples int
+	}
+
+	// на терне королям помогают только два оставшихся короля из 44 карт
+	runJSON(t, runEquity, &res, "-board", "2c 7h 9d Jc", "aces=AsAd", "kings=KsKd")
+
+	if res.Samples != 0 || len(res.Players) != 2 || math.Abs(res.Players[0].Equity-42.0/44) > 1e-9 {
+		t.Errorf("equity = %+v, want 42/44 for aces", res)
+	}
+
+	runJSON(t, runEquity, &res, "AA", "KK", "-samples", "5000", "-seed", "1")
+
+	if res.Samples != 5000 || res.Players[0].Combos != 6 || math.Abs(res.Players[0].Equity-0.82) > 0.03 {
+		t.Errorf("range equity = %+v, want about 0.82 for AA", res)
+	}
+
+	if math.Abs(res.Players[0].Equity+res.Players[1].Equity-1) > 1e-9 {
+		t.Errorf("equities sum to %.4f", res.Players[0].Equity+res.Players[1].Equity)
+	}
+
+	if got := run(t, runEquity, "AsAd", "random", "-seed", "1", "-samples", "100"); !strings.Contains(got, "Samples: 100") {
+		t.Errorf("equity = %q, want 100 samples", got)
+	}
+
+	for _, args := range [][]string{
+		{"AsAd"},
+		{"AsAd", "AsKd"},
+		{"AsAd", "KK", "-samples", "0"},
+		{"AsAdKs", "KK"},
+		{"-board", "2c 7h 9d Jc 3s 4s", "AsAd", "KK"},
+	} {
+		if err := runEquity(args, io.Discard); err == nil {
+			t.Errorf("equity %q succeeded", args)
+		}
+	}
+}
+
+func TestDeal(t *testing.T) {
+	var res struct {
+		Seed    int64
+		Board   []string
+		Hands   []struct{ Pocket []string }
+		Winners []string
+	}
+
+	runJSON(t, runDeal, &res, "-players", "3", "-seed", "42")
+
+	if res.Seed != 42 || len(res.Board) != 5 || len(res.Hands) != 3 || len(res.Winners) == 0 {
+		t.Errorf("deal = %+v", res)
+	}
+
+	// одно и то же зерно дает одну и ту же раздачу
+	if one, other := run(t, runDeal, "-seed", "7"), run(t, runDeal, "-seed", "7"); one != other {
+		t.Errorf("deals with the same seed differ:\n%s\n%s", one, other)
+	}
+
+	for _, args := range [][]string{{"-players", "1"}, {"-players", "24"}, {"3"}} {
+		if err := runDeal(args, io.Discard); err == nil {
+			t.Errorf("deal %q succeeded", args)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Команда sim проводит игру ботов друг против друга и выводит их результаты в bb/100.
+//
+//	sim -bots tag,equity,random -hands 100000 -workers 8 -seed 1
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+
+	"hands/src/sim"
+)
+
+func main() {
+	botsFlag := flag.String("bots", "tag,random", "comma-separated bots: "+strings.Join(sim.BotNames(), ", "))
+	hands := flag.Int("hands", 10000, "number of hands to play")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of parallel tables")
+	seed := flag.Int64("seed", 1, "random seed")
+	bb := flag.Int("bb", 2, "big blind")
+	sb := flag.Int("sb", 1, "small blind")
+	stack := flag.Int("stack", sim.DefaultStackBB, "starting stack in big blinds")
+
+	flag.Parse()
+
+	bots, err := sim.ParseBots(strings.Split(*botsFlag, ","))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	results, err := sim.Run(sim.Config{
+		Bots:       bots,
+		Hands:      *hands,
+		Workers:    *workers,
+		Seed:       *seed,
+		BigBlind:   *bb,
+		SmallBlind: *sb,
+		StackBB:    *stack,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "bot\thands\tbb/100\t95% CI\t")
+
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%d\t%.2f\t±%.2f\t\n", r.Name, r.Hands, r.BBPer100, r.CI95)
+	}
+
+	w.Flush()
+}
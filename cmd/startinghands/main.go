@@ -0,0 +1,114 @@
+// Команда startinghands пересчитывает таблицу эквити 169 стартовых рук против 1..9 случайных рук
+// и записывает ее в исходный файл пакета models.
+//
+//	go generate ./src/models
+//	startinghands -samples 20000 -out src/models/starting_hands_equity.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"math/rand"
+	"os"
+	"runtime"
+	"sync"
+
+	"hands/src/models"
+)
+
+func main() {
+	samples := flag.Int("samples", 20000, "random deals per hand and number of opponents")
+	seed := flag.Int64("seed", 1, "random seed")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of parallel workers")
+	out := flag.String("out", "", "file to write the table to (stdout if empty)")
+
+	flag.Parse()
+
+	hands := models.StartingHands()
+	equity := make([][models.MaxOpponents]float64, len(hands))
+	errs := make([]error, len(hands))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < *workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				// генератор зависит только от руки, поэтому таблица не зависит от количества горутин
+				r := rand.New(rand.NewSource(*seed + int64(i)))
+
+				for opponents := 1; opponents <= models.MaxOpponents; opponents++ {
+					e, err := models.EquityVsRandom(hands[i].Pocket(), nil, opponents, *samples, r)
+					if err != nil {
+						errs[i] = err
+
+						break
+					}
+
+					equity[i][opponents-1] = e
+				}
+			}
+		}()
+	}
+
+	for i := range hands {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by cmd/startinghands; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package models\n\n")
+	fmt.Fprintf(&buf, "// startingHandsEquity - эквити стартовых рук в олл-ине против 1..%d случайных рук, %d раздач\n",
+		models.MaxOpponents, *samples)
+	fmt.Fprintf(&buf, "var startingHandsEquity = map[string][MaxOpponents]float64{\n")
+
+	for i, h := range hands {
+		fmt.Fprintf(&buf, "%q: {", h.String())
+
+		for j, e := range equity[i] {
+			if j > 0 {
+				fmt.Fprint(&buf, ", ")
+			}
+
+			fmt.Fprintf(&buf, "%.4f", e)
+		}
+
+		fmt.Fprintf(&buf, "},\n")
+	}
+
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+
+		return
+	}
+
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
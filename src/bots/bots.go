@@ -0,0 +1,127 @@
+package bots
+
+import (
+	"fmt"
+
+	"hands/src/models"
+)
+
+// Decision - решение стратегии. Amount - размер повышения сверх текущей ставки, только для RaiseAction
+type Decision struct {
+	Action models.ActionType
+	Amount models.Chips
+}
+
+// Strategy - стратегия игры бота. Получает стол глазами игрока и возвращает действие
+type Strategy interface {
+	Decide(view *models.TableView) Decision
+}
+
+// passive возвращает пассивное решение: чек, если он допустим, иначе сброс карт
+func passive(view *models.TableView) Decision {
+	if view.CanAct(models.CheckAction) {
+		return Decision{Action: models.CheckAction}
+	}
+
+	return Decision{Action: models.FallAction}
+}
+
+// raise возвращает повышение на долю банка fraction, ограниченное минимальным повышением и олл-ином.
+// Если повышение недопустимо, возвращает колл (или чек)
+func raise(view *models.TableView, fraction float64) Decision {
+	if !view.CanAct(models.RaiseAction) {
+		return call(view)
+	}
+
+	amount := models.Chips(fraction * float64(view.Pot+view.ToCall))
+
+	if amount < view.MinRaise {
+		amount = view.MinRaise
+	}
+
+	if amount > view.MaxRaise() {
+		amount = view.MaxRaise()
+	}
+
+	return Decision{Action: models.RaiseAction, Amount: amount}
+}
+
+// call возвращает колл, или чек, если ставку уравнивать не нужно
+func call(view *models.TableView) Decision {
+	if view.CanAct(models.CallAction) {
+		return Decision{Action: models.CallAction}
+	}
+
+	return passive(view)
+}
+
+// PlayRound проводит круг торговли на столе t: каждый игрок действует согласно своей стратегии
+// из strategies (по ID игроков). Недопустимое решение стратегии заменяется чеком или сбросом карт.
+// В дро-покере игроки меняют карты по простым правилам (см. drawCards), а в пайнэппле сбрасывают карты,
+// меньше всего усиливающие руку
+func PlayRound(t *models.Table, strategies map[string]Strategy) error {
+	for p := t.NextToAct(); p != nil; p = t.NextToAct() {
+		s, ok := strategies[p.ID]
+		if !ok {
+			return fmt.Errorf("No strategy for player with ID %s", p.ID)
+		}
+
+		view, err := t.ViewFor(p.ID)
+		if err != nil {
+			return err
+		}
+
+		// стратегии решают только ставки, а карты боты меняют и сбрасывают одинаково
+		if view.Drawing && view.Discard > 0 {
+			if err := t.Discard(p.ID, worstCards(view)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if view.Drawing {
+			if _, err := t.Draw(p.ID, drawCards(view)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		d := s.Decide(view)
+
+		if _, err := t.Act(p.ID, d.Action, d.Amount); err != nil {
+			d = passive(view)
+
+			if _, err := t.Act(p.ID, d.Action, d.Amount); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// worstCards жадно выбирает view.Discard карманных карт, без которых оставшиеся карты
+// вместе с общими образуют самую сильную руку
+func worstCards(view *models.TableView) []*models.Card {
+	pocket := append(make([]*models.Card, 0, len(view.Pocket)), view.Pocket...)
+	discards := make([]*models.Card, 0, view.Discard)
+
+	for len(discards) < view.Discard && len(pocket) > 0 {
+		worst, best := 0, models.HandRank(0)
+
+		for i := range pocket {
+			rest := append(append(make([]*models.Card, 0, len(pocket)-1), pocket[:i]...), pocket[i+1:]...)
+
+			if r := models.RankCards(append(rest, view.Board...)); i == 0 || r > best {
+				worst, best = i, r
+			}
+		}
+
+		discards = append(discards, pocket[worst])
+		pocket = append(pocket[:worst], pocket[worst+1:]...)
+	}
+
+	return discards
+}
@@ -0,0 +1,175 @@
+package bots
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"hands/src/helpers"
+	"hands/src/models"
+)
+
+// values возвращает значения карт cards по возрастанию, например "27K"
+func values(cards []*models.Card) string {
+	sorted := append(make([]*models.Card, 0, len(cards)), cards...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Value < sorted[j].Value
+	})
+
+	var b strings.Builder
+	for _, c := range sorted {
+		b.WriteByte("23456789TJQKA"[c.Value-models.Two])
+	}
+
+	return b.String()
+}
+
+func TestDrawCards(t *testing.T) {
+	tests := []struct {
+		variant models.Variant
+		pocket  string
+		want    string
+	}{
+		{variant: models.TripleDrawVariant, pocket: "7s 5d 4c 3h 2s", want: ""},
+		{variant: models.TripleDrawVariant, pocket: "9s 7d 5c 3h 2s", want: ""},
+		{variant: models.TripleDrawVariant, pocket: "Ks 7d 5c 3h 2s", want: "K"},
+		{variant: models.TripleDrawVariant, pocket: "7s 7d 5c 3h 2s", want: "7"},
+		// в 2-7 туз старший, а стрит считается против игрока
+		{variant: models.TripleDrawVariant, pocket: "As 4d 3c 2h 6s", want: "A"},
+		{variant: models.TripleDrawVariant, pocket: "8s 7d 6c 5h 4s", want: "8"},
+		{variant: models.TripleDrawVariant, pocket: "Ts Jd 3c 2h 6s", want: "TJ"},
+
+		{variant: models.FiveCardDrawVariant, pocket: "Ah Kh Qh Jh Th", want: ""},
+		{variant: models.FiveCardDrawVariant, pocket: "9s 9d 9c 4h 4s", want: ""},
+		{variant: models.FiveCardDrawVariant, pocket: "Ah Kh 7h 2h 9c", want: "9"},
+		{variant: models.FiveCardDrawVariant, pocket: "Qs Qd 7c 4h 2s", want: "247"},
+		{variant: models.FiveCardDrawVariant, pocket: "Qs Qd 7c 7h 2s", want: "2"},
+		{variant: models.FiveCardDrawVariant, pocket: "As Jd 7c 4h 2s", want: "247J"},
+	}
+
+	for _, tt := range tests {
+		view := &models.TableView{Variant: tt.variant.Name(), Pocket: models.MustParse(tt.pocket), Drawing: true}
+
+		if got := values(drawCards(view)); got != tt.want {
+			t.Errorf("%s: drawCards(%s) = %q, want %q", tt.variant.Name(), tt.pocket, got, tt.want)
+		}
+	}
+}
+
+// checkedStrategy проверяет, что решения стратегии допустимы на столе, который видит игрок
+type checkedStrategy struct {
+	t        *testing.T
+	name     string
+	strategy Strategy
+	// decisions - количество принятых решений
+	decisions int
+}
+
+func (s *checkedStrategy) Decide(view *models.TableView) Decision {
+	d := s.strategy.Decide(view)
+	s.decisions++
+
+	if !view.CanAct(d.Action) {
+		s.t.Errorf("%s chose %s on %s, legal %v", s.name, d.Action, view.Street, view.Legal)
+	}
+
+	// короткий стек может повысить меньше минимума только олл-ином
+	short := d.Amount < view.MinRaise && d.Amount != view.MaxRaise()
+
+	if d.Action == models.RaiseAction && (d.Amount > view.MaxRaise() || short) {
+		s.t.Errorf("%s raised by %d, min %d, max %d", s.name, d.Amount, view.MinRaise, view.MaxRaise())
+	}
+
+	return d
+}
+
+func TestStrategiesReturnLegalActions(t *testing.T) {
+	const stack = 1000
+
+	strategies := map[string]func(r *rand.Rand) Strategy{
+		"random": func(r *rand.Rand) Strategy { return NewRandomStrategy(r) },
+		"tag":    func(r *rand.Rand) Strategy { return NewTightAggressiveStrategy() },
+		"equity": func(r *rand.Rand) Strategy { return NewEquityStrategy(100, r) },
+	}
+
+	variants := []models.Variant{
+		models.HoldemVariant, models.OmahaVariant, models.PineappleVariant, models.StudVariant,
+		models.TripleDrawVariant, models.FiveCardDrawVariant,
+	}
+
+	for name, newStrategy := range strategies {
+		for _, variant := range variants {
+			t.Run(name+"/"+variant.Name(), func(t *testing.T) {
+				r := rand.New(rand.NewSource(1))
+				table := models.NewTable("test", "t", helpers.NewDefaultIdGenerator(), models.CasheTableType, 3, 10, 5).
+					WithRandom(r).
+					WithVariant(variant)
+
+				players := make([]*models.Player, 3)
+				checked := make(map[string]Strategy)
+
+				for i := range players {
+					players[i] = models.NewPlayer("p", helpers.NewDefaultIdGenerator(), stack)
+
+					if err := table.Register(players[i]); err != nil {
+						t.Fatal(err)
+					}
+
+					checked[players[i].ID] = &checkedStrategy{t: t, name: name, strategy: newStrategy(r)}
+				}
+
+				for h := 0; h < 20; h++ {
+					for _, p := range players {
+						if diff := stack - p.GetCurrentChipsAmount(); diff > 0 {
+							p.AddChips(diff)
+						}
+					}
+
+					playHand(t, table, checked)
+				}
+
+				decisions := 0
+				for _, s := range checked {
+					decisions += s.(*checkedStrategy).decisions
+				}
+
+				if decisions == 0 {
+					t.Error("strategies made no decisions")
+				}
+			})
+		}
+	}
+}
+
+// playHand разыгрывает раздачу до конца: раздает улицы, проводит круги торговли и делит банк
+func playHand(t *testing.T, table *models.Table, strategies map[string]Strategy) {
+	t.Helper()
+
+	if _, err := table.NewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, deal := range table.GetDealFuncs() {
+		if _, err := deal(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := PlayRound(table, strategies); err != nil {
+			t.Fatal(err)
+		}
+
+		if table.IsHandOver() {
+			break
+		}
+	}
+
+	runouts, err := table.Showdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := table.Award(table.SplitRunouts(runouts)); err != nil {
+		t.Fatal(err)
+	}
+}
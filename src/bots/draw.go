@@ -0,0 +1,117 @@
+package bots
+
+import (
+	"sort"
+
+	"hands/src/models"
+)
+
+const (
+	// lowballKeepMax - старшая карта, которую бот оставляет при обмене в 2-7 лоуболе
+	lowballKeepMax = models.Eight
+	// lowballPatMax - старшая карта готовой руки 2-7 лоубола, с которой бот стоит пат
+	lowballPatMax = models.Nine
+)
+
+// drawCards выбирает карты для обмена в дро-покере по простым правилам: в 2-7 лоуболе сбрасываются
+// пары, стриты, флэши и карты старше восьмерки, в дро-покере на старшую руку - карты вне комбинации
+func drawCards(view *models.TableView) []*models.Card {
+	if view.Variant == models.TripleDrawVariant.Name() {
+		return lowballDiscards(view.Pocket)
+	}
+
+	return highDiscards(view.Pocket)
+}
+
+// lowballDiscards возвращает карты для обмена в 2-7 лоуболе. Готовая рука без пар, стрита и флэша
+// не старше девятки не меняется
+func lowballDiscards(pocket []*models.Card) []*models.Card {
+	rank := models.DeuceToSevenRank(pocket)
+	if len(pocket) == models.HandSize && rank.Value() == models.HighCardHand && rank.HandRank().High() <= lowballPatMax {
+		return nil
+	}
+
+	cards := append(make([]*models.Card, 0, len(pocket)), pocket...)
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].Value < cards[j].Value
+	})
+
+	var kept [models.Ace + 1]bool
+
+	discards := make([]*models.Card, 0, len(cards))
+
+	for _, c := range cards {
+		if c.Value > lowballKeepMax || kept[c.Value] {
+			discards = append(discards, c)
+
+			continue
+		}
+
+		kept[c.Value] = true
+	}
+
+	// все карты младшие и разные, но образуют стрит или флэш - меняем старшую
+	if len(discards) == 0 && len(cards) > 0 {
+		discards = append(discards, cards[len(cards)-1])
+	}
+
+	return discards
+}
+
+// highDiscards возвращает карты для обмена в дро-покере на старшую руку: со стритом и сильнее бот стоит пат,
+// с четырьмя картами к флэшу меняет пятую, с парой и сильнее меняет карты вне комбинации,
+// а без пары оставляет только старшую карту
+func highDiscards(pocket []*models.Card) []*models.Card {
+	if models.RankCards(pocket).Value() >= models.StraightHand {
+		return nil
+	}
+
+	var (
+		counts [models.Ace + 1]int
+		suits  = make(map[models.Suite]int, models.SuitesNum)
+	)
+
+	for _, c := range pocket {
+		counts[c.Value]++
+		suits[c.Suite.Suite]++
+	}
+
+	discards := make([]*models.Card, 0, len(pocket))
+
+	for suit, n := range suits {
+		if n != models.HandSize-1 {
+			continue
+		}
+
+		for _, c := range pocket {
+			if c.Suite.Suite != suit {
+				discards = append(discards, c)
+			}
+		}
+
+		return discards
+	}
+
+	high := pocket[0]
+
+	for _, c := range pocket {
+		if counts[c.Value] == 1 {
+			discards = append(discards, c)
+		}
+
+		if c.Value > high.Value {
+			high = c
+		}
+	}
+
+	// пары нет - оставляем старшую карту
+	if len(discards) == len(pocket) {
+		for i, c := range discards {
+			if c == high {
+				return append(discards[:i], discards[i+1:]...)
+			}
+		}
+	}
+
+	return discards
+}
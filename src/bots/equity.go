@@ -0,0 +1,50 @@
+package bots
+
+import (
+	"math/rand"
+
+	"hands/src/models"
+)
+
+const (
+	// DefaultEquitySamples - количество случайных раздач при оценке эквити
+	DefaultEquitySamples = 500
+	// raiseEquityFactor - во сколько раз эквити должно превышать "справедливую" долю банка для повышения
+	raiseEquityFactor = 1.5
+)
+
+// EquityStrategy оценивает эквити руки против случайных рук соперников методом Монте-Карло
+// и сравнивает его с шансами банка: уравнивает, если эквити не меньше шансов банка,
+// и повышает, если эквити заметно больше доли банка, приходящейся на одного игрока
+type EquityStrategy struct {
+	Samples int
+	r       *rand.Rand
+}
+
+func NewEquityStrategy(samples int, r *rand.Rand) *EquityStrategy {
+	return &EquityStrategy{
+		Samples: samples,
+		r:       r,
+	}
+}
+
+func (s *EquityStrategy) Decide(view *models.TableView) Decision {
+	opponents := view.Opponents()
+
+	equity, err := models.EquityVsRandom(view.Pocket, view.Board, opponents, s.Samples, s.r)
+	if err != nil {
+		return passive(view)
+	}
+
+	fair := 1 / float64(opponents+1)
+	potOdds := float64(view.ToCall) / float64(view.Pot+view.ToCall)
+
+	switch {
+	case equity >= fair*raiseEquityFactor:
+		return raise(view, equity)
+	case view.ToCall > 0 && equity >= potOdds:
+		return call(view)
+	}
+
+	return passive(view)
+}
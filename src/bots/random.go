@@ -0,0 +1,34 @@
+package bots
+
+import (
+	"math/rand"
+
+	"hands/src/models"
+)
+
+// RandomStrategy выбирает случайное допустимое действие, а при повышении - случайный его размер
+type RandomStrategy struct {
+	r *rand.Rand
+}
+
+func NewRandomStrategy(r *rand.Rand) *RandomStrategy {
+	return &RandomStrategy{r: r}
+}
+
+func (s *RandomStrategy) Decide(view *models.TableView) Decision {
+	action := view.Legal[s.r.Intn(len(view.Legal))]
+
+	if action != models.RaiseAction {
+		return Decision{Action: action}
+	}
+
+	low, high := view.MinRaise, view.MaxRaise()
+	if low > high {
+		low = high
+	}
+
+	return Decision{
+		Action: models.RaiseAction,
+		Amount: low + models.Chips(s.r.Int63n(int64(high-low)+1)),
+	}
+}
@@ -0,0 +1,144 @@
+package bots
+
+import (
+	"math/rand"
+	"os"
+
+	"hands/src/cfr"
+	"hands/src/models"
+)
+
+// SolvedStrategy играет по таблице стратегии, найденной cfr.Solver: "олл-ин или пас" на префлопе
+// (игра pushfold) или подыгру на ривере (игра river). В ситуациях, которых нет в таблице, бот чекает или уравнивает
+type SolvedStrategy struct {
+	Table *cfr.StrategyTable
+	r     *rand.Rand
+}
+
+func NewSolvedStrategy(table *cfr.StrategyTable, r *rand.Rand) *SolvedStrategy {
+	return &SolvedStrategy{
+		Table: table,
+		r:     r,
+	}
+}
+
+// LoadSolvedStrategy загружает таблицу стратегии из файла path, записанного cfr.StrategyTable.Save
+func LoadSolvedStrategy(path string, r *rand.Rand) (*SolvedStrategy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	table, err := cfr.LoadStrategyTable(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSolvedStrategy(table, r), nil
+}
+
+func (s *SolvedStrategy) Decide(view *models.TableView) Decision {
+	key, ok := s.key(view)
+	if !ok {
+		return call(view)
+	}
+
+	action, ok := s.Table.Sample(key, s.r)
+	if !ok {
+		return call(view)
+	}
+
+	switch action {
+	case cfr.FoldAction, cfr.PassAction:
+		return passive(view)
+	case cfr.PushAction:
+		return push(view)
+	case cfr.BetAction, cfr.RaiseAction:
+		return s.bet(view)
+	}
+
+	return call(view)
+}
+
+// key возвращает ключ информационного множества, соответствующий положению игрока за столом
+func (s *SolvedStrategy) key(view *models.TableView) (string, bool) {
+	switch {
+	case s.Table.Game == cfr.PushFoldGame && view.Street == models.PreFlopStreet:
+		return cfr.PushFoldKey(view.Pocket, streetRaised(view, models.PreFlopStreet)), true
+	case s.Table.Game == cfr.RiverGame && view.Street == models.RiverStreet:
+		return cfr.RiverKey(view.Pocket, riverHistory(view)), true
+	}
+
+	return "", false
+}
+
+// bet возвращает ставку размера BetSize из таблицы, ограниченную минимальным повышением и олл-ином
+func (s *SolvedStrategy) bet(view *models.TableView) Decision {
+	if !view.CanAct(models.RaiseAction) {
+		return call(view)
+	}
+
+	amount := models.Chips(s.Table.BetSize)
+
+	if amount < view.MinRaise {
+		amount = view.MinRaise
+	}
+
+	if amount > view.MaxRaise() {
+		amount = view.MaxRaise()
+	}
+
+	return Decision{Action: models.RaiseAction, Amount: amount}
+}
+
+// push возвращает олл-ин, или колл, если повысить нельзя
+func push(view *models.TableView) Decision {
+	if !view.CanAct(models.RaiseAction) {
+		return call(view)
+	}
+
+	return Decision{Action: models.RaiseAction, Amount: view.MaxRaise()}
+}
+
+// streetRaised проверяет, было ли повышение на улице street
+func streetRaised(view *models.TableView, street models.Street) bool {
+	for _, a := range view.Actions {
+		if a.Street == street && a.Type == models.RaiseAction {
+			return true
+		}
+	}
+
+	return false
+}
+
+// riverHistory переводит действия на ривере в обозначения cfr: чек, ставка, повышение, колл и сброс
+func riverHistory(view *models.TableView) string {
+	history := ""
+	bet := false
+
+	for _, a := range view.Actions {
+		if a.Street != models.RiverStreet {
+			continue
+		}
+
+		switch a.Type {
+		case models.CheckAction:
+			history += cfr.PassAction
+		case models.CallAction:
+			history += cfr.CallAction
+		case models.FallAction:
+			history += cfr.FoldAction
+		case models.RaiseAction:
+			if bet {
+				history += cfr.RaiseAction
+			} else {
+				history += cfr.BetAction
+			}
+
+			bet = true
+		}
+	}
+
+	return history
+}
@@ -0,0 +1,66 @@
+package bots
+
+import "hands/src/models"
+
+const (
+	// DefaultRaiseScore - оценка Чена, начиная с которой тайтово-агрессивный бот повышает на префлопе
+	DefaultRaiseScore = 10
+	// DefaultCallScore - оценка Чена, начиная с которой тайтово-агрессивный бот уравнивает на префлопе
+	DefaultCallScore = 8
+	// maxCallBlinds - максимальная ставка (в больших блайндах), которую бот уравнивает со средней рукой
+	maxCallBlinds = 4
+)
+
+// TightAggressiveStrategy играет узкий диапазон рук агрессивно: на префлопе решение принимается
+// по оценке Чена, после флопа - по величине собранной комбинации
+type TightAggressiveStrategy struct {
+	RaiseScore int
+	CallScore  int
+}
+
+func NewTightAggressiveStrategy() *TightAggressiveStrategy {
+	return &TightAggressiveStrategy{
+		RaiseScore: DefaultRaiseScore,
+		CallScore:  DefaultCallScore,
+	}
+}
+
+func (s *TightAggressiveStrategy) Decide(view *models.TableView) Decision {
+	if view.Street == models.PreFlopStreet {
+		return s.preflop(view)
+	}
+
+	return s.postflop(view)
+}
+
+func (s *TightAggressiveStrategy) preflop(view *models.TableView) Decision {
+	score, err := models.ChenScore(view.Pocket)
+	if err != nil {
+		return passive(view)
+	}
+
+	switch {
+	case score >= s.RaiseScore:
+		return raise(view, 1)
+	case score >= s.CallScore && view.ToCall <= maxCallBlinds*view.BigBlind:
+		return call(view)
+	}
+
+	return passive(view)
+}
+
+func (s *TightAggressiveStrategy) postflop(view *models.TableView) Decision {
+	hand, err := models.GetBestHand(append(append(make([]*models.Card, 0), view.Pocket...), view.Board...))
+	if err != nil {
+		return passive(view)
+	}
+
+	switch value := hand.Define(); {
+	case value >= models.TwoPairHand:
+		return raise(view, 2.0/3)
+	case value == models.PairHand && view.ToCall <= view.Pot/2:
+		return call(view)
+	}
+
+	return passive(view)
+}
@@ -0,0 +1,372 @@
+// Package cfr решает игры с неполной информацией методом минимизации контрфактического сожаления
+// (CFR и CFR+) и сохраняет найденные стратегии в таблицы, которые могут загружать боты.
+package cfr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+)
+
+// PlayersNum - количество игроков в решаемых играх
+const PlayersNum = 2
+
+// Названия игр
+const (
+	KuhnGame     = "kuhn"
+	LeducGame    = "leduc"
+	PushFoldGame = "pushfold"
+	RiverGame    = "river"
+)
+
+// Outcome - исход случайного события (раздачи карт) и его вероятность
+type Outcome struct {
+	State       State
+	Probability float64
+}
+
+// State - узел дерева игры двух игроков с нулевой суммой
+type State interface {
+	// IsTerminal - игра окончена
+	IsTerminal() bool
+	// Payoff возвращает выигрыш первого игрока в конечном узле. Выигрыш второго - с обратным знаком
+	Payoff() float64
+	// IsChance - узел случайного события
+	IsChance() bool
+	// Outcomes возвращает исходы случайного события
+	Outcomes() []Outcome
+	// Player возвращает номер игрока (0 или 1), который действует в узле
+	Player() int
+	// InfoSet возвращает ключ информационного множества - всего, что знает действующий игрок
+	InfoSet() string
+	// Actions возвращает допустимые действия
+	Actions() []string
+	// Play возвращает узел после действия с индексом action
+	Play(action int) State
+}
+
+// Game - игра, которую решает Solver
+type Game interface {
+	Name() string
+	Root() State
+}
+
+// betSizer - игра с фиксированным размером ставки
+type betSizer interface {
+	BetSize() float64
+}
+
+// node - накопленные сожаления и стратегии информационного множества
+type node struct {
+	actions     []string
+	regrets     []float64
+	strategySum []float64
+}
+
+func newNode(actions []string) *node {
+	return &node{
+		actions:     actions,
+		regrets:     make([]float64, len(actions)),
+		strategySum: make([]float64, len(actions)),
+	}
+}
+
+// strategy возвращает текущую стратегию по сопоставлению сожалений (regret matching)
+func (n *node) strategy() []float64 {
+	return normalize(n.regrets)
+}
+
+// average возвращает среднюю стратегию, сходящуюся к равновесию
+func (n *node) average() []float64 {
+	return normalize(n.strategySum)
+}
+
+// normalize возвращает положительные части values, нормированные к единице,
+// или равномерное распределение, если положительных значений нет
+func normalize(values []float64) []float64 {
+	probs := make([]float64, len(values))
+	sum := 0.0
+
+	for i, v := range values {
+		if v > 0 {
+			probs[i] = v
+			sum += v
+		}
+	}
+
+	for i := range probs {
+		if sum > 0 {
+			probs[i] /= sum
+		} else {
+			probs[i] = 1 / float64(len(probs))
+		}
+	}
+
+	return probs
+}
+
+// Solver решает игру методом CFR или CFR+.
+// CFR+ обнуляет отрицательные сожаления и взвешивает среднюю стратегию номером итерации,
+// что заметно ускоряет сходимость
+type Solver struct {
+	Game Game
+	Plus bool
+	// Iterations - количество проведенных итераций
+	Iterations int
+
+	nodes map[string]*node
+}
+
+func NewSolver(game Game, plus bool) *Solver {
+	return &Solver{
+		Game:  game,
+		Plus:  plus,
+		nodes: make(map[string]*node),
+	}
+}
+
+func (s *Solver) node(st State) *node {
+	key := st.InfoSet()
+
+	n, ok := s.nodes[key]
+	if !ok {
+		n = newNode(st.Actions())
+		s.nodes[key] = n
+	}
+
+	return n
+}
+
+// Solve проводит iterations итераций. На каждой итерации сожаления обновляются поочередно для обоих игроков.
+// Возвращает s
+func (s *Solver) Solve(iterations int) *Solver {
+	for i := 0; i < iterations; i++ {
+		s.Iterations++
+
+		for player := 0; player < PlayersNum; player++ {
+			s.cfr(s.Game.Root(), player, [PlayersNum]float64{1, 1}, 1)
+		}
+	}
+
+	return s
+}
+
+// cfr обходит дерево и обновляет сожаления игрока traverser.
+// reach - вероятности достижения узла за счет действий игроков, chance - за счет случая.
+// Возвращает ожидаемый выигрыш первого игрока в узле при текущих стратегиях
+func (s *Solver) cfr(st State, traverser int, reach [PlayersNum]float64, chance float64) float64 {
+	switch {
+	case st.IsTerminal():
+		return st.Payoff()
+	case st.IsChance():
+		value := 0.0
+
+		for _, o := range st.Outcomes() {
+			value += o.Probability * s.cfr(o.State, traverser, reach, chance*o.Probability)
+		}
+
+		return value
+	}
+
+	player := st.Player()
+	n := s.node(st)
+	strategy := n.strategy()
+	values := make([]float64, len(n.actions))
+	value := 0.0
+
+	for a := range n.actions {
+		// действия, которые traverser не может выбрать в узле соперника, обходить не нужно
+		if player != traverser && strategy[a] == 0 {
+			continue
+		}
+
+		next := reach
+		next[player] *= strategy[a]
+
+		values[a] = s.cfr(st.Play(a), traverser, next, chance)
+		value += strategy[a] * values[a]
+	}
+
+	if player != traverser {
+		return value
+	}
+
+	// выигрыш с точки зрения действующего игрока
+	sign := 1.0
+	if player == 1 {
+		sign = -1
+	}
+
+	counterfactual := chance * reach[1-player]
+
+	weight := 1.0
+	if s.Plus {
+		weight = float64(s.Iterations)
+	}
+
+	for a := range n.actions {
+		n.regrets[a] += counterfactual * sign * (values[a] - value)
+
+		if s.Plus && n.regrets[a] < 0 {
+			n.regrets[a] = 0
+		}
+
+		n.strategySum[a] += weight * reach[player] * strategy[a]
+	}
+
+	return value
+}
+
+// Strategy возвращает найденную (среднюю) стратегию
+func (s *Solver) Strategy() *StrategyTable {
+	table := &StrategyTable{
+		Game:       s.Game.Name(),
+		Iterations: s.Iterations,
+		InfoSets:   make(map[string][]ActionProbability, len(s.nodes)),
+	}
+
+	if g, ok := s.Game.(betSizer); ok {
+		table.BetSize = g.BetSize()
+	}
+
+	for key, n := range s.nodes {
+		probs := n.average()
+		actions := make([]ActionProbability, len(n.actions))
+
+		for i, action := range n.actions {
+			actions[i] = ActionProbability{Action: action, Probability: probs[i]}
+		}
+
+		table.InfoSets[key] = actions
+	}
+
+	return table
+}
+
+// Value возвращает ожидаемый выигрыш первого игрока, если оба игрока придерживаются стратегии table.
+// В информационных множествах, которых нет в таблице, игроки действуют равновероятно
+func Value(game Game, table *StrategyTable) float64 {
+	return value(game.Root(), table)
+}
+
+func value(st State, table *StrategyTable) float64 {
+	switch {
+	case st.IsTerminal():
+		return st.Payoff()
+	case st.IsChance():
+		v := 0.0
+
+		for _, o := range st.Outcomes() {
+			v += o.Probability * value(o.State, table)
+		}
+
+		return v
+	}
+
+	v := 0.0
+	actions := st.Actions()
+
+	for a, p := range table.probabilities(st.InfoSet(), actions) {
+		if p > 0 {
+			v += p * value(st.Play(a), table)
+		}
+	}
+
+	return v
+}
+
+// ActionProbability - вероятность действия в информационном множестве
+type ActionProbability struct {
+	Action      string
+	Probability float64
+}
+
+// StrategyTable - стратегия: распределение вероятностей действий по ключам информационных множеств
+type StrategyTable struct {
+	Game       string
+	Iterations int
+	// BetSize - размер ставки в фишках для игр с лимитными ставками
+	BetSize  float64
+	InfoSets map[string][]ActionProbability
+}
+
+// probabilities возвращает вероятности действий actions в информационном множестве key,
+// или равномерное распределение, если его нет в таблице
+func (t *StrategyTable) probabilities(key string, actions []string) []float64 {
+	probs := make([]float64, len(actions))
+
+	for i, action := range actions {
+		if p, ok := t.Probability(key, action); ok {
+			probs[i] = p
+		}
+	}
+
+	return normalize(probs)
+}
+
+// Probability возвращает вероятность действия action в информационном множестве key.
+// Второе значение - false, если информационного множества или действия нет в таблице
+func (t *StrategyTable) Probability(key, action string) (float64, bool) {
+	for _, a := range t.InfoSets[key] {
+		if a.Action == action {
+			return a.Probability, true
+		}
+	}
+
+	return 0, false
+}
+
+// Sample выбирает действие в информационном множестве key случайно, согласно стратегии.
+// Второе значение - false, если информационного множества нет в таблице
+func (t *StrategyTable) Sample(key string, r *rand.Rand) (string, bool) {
+	actions, ok := t.InfoSets[key]
+	if !ok || len(actions) == 0 {
+		return "", false
+	}
+
+	x := r.Float64()
+
+	for _, a := range actions {
+		if x < a.Probability {
+			return a.Action, true
+		}
+
+		x -= a.Probability
+	}
+
+	return actions[len(actions)-1].Action, true
+}
+
+// Keys возвращает отсортированные ключи информационных множеств
+func (t *StrategyTable) Keys() []string {
+	keys := make([]string, 0, len(t.InfoSets))
+
+	for key := range t.InfoSets {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Save записывает таблицу в w в формате JSON
+func (t *StrategyTable) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(t)
+}
+
+// LoadStrategyTable читает таблицу, записанную Save
+func LoadStrategyTable(r io.Reader) (*StrategyTable, error) {
+	table := &StrategyTable{}
+
+	if err := json.NewDecoder(r).Decode(table); err != nil {
+		return nil, fmt.Errorf("Can't load strategy table: %w", err)
+	}
+
+	return table, nil
+}
@@ -0,0 +1,72 @@
+package cfr
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestKuhnConverges(t *testing.T) {
+	for _, plus := range []bool{false, true} {
+		table := NewSolver(Kuhn{}, plus).Solve(5000).Strategy()
+
+		// цена Kuhn покера для первого игрока - -1/18
+		if got := Value(Kuhn{}, table); math.Abs(got+1.0/18) > 0.005 {
+			t.Errorf("plus %t: Value() = %.4f, want %.4f", plus, got, -1.0/18)
+		}
+
+		// с королем второй игрок всегда отвечает на ставку: в Kuhn покере колл - это ставка
+		if p, ok := table.Probability("K:b", BetAction); !ok || p < 0.99 {
+			t.Errorf("plus %t: call with K after a bet = %.3f, want 1", plus, p)
+		}
+	}
+}
+
+func TestLeducSmoke(t *testing.T) {
+	for _, plus := range []bool{false, true} {
+		table := NewSolver(Leduc{}, plus).Solve(50).Strategy()
+
+		if table.Game != LeducGame || table.Iterations != 50 || len(table.Keys()) == 0 {
+			t.Fatalf("plus %t: Strategy() = %s after %d iterations with %d info sets",
+				plus, table.Game, table.Iterations, len(table.Keys()))
+		}
+
+		for _, key := range table.Keys() {
+			sum := 0.0
+			for _, a := range table.InfoSets[key] {
+				sum += a.Probability
+			}
+
+			if math.Abs(sum-1) > 1e-9 {
+				t.Fatalf("plus %t: probabilities in %s sum to %.6f", plus, key, sum)
+			}
+		}
+
+		if v := Value(Leduc{}, table); math.Abs(v) > 1 {
+			t.Errorf("plus %t: Value() = %.4f, want a small value", plus, v)
+		}
+	}
+}
+
+func TestStrategyTableSaveLoad(t *testing.T) {
+	table := NewSolver(Kuhn{}, true).Solve(100).Strategy()
+
+	var buf bytes.Buffer
+	if err := table.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadStrategyTable(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(table, loaded) {
+		t.Errorf("LoadStrategyTable() = %+v, want %+v", loaded, table)
+	}
+
+	if _, err := LoadStrategyTable(bytes.NewBufferString("{")); err == nil {
+		t.Error("LoadStrategyTable() accepted broken JSON")
+	}
+}
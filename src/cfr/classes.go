@@ -0,0 +1,89 @@
+package cfr
+
+import (
+	"sort"
+	"strings"
+
+	"hands/src/models"
+)
+
+// HandClass возвращает класс карманных карт без учета мастей (models.StartingHand): "AA", "AKs", "AKo"
+func HandClass(pocket []*models.Card) string {
+	h, err := models.NewStartingHand(pocket)
+	if err != nil {
+		return ""
+	}
+
+	return h.String()
+}
+
+// HandClasses возвращает все 169 классов карманных карт
+func HandClasses() []string {
+	classes := make([]string, 0, models.StartingHandsNum)
+
+	for _, h := range models.StartingHands() {
+		classes = append(classes, h.String())
+	}
+
+	return classes
+}
+
+// ClassCombos возвращает все сочетания карманных карт класса class, не содержащие карт dead.
+// Класс без обозначения мастей ("AK") включает одномастные и разномастные сочетания
+func ClassCombos(class string, dead []*models.Card) ([][]*models.Card, error) {
+	if len(class) == 2 && class[0] != class[1] {
+		suited, err := ClassCombos(class+"s", dead)
+		if err != nil {
+			return nil, err
+		}
+
+		offsuit, err := ClassCombos(class+"o", dead)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(suited, offsuit...), nil
+	}
+
+	h, err := models.ParseStartingHand(class)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Combos(dead...), nil
+}
+
+// ParseRange возвращает сочетания карманных карт диапазона, заданного классами через запятую ("AA,KK,AKs"),
+// не содержащие карт dead
+func ParseRange(classes string, dead []*models.Card) ([][]*models.Card, error) {
+	combos := make([][]*models.Card, 0)
+
+	for _, class := range strings.Split(classes, ",") {
+		c, err := ClassCombos(strings.TrimSpace(class), dead)
+		if err != nil {
+			return nil, err
+		}
+
+		combos = append(combos, c...)
+	}
+
+	return combos, nil
+}
+
+// orderedCards возвращает все карты колоды в фиксированном порядке
+func orderedCards() []*models.Card {
+	return models.FullDeck.Cards()
+}
+
+// isDead проверяет, есть ли среди cards карты из dead
+func isDead(cards, dead []*models.Card) bool {
+	return models.NewCardSet(cards...).Intersect(models.NewCardSet(dead...)) != 0
+}
+
+// PocketKey возвращает ключ карманных карт, не зависящий от порядка карт
+func PocketKey(pocket []*models.Card) string {
+	cards := models.NewStringSliceFromCards(pocket)
+	sort.Strings(cards)
+
+	return strings.Join(cards, "")
+}
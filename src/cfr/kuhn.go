@@ -0,0 +1,103 @@
+package cfr
+
+// Действия в Kuhn и Leduc покере
+const (
+	PassAction  = "p"
+	BetAction   = "b"
+	FoldAction  = "f"
+	CallAction  = "c"
+	RaiseAction = "r"
+)
+
+// kuhnCards - карты Kuhn покера: валет, дама, король
+var kuhnCards = []string{"J", "Q", "K"}
+
+// Kuhn - Kuhn покер: три карты, анте 1, один круг торговли со ставкой 1.
+// Цена игры для первого игрока - -1/18
+type Kuhn struct{}
+
+func (Kuhn) Name() string {
+	return KuhnGame
+}
+
+func (Kuhn) Root() State {
+	return &kuhnState{}
+}
+
+// kuhnState - узел Kuhn покера. cards - индексы карт игроков в kuhnCards, history - действия
+type kuhnState struct {
+	dealt   bool
+	cards   [PlayersNum]int
+	history string
+}
+
+func (s *kuhnState) IsTerminal() bool {
+	switch s.history {
+	case "pp", "bp", "bb", "pbp", "pbb":
+		return true
+	}
+
+	return false
+}
+
+func (s *kuhnState) Payoff() float64 {
+	switch s.history {
+	case "bp":
+		return 1
+	case "pbp":
+		return -1
+	}
+
+	stake := 1.0
+	if s.history != "pp" {
+		stake = 2
+	}
+
+	if s.cards[0] > s.cards[1] {
+		return stake
+	}
+
+	return -stake
+}
+
+func (s *kuhnState) IsChance() bool {
+	return !s.dealt
+}
+
+func (s *kuhnState) Outcomes() []Outcome {
+	outcomes := make([]Outcome, 0, len(kuhnCards)*(len(kuhnCards)-1))
+	p := 1 / float64(len(kuhnCards)*(len(kuhnCards)-1))
+
+	for first := range kuhnCards {
+		for second := range kuhnCards {
+			if first != second {
+				outcomes = append(outcomes, Outcome{
+					State:       &kuhnState{dealt: true, cards: [PlayersNum]int{first, second}},
+					Probability: p,
+				})
+			}
+		}
+	}
+
+	return outcomes
+}
+
+func (s *kuhnState) Player() int {
+	return len(s.history) % PlayersNum
+}
+
+func (s *kuhnState) InfoSet() string {
+	return kuhnCards[s.cards[s.Player()]] + ":" + s.history
+}
+
+func (s *kuhnState) Actions() []string {
+	return []string{PassAction, BetAction}
+}
+
+func (s *kuhnState) Play(action int) State {
+	return &kuhnState{
+		dealt:   true,
+		cards:   s.cards,
+		history: s.history + s.Actions()[action],
+	}
+}
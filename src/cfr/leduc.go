@@ -0,0 +1,188 @@
+package cfr
+
+import "strings"
+
+const (
+	// leducDeckSize - колода Leduc покера: по две карты каждого из kuhnCards
+	leducDeckSize = 6
+	// leducMaxBets - максимальное количество ставок и повышений за круг торговли
+	leducMaxBets = 2
+	// leducRounds - количество кругов торговли
+	leducRounds = 2
+)
+
+// leducBetSizes - размер ставки в каждом круге торговли
+var leducBetSizes = [leducRounds]float64{2, 4}
+
+// Leduc - Leduc покер: шесть карт (J, Q, K по две), анте 1, по одной карманной карте и одна общая.
+// Два круга торговли со ставками 2 и 4, не более двух ставок за круг.
+// Побеждает пара с общей картой, иначе - старшая карта
+type Leduc struct{}
+
+func (Leduc) Name() string {
+	return LeducGame
+}
+
+func (Leduc) Root() State {
+	return &leducState{public: -1, bets: [PlayersNum]float64{1, 1}, folded: -1}
+}
+
+// leducState - узел Leduc покера. cards и public - индексы карт в колоде (значение карты - индекс / 2)
+type leducState struct {
+	dealt   bool
+	cards   [PlayersNum]int
+	public  int
+	round   int
+	history [leducRounds]string
+	// bets - вклады игроков в банк
+	bets   [PlayersNum]float64
+	folded int
+}
+
+func leducRank(card int) int {
+	return card / 2
+}
+
+// roundOver проверяет, окончен ли круг торговли с лимитными ставками
+func roundOver(history string) bool {
+	return history == PassAction+PassAction || strings.HasSuffix(history, CallAction)
+}
+
+// limitActions возвращает допустимые действия в круге торговли с лимитными ставками,
+// в котором разрешено не более maxBets ставок и повышений
+func limitActions(history string, maxBets int) []string {
+	if history == "" || strings.HasSuffix(history, PassAction) {
+		return []string{PassAction, BetAction}
+	}
+
+	if strings.Count(history, BetAction)+strings.Count(history, RaiseAction) < maxBets {
+		return []string{FoldAction, CallAction, RaiseAction}
+	}
+
+	return []string{FoldAction, CallAction}
+}
+
+// playLimit применяет действие action игрока player к вкладам в банк bets при размере ставки size.
+// Возвращает номер сбросившего карты игрока, или -1
+func playLimit(bets *[PlayersNum]float64, player int, action string, size float64) int {
+	switch action {
+	case BetAction:
+		bets[player] += size
+	case RaiseAction:
+		bets[player] = bets[1-player] + size
+	case CallAction:
+		bets[player] = bets[1-player]
+	case FoldAction:
+		return player
+	}
+
+	return -1
+}
+
+func (s *leducState) IsTerminal() bool {
+	return s.folded >= 0 || (s.round == leducRounds-1 && roundOver(s.history[s.round]))
+}
+
+func (s *leducState) Payoff() float64 {
+	switch s.folded {
+	case 0:
+		return -s.bets[0]
+	case 1:
+		return s.bets[1]
+	}
+
+	first, second := s.strength(0), s.strength(1)
+
+	switch {
+	case first > second:
+		return s.bets[1]
+	case first < second:
+		return -s.bets[0]
+	}
+
+	return 0
+}
+
+// strength - сила руки игрока: пара с общей картой старше любой старшей карты
+func (s *leducState) strength(player int) int {
+	rank := leducRank(s.cards[player])
+
+	if rank == leducRank(s.public) {
+		return len(kuhnCards) + rank
+	}
+
+	return rank
+}
+
+func (s *leducState) IsChance() bool {
+	return !s.dealt || (s.round == 0 && roundOver(s.history[0]))
+}
+
+func (s *leducState) Outcomes() []Outcome {
+	outcomes := make([]Outcome, 0, leducDeckSize*(leducDeckSize-1))
+
+	if !s.dealt {
+		p := 1 / float64(leducDeckSize*(leducDeckSize-1))
+
+		for first := 0; first < leducDeckSize; first++ {
+			for second := 0; second < leducDeckSize; second++ {
+				if first == second {
+					continue
+				}
+
+				next := *s
+				next.dealt = true
+				next.cards = [PlayersNum]int{first, second}
+
+				outcomes = append(outcomes, Outcome{State: &next, Probability: p})
+			}
+		}
+
+		return outcomes
+	}
+
+	p := 1 / float64(leducDeckSize-PlayersNum)
+
+	for public := 0; public < leducDeckSize; public++ {
+		if public == s.cards[0] || public == s.cards[1] {
+			continue
+		}
+
+		next := *s
+		next.public = public
+		next.round++
+
+		outcomes = append(outcomes, Outcome{State: &next, Probability: p})
+	}
+
+	return outcomes
+}
+
+func (s *leducState) Player() int {
+	return len(s.history[s.round]) % PlayersNum
+}
+
+func (s *leducState) InfoSet() string {
+	key := kuhnCards[leducRank(s.cards[s.Player()])]
+
+	if s.public >= 0 {
+		key += kuhnCards[leducRank(s.public)]
+	}
+
+	return key + ":" + s.history[0] + "/" + s.history[1]
+}
+
+func (s *leducState) Actions() []string {
+	return limitActions(s.history[s.round], leducMaxBets)
+}
+
+func (s *leducState) Play(action int) State {
+	next := *s
+	player := s.Player()
+	a := s.Actions()[action]
+
+	next.history[s.round] += a
+	next.folded = playLimit(&next.bets, player, a, leducBetSizes[s.round])
+
+	return &next
+}
@@ -0,0 +1,223 @@
+package cfr
+
+import (
+	"fmt"
+	"math/rand"
+
+	"hands/src/models"
+)
+
+const (
+	// PushAction - олл-ин
+	PushAction = "a"
+	// DefaultPushFoldSamples - количество случайных раздач при оценке эквити пары классов рук
+	DefaultPushFoldSamples = 200
+	// smallBlindBB - малый блайнд в больших блайндах
+	smallBlindBB = 0.5
+)
+
+// PushFold - игра один на один "олл-ин или пас" на префлопе: малый блайнд идет в олл-ин или сбрасывает карты,
+// большой блайнд уравнивает или сбрасывает. Руки объединены в 169 классов (HandClass),
+// эквити пар классов оценивается методом Монте-Карло. Выигрыши - в больших блайндах
+type PushFold struct {
+	// StackBB - эффективный стек в больших блайндах, включая блайнды
+	StackBB float64
+
+	classes  []string
+	equity   [][]float64
+	outcomes []Outcome
+}
+
+// NewPushFold создает игру со стеком stackBB, оценивая эквити по samples раздачам на пару классов рук
+func NewPushFold(stackBB float64, samples int, r *rand.Rand) (*PushFold, error) {
+	if stackBB < 1 {
+		return nil, fmt.Errorf("Stack %v is less than the big blind", stackBB)
+	}
+
+	g := &PushFold{
+		StackBB: stackBB,
+		classes: HandClasses(),
+	}
+
+	combos := make([][][]*models.Card, len(g.classes))
+
+	for i, class := range g.classes {
+		c, err := ClassCombos(class, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		combos[i] = c
+	}
+
+	g.equity = make([][]float64, len(g.classes))
+	weights := make([][]float64, len(g.classes))
+	total := 0.0
+
+	for i := range g.classes {
+		g.equity[i] = make([]float64, len(g.classes))
+		weights[i] = make([]float64, len(g.classes))
+	}
+
+	for i := range g.classes {
+		for j := i; j < len(g.classes); j++ {
+			equity, weight := matchupEquity(combos[i], combos[j], samples, r)
+			if i == j {
+				equity = 0.5
+			}
+
+			g.equity[i][j], g.equity[j][i] = equity, 1-equity
+			weights[i][j], weights[j][i] = weight, weight
+		}
+	}
+
+	for i := range g.classes {
+		for j := range g.classes {
+			total += weights[i][j]
+		}
+	}
+
+	for i := range g.classes {
+		for j := range g.classes {
+			if weights[i][j] == 0 {
+				continue
+			}
+
+			g.outcomes = append(g.outcomes, Outcome{
+				State:       &pushFoldState{game: g, dealt: true, hands: [PlayersNum]int{i, j}},
+				Probability: weights[i][j] / total,
+			})
+		}
+	}
+
+	return g, nil
+}
+
+// matchupEquity оценивает эквити первого класса рук против второго.
+// Возвращает эквити и количество сочетаний карт, при которых классы не пересекаются
+func matchupEquity(first, second [][]*models.Card, samples int, r *rand.Rand) (float64, float64) {
+	pairs := make([][PlayersNum][]*models.Card, 0, len(first)*len(second))
+
+	for _, a := range first {
+		for _, b := range second {
+			if !isDead(a, b) {
+				pairs = append(pairs, [PlayersNum][]*models.Card{a, b})
+			}
+		}
+	}
+
+	if len(pairs) == 0 {
+		return 0, 0
+	}
+
+	wins := 0.0
+	deck := orderedCards()
+	cards := make([]*models.Card, 0, models.BoardSize+models.PocketSize)
+	remaining := make([]*models.Card, 0, models.DeckLength)
+
+	for i := 0; i < samples; i++ {
+		pair := pairs[r.Intn(len(pairs))]
+		dead := append(append(make([]*models.Card, 0, 2*models.PocketSize), pair[0]...), pair[1]...)
+
+		remaining = remaining[:0]
+		for _, c := range deck {
+			if !isDead([]*models.Card{c}, dead) {
+				remaining = append(remaining, c)
+			}
+		}
+
+		for j := 0; j < models.BoardSize; j++ {
+			k := j + r.Intn(len(remaining)-j)
+			remaining[j], remaining[k] = remaining[k], remaining[j]
+		}
+
+		board := remaining[:models.BoardSize]
+		hero := models.RankCards(append(append(cards[:0], board...), pair[0]...))
+		villain := models.RankCards(append(append(cards[:0], board...), pair[1]...))
+
+		switch {
+		case hero > villain:
+			wins++
+		case hero == villain:
+			wins += 0.5
+		}
+	}
+
+	return wins / float64(samples), float64(len(pairs))
+}
+
+func (g *PushFold) Name() string {
+	return PushFoldGame
+}
+
+func (g *PushFold) Root() State {
+	return &pushFoldState{game: g}
+}
+
+// PushFoldKey возвращает ключ информационного множества игрока с картами pocket.
+// facingPush - игрок на большом блайнде, малый блайнд пошел в олл-ин
+func PushFoldKey(pocket []*models.Card, facingPush bool) string {
+	key := HandClass(pocket) + ":"
+
+	if facingPush {
+		key += PushAction
+	}
+
+	return key
+}
+
+// pushFoldState - узел игры "олл-ин или пас". hands - индексы классов рук малого и большого блайндов
+type pushFoldState struct {
+	game    *PushFold
+	dealt   bool
+	hands   [PlayersNum]int
+	history string
+}
+
+func (s *pushFoldState) IsTerminal() bool {
+	return s.history == FoldAction || len(s.history) == PlayersNum
+}
+
+func (s *pushFoldState) Payoff() float64 {
+	switch s.history {
+	case FoldAction:
+		return -smallBlindBB
+	case PushAction + FoldAction:
+		return 1
+	}
+
+	return s.game.StackBB * (2*s.game.equity[s.hands[0]][s.hands[1]] - 1)
+}
+
+func (s *pushFoldState) IsChance() bool {
+	return !s.dealt
+}
+
+func (s *pushFoldState) Outcomes() []Outcome {
+	return s.game.outcomes
+}
+
+func (s *pushFoldState) Player() int {
+	return len(s.history)
+}
+
+func (s *pushFoldState) InfoSet() string {
+	return s.game.classes[s.hands[s.Player()]] + ":" + s.history
+}
+
+func (s *pushFoldState) Actions() []string {
+	if s.history == "" {
+		return []string{FoldAction, PushAction}
+	}
+
+	return []string{FoldAction, CallAction}
+}
+
+func (s *pushFoldState) Play(action int) State {
+	return &pushFoldState{
+		game:    s.game,
+		dealt:   true,
+		hands:   s.hands,
+		history: s.history + s.Actions()[action],
+	}
+}
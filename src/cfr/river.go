@@ -0,0 +1,161 @@
+package cfr
+
+import (
+	"fmt"
+
+	"hands/src/models"
+)
+
+// DefaultRiverMaxBets - максимальное количество ставок и повышений на ривере по умолчанию
+const DefaultRiverMaxBets = 4
+
+// River - подыгра на ривере лимитного холдема один на один: общие карты известны, у игроков
+// диапазоны карманных карт, в банке Pot. Первым действует игрок 0 (без позиции),
+// ставки и повышения - размером Bet, не более MaxBets за круг. Сила рук определяется models.RankCards
+type River struct {
+	Board   []*models.Card
+	Ranges  [PlayersNum][][]*models.Card
+	Pot     float64
+	Bet     float64
+	MaxBets int
+
+	outcomes []Outcome
+}
+
+// NewRiver создает подыгру. Руки из диапазонов, пересекающиеся с общими картами, не учитываются
+func NewRiver(board []*models.Card, ranges [PlayersNum][][]*models.Card, pot, bet float64, maxBets int) (*River, error) {
+	if len(board) != models.BoardSize {
+		return nil, fmt.Errorf("River board must contain %d cards, got %d", models.BoardSize, len(board))
+	}
+
+	g := &River{
+		Board:   board,
+		Ranges:  ranges,
+		Pot:     pot,
+		Bet:     bet,
+		MaxBets: maxBets,
+	}
+
+	ranks := [PlayersNum][]models.HandRank{}
+	cards := make([]*models.Card, 0, models.BoardSize+models.PocketSize)
+
+	for player, r := range ranges {
+		ranks[player] = make([]models.HandRank, len(r))
+
+		for i, pocket := range r {
+			if len(pocket) != models.PocketSize {
+				return nil, fmt.Errorf("Pocket must contain %d cards, got %d", models.PocketSize, len(pocket))
+			}
+
+			ranks[player][i] = models.RankCards(append(append(cards[:0], board...), pocket...))
+		}
+	}
+
+	for i, first := range ranges[0] {
+		for j, second := range ranges[1] {
+			if isDead(first, board) || isDead(second, board) || isDead(first, second) {
+				continue
+			}
+
+			g.outcomes = append(g.outcomes, Outcome{
+				State: &riverState{
+					game:   g,
+					dealt:  true,
+					keys:   [PlayersNum]string{PocketKey(first), PocketKey(second)},
+					ranks:  [PlayersNum]models.HandRank{ranks[0][i], ranks[1][j]},
+					bets:   [PlayersNum]float64{pot / 2, pot / 2},
+					folded: -1,
+				},
+			})
+		}
+	}
+
+	if len(g.outcomes) == 0 {
+		return nil, fmt.Errorf("Ranges have no hands compatible with the board and each other")
+	}
+
+	for i := range g.outcomes {
+		g.outcomes[i].Probability = 1 / float64(len(g.outcomes))
+	}
+
+	return g, nil
+}
+
+func (g *River) Name() string {
+	return RiverGame
+}
+
+func (g *River) Root() State {
+	return &riverState{game: g, folded: -1}
+}
+
+// BetSize возвращает размер ставки
+func (g *River) BetSize() float64 {
+	return g.Bet
+}
+
+// RiverKey возвращает ключ информационного множества игрока с картами pocket после действий history
+func RiverKey(pocket []*models.Card, history string) string {
+	return PocketKey(pocket) + ":" + history
+}
+
+// riverState - узел подыгры на ривере. Выигрыш считается относительно половины начального банка,
+// которую условно внес каждый игрок
+type riverState struct {
+	game    *River
+	dealt   bool
+	keys    [PlayersNum]string
+	ranks   [PlayersNum]models.HandRank
+	history string
+	bets    [PlayersNum]float64
+	folded  int
+}
+
+func (s *riverState) IsTerminal() bool {
+	return s.folded >= 0 || roundOver(s.history)
+}
+
+func (s *riverState) Payoff() float64 {
+	switch {
+	case s.folded == 0:
+		return -s.bets[0]
+	case s.folded == 1:
+		return s.bets[1]
+	case s.ranks[0] > s.ranks[1]:
+		return s.bets[1]
+	case s.ranks[0] < s.ranks[1]:
+		return -s.bets[0]
+	}
+
+	return 0
+}
+
+func (s *riverState) IsChance() bool {
+	return !s.dealt
+}
+
+func (s *riverState) Outcomes() []Outcome {
+	return s.game.outcomes
+}
+
+func (s *riverState) Player() int {
+	return len(s.history) % PlayersNum
+}
+
+func (s *riverState) InfoSet() string {
+	return s.keys[s.Player()] + ":" + s.history
+}
+
+func (s *riverState) Actions() []string {
+	return limitActions(s.history, s.game.MaxBets)
+}
+
+func (s *riverState) Play(action int) State {
+	next := *s
+	a := s.Actions()[action]
+
+	next.history += a
+	next.folded = playLimit(&next.bets, s.Player(), a, s.game.Bet)
+
+	return &next
+}
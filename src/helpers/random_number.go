@@ -16,3 +16,8 @@ func randomInt(num int) int {
 func GenerateRandomNumInRange(max int) int {
 	return randomInt(max)
 }
+
+// NewRandom возвращает генератор случайных чисел, инициализированный текущим временем
+func NewRandom() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
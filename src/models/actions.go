@@ -0,0 +1,322 @@
+package models
+
+import "fmt"
+
+// Street - улица (круг торговли) раздачи
+type Street string
+
+const (
+	PreFlopStreet Street = "preflop"
+	FlopStreet    Street = "flop"
+	TurnStreet    Street = "turn"
+	RiverStreet   Street = "river"
+)
+
+// ActionType - действие игрока в круге торговли
+type ActionType string
+
+const (
+	CheckAction ActionType = "check"
+	CallAction  ActionType = "call"
+	// RaiseAction - повышение ставки. Если ставок на улице еще не было, это бет
+	RaiseAction ActionType = "raise"
+	FallAction  ActionType = "fall"
+	// DrawAction - обмен карт в дро-покере
+	DrawAction ActionType = "draw"
+	// StandPatAction - отказ от обмена карт
+	StandPatAction ActionType = "stand pat"
+	// DiscardAction - сброс лишней карманной карты без замены (пайнэппл)
+	DiscardAction ActionType = "discard"
+)
+
+// Action - запись о действии игрока. Amount - фишки, внесенные игроком в банк этим действием
+type Action struct {
+	PlayerID string
+	Type     ActionType
+	Amount   Chips
+	Street   Street
+}
+
+// canAct определяет, может ли игрок действовать: он не сбросил карты и у него остались фишки
+func canAct(p *Player) bool {
+	return p.Active && p.GetCurrentChipsAmount() > 0
+}
+
+// inHand возвращает количество игроков, не сбросивших карты
+func (t *Table) inHand() int {
+	n := 0
+
+	for _, p := range t.Players {
+		if p.Active {
+			n++
+		}
+	}
+
+	return n
+}
+
+// startBettingRound начинает новый круг торговли после выкладки общих карт.
+// Первым действует первый игрок после баттона
+func (t *Table) startBettingRound(street Street) {
+	t.Street = street
+	t.roundBets = make(map[string]Chips)
+	t.acted = make(map[string]bool)
+	t.CurrentBet = 0
+	t.minRaise = t.BigBlind
+	t.bets = 0
+
+	for _, p := range t.PostflopOrder() {
+		if canAct(p) {
+			t.CurrentMove = t.playerIndex(p.ID)
+
+			return
+		}
+	}
+}
+
+func (t *Table) playerIndex(playerID string) int {
+	for i, p := range t.Players {
+		if p.ID == playerID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isRoundOver - см. IsRoundOver. Вызывается под блокировкой стола
+func (t *Table) isRoundOver() bool {
+	if t.inHand() < 2 {
+		return true
+	}
+
+	if t.drawing {
+		return false
+	}
+
+	active := make([]*Player, 0)
+
+	for _, p := range t.Players {
+		if canAct(p) {
+			active = append(active, p)
+		}
+	}
+
+	// играть не с кем: остальные в олл-ине
+	if len(active) == 1 {
+		return t.roundBets[active[0].ID] >= t.CurrentBet
+	}
+
+	for _, p := range active {
+		if !t.acted[p.ID] || t.roundBets[p.ID] < t.CurrentBet {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsRoundOver определяет, закончен ли текущий круг торговли: все игроки, которые могут действовать,
+// уже действовали и уравняли ставку, либо в раздаче остался один игрок
+func (t *Table) IsRoundOver() bool {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	return t.isRoundOver()
+}
+
+// IsHandOver определяет, закончена ли раздача досрочно: все, кроме одного игрока, сбросили карты
+func (t *Table) IsHandOver() bool {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	return t.inHand() < 2
+}
+
+// NextToAct возвращает игрока, который должен действовать, или nil, если круг торговли закончен
+func (t *Table) NextToAct() *Player {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	if t.inHand() < 2 || t.CurrentMove < 0 || t.CurrentMove >= len(t.Players) {
+		return nil
+	}
+
+	if t.drawing {
+		return t.Players[t.CurrentMove]
+	}
+
+	if t.isRoundOver() {
+		return nil
+	}
+
+	return t.Players[t.CurrentMove]
+}
+
+// advance передает ход следующему по часовой стрелке игроку, который может действовать
+func (t *Table) advance() {
+	for i := 1; i <= len(t.Players); i++ {
+		n := (t.CurrentMove + i) % len(t.Players)
+		p := t.Players[n]
+
+		if canAct(p) && (!t.acted[p.ID] || t.roundBets[p.ID] < t.CurrentBet) {
+			t.CurrentMove = n
+
+			return
+		}
+	}
+}
+
+// Act выполняет действие игрока, чей сейчас ход. Для RaiseAction amount - размер повышения сверх
+// текущей ставки (не меньше большого блайнда и предыдущего повышения, если это не олл-ин, а в пот-лимите -
+// не больше банка после уравнивания). В лимитной игре amount не используется: повышение равно ставке улицы.
+// Для остальных действий amount не используется. Возвращает фишки, внесенные игроком в банк
+func (t *Table) Act(playerID string, action ActionType, amount Chips) (Chips, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	player := t.GetPlayerByID(playerID)
+	if player == nil {
+		return 0, fmt.Errorf("Player with ID %s is not in this game", playerID)
+	}
+
+	if t.drawing || t.isRoundOver() || t.Players[t.CurrentMove] != player {
+		return 0, fmt.Errorf("It is not a turn of player with ID %s", playerID)
+	}
+
+	toCall := t.CurrentBet - t.roundBets[playerID]
+	stack := player.GetCurrentChipsAmount()
+
+	var (
+		put Chips
+		err error
+	)
+
+	switch action {
+	case CheckAction:
+		if toCall > 0 {
+			return 0, fmt.Errorf("Player with ID %s can't check, %d to call", playerID, toCall)
+		}
+
+		put, err = player.Check(NewBet(0, 0))
+	case CallAction:
+		if toCall == 0 {
+			return 0, fmt.Errorf("Player with ID %s has nothing to call", playerID)
+		}
+
+		if toCall > stack {
+			toCall = stack
+		}
+
+		put, err = player.Call(NewBet(toCall, 0))
+	case RaiseAction:
+		betting := t.variant().Betting()
+		full := true
+
+		switch betting {
+		case FixedLimit:
+			if t.bets >= MaxLimitBets {
+				return 0, fmt.Errorf("Betting is capped at %d bets on %s street", MaxLimitBets, t.Street)
+			}
+
+			amount = t.limitRaise()
+
+			if toCall+amount > stack {
+				amount, full = stack-toCall, false
+			}
+		case PotLimit:
+			if limit := t.Pot.TotalChipsNum + toCall; amount > limit {
+				return 0, fmt.Errorf("Raise amount %d exceeds pot limit %d", amount, limit)
+			}
+		}
+
+		if toCall+amount > stack || amount <= 0 {
+			return 0, fmt.Errorf("Wrong raise amount %d for stack %d", amount, stack)
+		}
+
+		allIn := toCall+amount == stack
+
+		if betting != FixedLimit && amount < t.minRaise && !allIn {
+			return 0, fmt.Errorf("Raise amount %d is less than minimal raise %d", amount, t.minRaise)
+		}
+
+		put, err = player.Raise(NewBet(toCall, amount))
+		if err != nil {
+			return 0, err
+		}
+
+		t.CurrentBet += amount
+		t.bets++
+
+		// неполное повышение в олл-ин не открывает торговлю заново
+		if betting == FixedLimit && full {
+			t.acted = make(map[string]bool)
+		} else if betting != FixedLimit && amount >= t.minRaise {
+			t.minRaise = amount
+			t.acted = make(map[string]bool)
+		}
+	case FallAction:
+		_, err = player.Fall(NewBet(0, 0))
+	default:
+		return 0, fmt.Errorf("Unknown action %s", action)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if put > 0 {
+		if _, err := t.Pot.AddPlayerBet(put, playerID); err != nil {
+			return 0, err
+		}
+
+		t.roundBets[playerID] += put
+	}
+
+	t.acted[playerID] = true
+	t.actions = append(t.actions, Action{
+		PlayerID: playerID,
+		Type:     action,
+		Amount:   put,
+		Street:   t.Street,
+	})
+
+	t.advance()
+
+	return put, nil
+}
+
+// GetActions возвращает действия игроков в текущей раздаче
+func (t *Table) GetActions() []Action {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	return append(make([]Action, 0, len(t.actions)), t.actions...)
+}
+
+// NewHand готовит стол к новой раздаче: очищает общие карты, карты игроков и банк,
+// передвигает баттон, тасует колоду и собирает принудительные ставки
+func (t *Table) NewHand() (*Table, error) {
+	t.m.Lock()
+
+	t.rotate()
+
+	t.Board = nil
+	t.actions = nil
+	t.Street = t.variant().Streets()[0].Street
+	t.upCards = make(map[string][]*Card)
+	t.drawing = false
+	t.discardNum, t.pendingBoard = 0, 0
+	t.Pot.Reset()
+
+	for _, p := range t.Players {
+		p.resetHand(t.variant().PocketSize())
+	}
+
+	t.NextDealer()
+	t.shuffleDeck()
+
+	t.m.Unlock()
+
+	return t.Blinds()
+}
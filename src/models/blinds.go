@@ -0,0 +1,199 @@
+package models
+
+import "fmt"
+
+// UTGStraddleMinPlayers - минимальное количество игроков, при котором возможен стрэддл с UTG
+// (UTG не должен совпадать с дилером)
+const UTGStraddleMinPlayers = 4
+
+// ButtonStraddleMinPlayers - минимальное количество игроков, при котором возможен стрэддл с баттона
+const ButtonStraddleMinPlayers = 3
+
+func (t *Table) rules() *TableRules {
+	if t.TableRules == nil {
+		return &TableRules{}
+	}
+
+	return t.TableRules
+}
+
+// post вносит в банк принудительную ставку игрока. Живые ставки (блайнды, стрэддлы)
+// учитываются в ставках текущего круга торговли, мертвые (анте, мертвый малый блайнд) - только в банке.
+// Возвращает фактически внесенную сумму
+func (t *Table) post(player *Player, amount Chips, live bool) (Chips, error) {
+	posted := player.Post(amount)
+
+	if _, err := t.Pot.AddPlayerBet(posted, player.ID); err != nil {
+		return 0, err
+	}
+
+	if live {
+		t.roundBets[player.ID] += posted
+
+		if t.roundBets[player.ID] > t.CurrentBet {
+			t.CurrentBet = t.roundBets[player.ID]
+		}
+	}
+
+	return posted, nil
+}
+
+// postAntes собирает анте: с каждого игрока, либо одно анте с большого блайнда
+func (t *Table) postAntes(rules *TableRules, big *Player) error {
+	if rules.Ante == 0 {
+		return nil
+	}
+
+	if rules.BigBlindAnte {
+		_, err := t.post(big, rules.Ante, false)
+
+		return err
+	}
+
+	for _, player := range t.seated() {
+		if _, err := t.post(player, rules.Ante, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// postDeadBlinds собирает мертвый малый и живой большой блайнды с игроков, севших за стол в середине круга.
+// Новички, оказавшиеся на блайндах, вносят обычные блайнды.
+func (t *Table) postDeadBlinds(rules *TableRules, small, big *Player) error {
+	defer func() {
+		t.newcomers = make(map[string]struct{})
+	}()
+
+	if !rules.DeadBlinds {
+		return nil
+	}
+
+	for _, player := range t.seated() {
+		if _, ok := t.newcomers[player.ID]; !ok || player == small || player == big {
+			continue
+		}
+
+		if _, err := t.post(player, t.SmallBlind, false); err != nil {
+			return err
+		}
+
+		if _, err := t.post(player, t.BigBlind, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetRoundBet возвращает живую ставку игрока в текущем круге торговли
+func (t *Table) GetRoundBet(playerID string) Chips {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	return t.roundBets[playerID]
+}
+
+// straddled учитывает поставленный стрэддл: минимальное повышение на префлопе равно размеру стрэддла
+func (t *Table) straddled() {
+	t.bets++
+
+	if t.CurrentBet > t.minRaise {
+		t.minRaise = t.CurrentBet
+	}
+}
+
+// Blinds собирает принудительные ставки перед раздачей: анте, блайнды, стрэддлы и мертвые блайнды,
+// в соответствии с TableRules. Малый блайнд не ставится, если он мертвый.
+// Устанавливает CurrentBet и CurrentMove - индекс в Players первого игрока, действующего на префлопе.
+func (t *Table) Blinds() (*Table, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	rules := t.rules()
+
+	t.roundBets = make(map[string]Chips)
+	t.acted = make(map[string]bool)
+	t.CurrentBet = 0
+	t.minRaise = t.BigBlind
+	t.startingStacks = make(map[string]Chips)
+	t.handPositions = t.Positions()
+
+	// пропускающие раздачу игроки в ней не участвуют, и их стеки в запись о раздаче не попадают
+	for _, p := range t.Players {
+		if p.Active {
+			t.startingStacks[p.ID] = p.GetCurrentChipsAmount()
+		}
+	}
+
+	small := t.GetFirstPosition()
+	big := t.GetSecondPosition()
+	order := t.PreflopOrder()
+
+	if big == nil || len(order) < HeadsUpPlayersNum {
+		return nil, fmt.Errorf("Not enough players to post blinds at table %s", t.Name)
+	}
+
+	// в играх с bring-in анте вносят все игроки, а bring-in собирается после раздачи открытых карт
+	if t.variant().BringIn() {
+		t.newcomers = make(map[string]struct{})
+
+		return t, t.postAntes(&TableRules{Ante: rules.Ante}, big)
+	}
+
+	if err := t.postAntes(rules, big); err != nil {
+		return nil, err
+	}
+
+	if small != nil {
+		if _, err := t.post(small, t.SmallBlind, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := t.post(big, t.BigBlind, true); err != nil {
+		return nil, err
+	}
+
+	t.bets = 1
+
+	// first - индекс в order первого действующего игрока
+	first := 0
+
+	if rules.UTGStraddle && len(order) >= UTGStraddleMinPlayers {
+		if _, err := t.post(order[first], 2*t.CurrentBet, true); err != nil {
+			return nil, err
+		}
+
+		t.straddled()
+		first++
+	}
+
+	// стрэддл с баттона не меняет порядок действий, но дилер действует последним
+	dealer := t.GetDealer()
+
+	if rules.ButtonStraddle && len(order) >= ButtonStraddleMinPlayers && dealer != nil {
+		if _, err := t.post(dealer, 2*t.CurrentBet, true); err != nil {
+			return nil, err
+		}
+
+		t.straddled()
+
+		if order[first] == dealer {
+			first++
+		}
+	}
+
+	if err := t.postDeadBlinds(rules, small, big); err != nil {
+		return nil, err
+	}
+
+	for i, p := range t.Players {
+		if p == order[first] {
+			t.CurrentMove = i
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,252 @@
+package models
+
+import (
+	"math/rand"
+	"testing"
+
+	"hands/src/helpers"
+)
+
+// newBlindsTable создает стол с правилами rules и num игроками по 1000 фишек и начинает раздачу
+func newBlindsTable(t *testing.T, num int, rules TableRules) (*Table, []*Player) {
+	t.Helper()
+
+	table := NewTable("test", "t", helpers.NewDefaultIdGenerator(), CasheTableType, num+1, 10, 5).
+		WithRandom(rand.New(rand.NewSource(1))).
+		WithRules(&rules)
+
+	players := make([]*Player, num)
+	for i := range players {
+		players[i] = NewPlayer("p", helpers.NewDefaultIdGenerator(), 1000)
+
+		if err := table.Register(players[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := table.NewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	return table, players
+}
+
+// posted возвращает сумму, внесенную игроком в банк, и его живую ставку
+func posted(table *Table, p *Player) (Chips, Chips) {
+	return table.Pot.PlayersChips[p.ID], table.GetRoundBet(p.ID)
+}
+
+func TestAntes(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules TableRules
+		// other, small, big - внесено в банк обычным игроком, малым и большим блайндом
+		other, small, big Chips
+		total             Chips
+	}{
+		{name: "no ante", other: 0, small: 5, big: 10, total: 15},
+		{name: "ante", rules: TableRules{Ante: 2}, other: 2, small: 7, big: 12, total: 27},
+		{name: "big blind ante", rules: TableRules{Ante: 10, BigBlindAnte: true}, other: 0, small: 5, big: 20, total: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newBlindsTable(t, 6, tt.rules)
+			small, big := table.GetFirstPosition(), table.GetSecondPosition()
+
+			for _, p := range players {
+				want, live := tt.other, Chips(0)
+
+				switch p {
+				case small:
+					want, live = tt.small, 5
+				case big:
+					want, live = tt.big, 10
+				}
+
+				// анте - мертвая ставка и в ставку круга не входит
+				if got, round := posted(table, p); got != want || round != live {
+					t.Errorf("seat %d posted %d, round bet %d, want %d, %d", p.Seat, got, round, want, live)
+				}
+			}
+
+			if table.Pot.TotalChipsNum != tt.total || table.CurrentBet != 10 {
+				t.Errorf("pot %d, bet %d, want %d, 10", table.Pot.TotalChipsNum, table.CurrentBet, tt.total)
+			}
+		})
+	}
+}
+
+func TestButtonStraddle(t *testing.T) {
+	tests := []struct {
+		name string
+		num  int
+		// firstIsSmall - первым на префлопе действует малый блайнд, а не игрок после большого блайнда
+		firstIsSmall bool
+	}{
+		{name: "six players", num: 6},
+		// втроем первым действует дилер, но после стрэддла он действует последним
+		{name: "three players", num: 3, firstIsSmall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := newBlindsTable(t, tt.num, TableRules{ButtonStraddle: true})
+			dealer, small := table.GetDealer(), table.GetFirstPosition()
+
+			if got, round := posted(table, dealer); got != 20 || round != 20 {
+				t.Errorf("button posted %d, round bet %d, want 20, 20", got, round)
+			}
+
+			if table.Pot.TotalChipsNum != 35 || table.CurrentBet != 20 || table.minRaise != 20 {
+				t.Errorf("pot %d, bet %d, min raise %d, want 35, 20, 20",
+					table.Pot.TotalChipsNum, table.CurrentBet, table.minRaise)
+			}
+
+			first := table.NextToAct()
+
+			if first == dealer {
+				t.Error("straddling button acts first")
+			}
+
+			if (first == small) != tt.firstIsSmall {
+				t.Errorf("seat %d acts first, small blind is seat %d", first.Seat, small.Seat)
+			}
+		})
+	}
+}
+
+func TestDeadBlinds(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules TableRules
+		// dead, live - мертвая и живая части ставки новичка
+		dead, live Chips
+	}{
+		{name: "dead blinds", rules: TableRules{DeadBlinds: true}, dead: 5, live: 10},
+		{name: "no dead blinds", rules: TableRules{}, dead: 0, live: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := newBlindsTable(t, 5, tt.rules)
+
+			newcomer := NewPlayer("new", helpers.NewDefaultIdGenerator(), 1000)
+			if err := table.Register(newcomer); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, ok := table.newcomers[newcomer.ID]; !ok {
+				t.Fatal("player who joined mid-game is not a newcomer")
+			}
+
+			if _, err := table.NewHand(); err != nil {
+				t.Fatal(err)
+			}
+
+			if p := newcomer; p == table.GetFirstPosition() || p == table.GetSecondPosition() {
+				t.Fatalf("newcomer is on the blinds at seat %d", p.Seat)
+			}
+
+			if got, round := posted(table, newcomer); got != tt.dead+tt.live || round != tt.live {
+				t.Errorf("newcomer posted %d, round bet %d, want %d, %d", got, round, tt.dead+tt.live, tt.live)
+			}
+
+			if want := 15 + tt.dead + tt.live; table.Pot.TotalChipsNum != want {
+				t.Errorf("pot %d, want %d", table.Pot.TotalChipsNum, want)
+			}
+
+			if len(table.newcomers) != 0 {
+				t.Errorf("%d newcomers left after the blinds", len(table.newcomers))
+			}
+
+			// в следующей раздаче новичок уже не вносит мертвые блайнды
+			if _, err := table.NewHand(); err != nil {
+				t.Fatal(err)
+			}
+
+			if p := newcomer; p != table.GetFirstPosition() && p != table.GetSecondPosition() {
+				if got, _ := posted(table, p); got != 0 {
+					t.Errorf("newcomer posted %d in the next hand", got)
+				}
+			}
+		})
+	}
+}
+
+func TestNewcomerOnBigBlindPostsOnlyBigBlind(t *testing.T) {
+	table, _ := newBlindsTable(t, 4, TableRules{DeadBlinds: true})
+
+	newcomer := NewPlayer("new", helpers.NewDefaultIdGenerator(), 1000)
+	if err := table.Register(newcomer); err != nil {
+		t.Fatal(err)
+	}
+
+	// раздаем, пока новичок не окажется на большом блайнде, и отмечаем его новичком заново
+	for i := 0; i < 5 && table.GetSecondPosition() != newcomer; i++ {
+		table.newcomers[newcomer.ID] = struct{}{}
+
+		if _, err := table.NewHand(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if table.GetSecondPosition() != newcomer {
+		t.Fatal("newcomer never reached the big blind")
+	}
+
+	if got, round := posted(table, newcomer); got != 10 || round != 10 {
+		t.Errorf("newcomer on the big blind posted %d, round bet %d, want 10, 10", got, round)
+	}
+
+	if table.Pot.TotalChipsNum != 15 {
+		t.Errorf("pot %d, want 15", table.Pot.TotalChipsNum)
+	}
+}
+
+func TestStraddleMinRaise(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules TableRules
+		// bet - ставка, которую нужно уравнять на префлопе
+		bet      Chips
+		minRaise Chips
+	}{
+		{name: "no straddle", bet: 10, minRaise: 10},
+		{name: "utg straddle", rules: TableRules{UTGStraddle: true}, bet: 20, minRaise: 20},
+		{name: "utg and button straddles", rules: TableRules{UTGStraddle: true, ButtonStraddle: true}, bet: 40, minRaise: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			table := NewTable("test", "t", helpers.NewDefaultIdGenerator(), CasheTableType, 6, 10, 5).
+				WithRandom(rand.New(rand.NewSource(1))).
+				WithRules(&rules)
+
+			for i := 0; i < 6; i++ {
+				if err := table.Register(NewPlayer("p", helpers.NewDefaultIdGenerator(), 1000)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := table.NewHand(); err != nil {
+				t.Fatal(err)
+			}
+
+			if table.CurrentBet != tt.bet || table.minRaise != tt.minRaise {
+				t.Fatalf("bet %d, min raise %d, want %d, %d", table.CurrentBet, table.minRaise, tt.bet, tt.minRaise)
+			}
+
+			player := table.NextToAct()
+
+			if _, err := table.Act(player.ID, RaiseAction, tt.minRaise-1); err == nil {
+				t.Errorf("raise by %d accepted", tt.minRaise-1)
+			}
+
+			if _, err := table.Act(player.ID, RaiseAction, tt.minRaise); err != nil {
+				t.Errorf("raise by %d: %v", tt.minRaise, err)
+			}
+		})
+	}
+}
@@ -4,22 +4,15 @@ import (
 	"fmt"
 	"hands/src/helpers"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
 type CardValue int
 
+// cardValueFromString возвращает значение карты по его записи, или 0, если запись неизвестна
 func cardValueFromString(s string) CardValue {
-	cv := Two
-
-	if v, ok := picCardsValues[s]; ok {
-		return v
-	}
-
-	for i, v := range cardValuesStrings {
-		if v == s {
-			cv = CardValue(i + 2)
-		}
-	}
+	cv, _ := parseValue(s)
 
 	return cv
 }
@@ -47,10 +40,7 @@ var picCardsValues = map[string]CardValue{
 	"A": Ace,
 }
 
-var (
-	suiteFromStringPatten      = regexp.MustCompile(`[HDSC]$`)
-	cardValueFromStringPattern = regexp.MustCompile(`^\d{1,2}|[JQKA]{1}`)
-)
+var cardValueFromStringPattern = regexp.MustCompile(`(?i)^(10|[2-9TJQKA])`)
 
 const (
 	Two CardValue = iota + 2
@@ -85,6 +75,10 @@ var CardValues = []CardValue{
 }
 
 func (cv CardValue) String() string {
+	if cv < Two || cv > Ace {
+		return "?"
+	}
+
 	return cardValuesStrings[cv-2]
 }
 
@@ -119,10 +113,14 @@ func (s Suite) Color() SuiteColor {
 	return Black
 }
 
+// SuiteFromString возвращает масть карты card по последнему символу записи, или пустую масть
 func SuiteFromString(card string) Suite {
-	return Suite(suiteFromStringPatten.FindString(card))
+	r, _ := utf8.DecodeLastRuneInString(strings.TrimSpace(card))
+
+	return suitRunes[r]
 }
 
+// CardValueFromString возвращает значение карты card по началу записи, или 0, если значение неизвестно
 func CardValueFromString(card string) CardValue {
 	return cardValueFromString(cardValueFromStringPattern.FindString(card))
 }
@@ -134,16 +132,16 @@ const (
 
 const (
 	Hearts   Suite = "H"
-	Diamonds       = "D"
-	Spades         = "S"
-	Crosses        = "C"
+	Diamonds Suite = "D"
+	Spades   Suite = "S"
+	Crosses  Suite = "C"
 )
 
 var Suites = []Suite{
-	"H",
-	"D",
-	"S",
-	"C",
+	Hearts,
+	Diamonds,
+	Spades,
+	Crosses,
 }
 
 type CardSuite struct {
@@ -160,34 +158,25 @@ type Card struct {
 	Suite *CardSuite
 }
 
+// NewCardFromString создает карту по записи card, как ParseCard, но не сообщает об ошибках:
+// у карты с неизвестным значением Value равно 0, с неизвестной мастью - пустая масть. Такая карта
+// не имеет номера в колоде (Index возвращает NoCard), не входит в CardSet и не сдается из Deck,
+// поэтому вызывающий код должен сам проверять Index() != NoCard.
+//
+// Deprecated: используйте ParseCard, которая возвращает ошибку для неизвестных карт
 func NewCardFromString(card string) *Card {
-	suite := SuiteFromString(card)
-	value := CardValueFromString(card)
-
-	return &Card{
-		Value: value,
-		Suite: &CardSuite{
-			Color: suite.Color(),
-			Suite: suite,
-		},
+	if c, err := ParseCard(card); err == nil {
+		return c
 	}
+
+	return newCard(CardValueFromString(card), SuiteFromString(card))
 }
 
 func NewRandomCard() *Card {
 	suiteNum := helpers.GenerateRandomNumInRange(SuitesNum)
 	valueNum := helpers.GenerateRandomNumInRange(ValuesNum)
 
-	suite := Suites[suiteNum]
-
-	c := &Card{
-		Value: NewCardValue(valueNum),
-		Suite: &CardSuite{
-			Color: suite.Color(),
-			Suite: suite,
-		},
-	}
-
-	return c
+	return CardIndex(suiteNum*ValuesNum + valueNum).Card()
 }
 
 func (same *Card) String() string {
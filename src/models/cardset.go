@@ -0,0 +1,235 @@
+package models
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// CardIndex - номер карты в колоде, занимающий один байт: номер масти в Suites * 13 + (значение - 2).
+// Порядок номеров совпадает с порядком карт новой колоды. Колода и множества карт хранят номера,
+// а экземпляры Card создаются только при выдаче карт
+type CardIndex uint8
+
+// NoCard - номер карты с неизвестным значением или мастью
+const NoCard CardIndex = 0xff
+
+// cardsByIndex - карты по номерам. Экземпляры только читаются и наружу не выдаются: Card возвращает копии,
+// чтобы изменение полученной карты (например, json.Unmarshal в нее) не меняло другие карты
+var cardsByIndex = func() [DeckLength]*Card {
+	var cards [DeckLength]*Card
+
+	for s, suite := range Suites {
+		for v, value := range CardValues {
+			cards[s*ValuesNum+v] = &Card{
+				Value: value,
+				Suite: &CardSuite{
+					Color: suite.Color(),
+					Suite: suite,
+				},
+			}
+		}
+	}
+
+	return cards
+}()
+
+// suitIndex возвращает номер масти в Suites, или -1
+func suitIndex(suite Suite) int {
+	switch suite {
+	case Hearts:
+		return 0
+	case Diamonds:
+		return 1
+	case Spades:
+		return 2
+	case Crosses:
+		return 3
+	}
+
+	return -1
+}
+
+// newCardIndex возвращает номер карты значения value масти suite, или NoCard
+func newCardIndex(value CardValue, suite Suite) CardIndex {
+	s := suitIndex(suite)
+	if s < 0 || value < Two || value > Ace {
+		return NoCard
+	}
+
+	return CardIndex(s*ValuesNum + int(value-Two))
+}
+
+// Card возвращает новый экземпляр карты с номером i, или nil для NoCard
+func (i CardIndex) Card() *Card {
+	c := i.shared()
+	if c == nil {
+		return nil
+	}
+
+	return &Card{
+		Value: c.Value,
+		Suite: &CardSuite{
+			Color: c.Suite.Color,
+			Suite: c.Suite.Suite,
+		},
+	}
+}
+
+// shared возвращает карту с номером i из cardsByIndex только для чтения, или nil для NoCard
+func (i CardIndex) shared() *Card {
+	if int(i) >= DeckLength {
+		return nil
+	}
+
+	return cardsByIndex[i]
+}
+
+func (i CardIndex) String() string {
+	if c := i.shared(); c != nil {
+		return c.String()
+	}
+
+	return "??"
+}
+
+// Index возвращает номер карты в колоде, или NoCard, если значение или масть карты неизвестны
+func (same *Card) Index() CardIndex {
+	return newCardIndex(same.Value, same.Suite.Suite)
+}
+
+// CardSet - множество карт колоды: бит i соответствует карте с номером i. Операции над множеством
+// не выделяют память, поэтому CardSet удобен для учета мертвых и уже сданных карт
+type CardSet uint64
+
+// FullDeck - множество всех карт колоды
+const FullDeck CardSet = 1<<DeckLength - 1
+
+// NewCardSet возвращает множество карт cards. Карты с неизвестным значением или мастью пропускаются
+func NewCardSet(cards ...*Card) CardSet {
+	var s CardSet
+
+	for _, c := range cards {
+		s = s.Add(c)
+	}
+
+	return s
+}
+
+// Add возвращает множество s с картой c
+func (s CardSet) Add(c *Card) CardSet {
+	return s.AddIndex(c.Index())
+}
+
+// AddIndex возвращает множество s с картой номер i
+func (s CardSet) AddIndex(i CardIndex) CardSet {
+	if int(i) >= DeckLength {
+		return s
+	}
+
+	return s | 1<<i
+}
+
+// Remove возвращает множество s без карты c
+func (s CardSet) Remove(c *Card) CardSet {
+	return s.RemoveIndex(c.Index())
+}
+
+// RemoveIndex возвращает множество s без карты номер i
+func (s CardSet) RemoveIndex(i CardIndex) CardSet {
+	if int(i) >= DeckLength {
+		return s
+	}
+
+	return s &^ (1 << i)
+}
+
+// Contains проверяет, входит ли карта c в множество
+func (s CardSet) Contains(c *Card) bool {
+	return s.Has(c.Index())
+}
+
+// Has проверяет, входит ли карта номер i в множество
+func (s CardSet) Has(i CardIndex) bool {
+	return int(i) < DeckLength && s&(1<<i) != 0
+}
+
+// Union возвращает объединение множеств
+func (s CardSet) Union(other CardSet) CardSet {
+	return s | other
+}
+
+// Intersect возвращает пересечение множеств
+func (s CardSet) Intersect(other CardSet) CardSet {
+	return s & other
+}
+
+// Difference возвращает карты s, не входящие в other
+func (s CardSet) Difference(other CardSet) CardSet {
+	return s &^ other
+}
+
+// Len возвращает количество карт в множестве
+func (s CardSet) Len() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+// valueMask - карты значения Two во всех мастях; сдвиг на value-Two дает карты значения value
+const valueMask CardSet = 1 | 1<<ValuesNum | 1<<(2*ValuesNum) | 1<<(3*ValuesNum)
+
+// CountValue возвращает количество карт значения value в множестве
+func (s CardSet) CountValue(value CardValue) int {
+	if value < Two || value > Ace {
+		return 0
+	}
+
+	return (s & (valueMask << (value - Two))).Len()
+}
+
+// Pop возвращает карту множества с наименьшим номером и множество без нее, или NoCard для пустого множества.
+// Позволяет перебрать карты без выделения памяти:
+//
+//	for rest := set; rest != 0; {
+//		var i CardIndex
+//		i, rest = rest.Pop()
+//		...
+//	}
+func (s CardSet) Pop() (CardIndex, CardSet) {
+	if s == 0 {
+		return NoCard, s
+	}
+
+	i := CardIndex(bits.TrailingZeros64(uint64(s)))
+
+	return i, s & (s - 1)
+}
+
+// Each вызывает fn для каждой карты множества в порядке номеров
+func (s CardSet) Each(fn func(c *Card)) {
+	for s != 0 {
+		var i CardIndex
+
+		i, s = s.Pop()
+		fn(i.Card())
+	}
+}
+
+// Cards возвращает карты множества в порядке номеров
+func (s CardSet) Cards() []*Card {
+	cards := make([]*Card, 0, s.Len())
+
+	s.Each(func(c *Card) {
+		cards = append(cards, c)
+	})
+
+	return cards
+}
+
+func (s CardSet) String() string {
+	names := make([]string, 0, s.Len())
+
+	s.Each(func(c *Card) {
+		names = append(names, c.String())
+	})
+
+	return strings.Join(names, " ")
+}
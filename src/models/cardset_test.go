@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCardsAreNotShared(t *testing.T) {
+	tests := []struct {
+		name string
+		card func() *Card
+	}{
+		{name: "index", card: func() *Card { return CardIndex(12).Card() }},
+		{name: "parse", card: func() *Card { return MustParse("Ah")[0] }},
+		{name: "deck", card: func() *Card {
+			card, _ := newDeckFromCards(MustParse("Ah")).Card()
+
+			return card
+		}},
+		{name: "set", card: func() *Card { return NewCardSet(MustParse("Ah")...).Cards()[0] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := tt.card()
+			if card.String() != "AH" {
+				t.Fatalf("card = %s, want AH", card)
+			}
+
+			if err := json.Unmarshal([]byte(`"2c"`), card); err != nil {
+				t.Fatal(err)
+			}
+
+			card.Suite.Suite = Diamonds
+
+			if c := MustParse("Ah")[0]; c.String() != "AH" {
+				t.Errorf("parsed card changed to %s", c)
+			}
+
+			if c := CardIndex(12).Card(); c.String() != "AH" {
+				t.Errorf("indexed card changed to %s", c)
+			}
+
+			if c := CardIndex(39).Card(); c.String() != "2C" {
+				t.Errorf("2c changed to %s", c)
+			}
+		})
+	}
+}
+
+func TestCountValue(t *testing.T) {
+	set := NewCardSet(MustParse("Ah Ad As 2c 2h Kd")...)
+
+	tests := []struct {
+		value CardValue
+		want  int
+	}{
+		{value: Ace, want: 3},
+		{value: Two, want: 2},
+		{value: King, want: 1},
+		{value: Queen, want: 0},
+		{value: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := set.CountValue(tt.value); got != tt.want {
+			t.Errorf("CountValue(%v) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"errors"
+	"math"
+)
+
+// chenCardScores - очки старшей карты по формуле Чена для картинок и туза
+var chenCardScores = map[CardValue]float64{
+	Ace:   10,
+	King:  8,
+	Queen: 7,
+	Jack:  6,
+}
+
+// chenGapPenalties - штраф за разрыв между картами: индекс - количество пропущенных номиналов
+var chenGapPenalties = []float64{0, 1, 2, 4, 5}
+
+func chenCardScore(v CardValue) float64 {
+	if score, ok := chenCardScores[v]; ok {
+		return score
+	}
+
+	return float64(v) / 2
+}
+
+// ChenScore оценивает силу стартовой руки по формуле Билла Чена (от -1 для 72o до 20 для AA)
+func ChenScore(pocket []*Card) (int, error) {
+	if len(pocket) != PocketSize {
+		return 0, errors.New("Chen score requires exactly two pocket cards")
+	}
+
+	high, low := pocket[0], pocket[1]
+	if low.Value > high.Value {
+		high, low = low, high
+	}
+
+	score := chenCardScore(high.Value)
+
+	if high.Value == low.Value {
+		score = math.Max(score*2, 5)
+
+		return int(math.Ceil(score)), nil
+	}
+
+	if high.CompareSuites(low) {
+		score += 2
+	}
+
+	gap := int(high.Value-low.Value) - 1
+	if gap >= len(chenGapPenalties) {
+		gap = len(chenGapPenalties) - 1
+	}
+
+	score -= chenGapPenalties[gap]
+
+	// бонус за связность младших карт
+	if gap <= 1 && high.Value < Queen {
+		score++
+	}
+
+	return int(math.Ceil(score)), nil
+}
@@ -1,6 +1,10 @@
 package models
 
-import "hands/src/helpers"
+import (
+	"math/rand"
+
+	"hands/src/helpers"
+)
 
 const DeckLength = 52
 
@@ -18,92 +22,246 @@ func (e EofError) Error() string {
 	return EOF
 }
 
-// Deck представляет карточную колоду
+// Deck представляет карточную колоду. Карты хранятся номерами CardIndex в массивах фиксированного размера,
+// поэтому тасование, сдача номеров карт (DealIndex) и сброс не выделяют память. Экземпляры Card
+// создаются только при выдаче карт наружу (Card, Remaining, Mucked)
 type Deck struct {
-	cards []*Card
+	cards []CardIndex
+	// discards - сброшенные карты, которые можно перетасовать в колоду, когда она закончится
+	discards []CardIndex
+
+	cardsBuf    [DeckLength]CardIndex
+	discardsBuf [DeckLength]CardIndex
 }
 
 func NewDeck() *Deck {
-	return &Deck{cards: make([]*Card, 0)}
+	d := &Deck{}
+	d.cards = d.cardsBuf[:0]
+	d.discards = d.discardsBuf[:0]
+
+	return d
+}
+
+// newDeckFromCards создает колоду из карт cards в том же порядке: последняя карта сдается первой
+func newDeckFromCards(cards []*Card) *Deck {
+	d := NewDeck()
+
+	for _, c := range cards {
+		if i := c.Index(); i != NoCard && len(d.cards) < DeckLength {
+			d.cards = append(d.cards, i)
+		}
+	}
+
+	return d
 }
 
 // makeOrderedCards возвращает []*Card, заполненный картами, упорядоченными по мастям и значениям
+// (в порядке номеров CardIndex)
 //
 //	[]*Card{
 //		"2H".
-//		"2D".
-//		"2S".
-//		"2C".
 //		"3H".
-//		"3D".
+//		"4H".
+//		...
+//		"AH".
+//		"2D".
 //		...
 //		"AC".
 //	}
 func makeOrderedCards() []*Card {
-	cards := make([]*Card, 0)
-
-	for _, suite := range Suites {
-		for _, cardValue := range CardValues {
-			cards = append(cards, &Card{
-				Value: cardValue,
-				Suite: &CardSuite{
-					Color: suite.Color(),
-					Suite: suite,
-				},
-			})
-		}
+	cards := make([]*Card, DeckLength)
+
+	for i := range cards {
+		cards[i] = CardIndex(i).Card()
 	}
 
 	return cards
 }
 
-// Shuffle заполняет слайс d.cards уникальными случайными значениями из полного упорядоченного набора карт.
+// Shuffle заполняет колоду полным набором карт в случайном порядке (тасование Фишера-Йетса).
 // Возвращает d
 func (d *Deck) Shuffle() *Deck {
-	control := make(map[int]struct{})
-	orderedCards := makeOrderedCards()
+	return d.shuffle(FullDeck, helpers.GenerateRandomNumInRange)
+}
 
-	d.cards = make([]*Card, len(orderedCards))
+// ShuffleWithRandom тасует колоду так же, как Shuffle, используя генератор r.
+// При одинаковом состоянии генератора порядок карт одинаков. Возвращает d
+func (d *Deck) ShuffleWithRandom(r *rand.Rand) *Deck {
+	return d.shuffle(FullDeck, r.Intn)
+}
 
-	for i := 0; i < len(orderedCards); i++ {
-		num := helpers.GenerateRandomNumInRange(len(orderedCards))
+// ShuffleCards заполняет колоду картами cards (например, неполной колодой варианта игры)
+// в случайном порядке. Если r равен nil, используется генератор по умолчанию. Возвращает d
+func (d *Deck) ShuffleCards(cards []*Card, r *rand.Rand) *Deck {
+	return d.ShuffleSet(NewCardSet(cards...), r)
+}
 
-		if _, ok := control[num]; !ok {
-			control[num] = struct{}{}
+// ShuffleSet заполняет колоду картами множества cards в случайном порядке, например полной колодой
+// без мертвых карт. Если r равен nil, используется генератор по умолчанию. Возвращает d
+func (d *Deck) ShuffleSet(cards CardSet, r *rand.Rand) *Deck {
+	if r == nil {
+		return d.shuffle(cards, helpers.GenerateRandomNumInRange)
+	}
 
-			d.cards[i] = orderedCards[num]
-		}
+	return d.shuffle(cards, r.Intn)
+}
+
+// shuffle раскладывает карты cards в порядке номеров и тасует их
+func (d *Deck) shuffle(cards CardSet, intn func(int) int) *Deck {
+	d.cards = d.cardsBuf[:0]
+	d.discards = d.discardsBuf[:0]
+
+	for cards != 0 {
+		var i CardIndex
+
+		i, cards = cards.Pop()
+		d.cards = append(d.cards, i)
 	}
 
+	shuffleIndexes(d.cards, intn)
+
 	return d
 }
 
+func shuffleIndexes(cards []CardIndex, intn func(int) int) {
+	for i := len(cards) - 1; i > 0; i-- {
+		j := intn(i + 1)
+
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+}
+
 func (d *Deck) randomCard() *Card {
 	return NewRandomCard()
 }
 
 // pop удаляет последний элемент слайса cards (верхнюю карту в колоде) и возвращает его.
 // Возвращает ошибку, если длина cards равна 0.
-func (d *Deck) pop() (*Card, error) {
+func (d *Deck) pop() (CardIndex, error) {
 	if len(d.cards) == 0 {
-		return nil, NewEofError()
+		return NoCard, NewEofError()
 	}
 
-	card, cards := d.cards[len(d.cards)-1], d.cards[0:len(d.cards)-1]
-
-	d.cards = cards
+	card := d.cards[len(d.cards)-1]
+	d.cards = d.cards[:len(d.cards)-1]
 
 	return card, nil
 }
 
+// DealIndex возвращает номер последней карты в колоде, или ошибку в случае, если карт в колоде нет.
+// В отличие от Card не создает экземпляр карты и не выделяет память
+func (d *Deck) DealIndex() (CardIndex, error) {
+	return d.pop()
+}
+
 // Card возвращает последнюю карту в колоде, или ошибку в случае, если карт в колоде нет, т.е длина cards равна 0.
+// Каждый вызов создает новый экземпляр Card, поэтому там, где достаточно номера карты, используйте DealIndex
 func (d *Deck) Card() (*Card, error) {
-	return d.pop()
+	i, err := d.pop()
+	if err != nil {
+		return nil, err
+	}
+
+	return i.Card(), nil
 }
 
-// Discard удаляет последнюю карту в колоде без получения ее значения. Возвращает ошибку в случае, если карт в колоде нет, т.е длина cards равна 0.
+// Discard удаляет последнюю карту в колоде без получения ее значения и откладывает ее в сброс.
+// Возвращает ошибку в случае, если карт в колоде нет, т.е длина cards равна 0.
 func (d *Deck) Discard() error {
-	_, err := d.pop()
+	card, err := d.pop()
+	if err != nil {
+		return err
+	}
+
+	d.discards = append(d.discards, card)
+
+	return nil
+}
+
+// Muck откладывает сброшенные игроком карты в сброс
+func (d *Deck) Muck(cards ...*Card) {
+	for _, c := range cards {
+		if i := c.Index(); i != NoCard {
+			d.discards = append(d.discards, i)
+		}
+	}
+}
+
+// Reshuffle тасует сброс и кладет его под оставшиеся в колоде карты. Если r равен nil,
+// используется генератор по умолчанию. Возвращает ошибку, если сброс пуст
+func (d *Deck) Reshuffle(r *rand.Rand) error {
+	if len(d.discards) == 0 {
+		return NewEofError()
+	}
+
+	intn := helpers.GenerateRandomNumInRange
+	if r != nil {
+		intn = r.Intn
+	}
+
+	shuffleIndexes(d.discards, intn)
+
+	// карты сдаются с конца слайса, поэтому сброс кладется в начало
+	if n, m := len(d.discards), len(d.cards); n+m <= DeckLength {
+		copy(d.cardsBuf[n:n+m], d.cards)
+		copy(d.cardsBuf[:n], d.discards)
+
+		d.cards = d.cardsBuf[:n+m]
+	} else {
+		d.cards = append(append(make([]CardIndex, 0, n+m), d.discards...), d.cards...)
+	}
+
+	d.discards = d.discardsBuf[:0]
+
+	return nil
+}
+
+// Mucked возвращает сброшенные карты в порядке сброса
+func (d *Deck) Mucked() []*Card {
+	cards := make([]*Card, len(d.discards))
+
+	for i, c := range d.discards {
+		cards[i] = c.Card()
+	}
+
+	return cards
+}
+
+// Remaining возвращает копию оставшихся в колоде карт
+func (d *Deck) Remaining() []*Card {
+	cards := make([]*Card, len(d.cards))
+
+	for i, c := range d.cards {
+		cards[i] = c.Card()
+	}
+
+	return cards
+}
+
+// RemainingSet возвращает множество оставшихся в колоде карт
+func (d *Deck) RemainingSet() CardSet {
+	var s CardSet
+
+	for _, c := range d.cards {
+		s = s.AddIndex(c)
+	}
+
+	return s
+}
+
+// Clone возвращает копию колоды с тем же порядком карт
+func (d *Deck) Clone() *Deck {
+	clone := &Deck{cardsBuf: d.cardsBuf, discardsBuf: d.discardsBuf}
+	clone.cards = clone.cardsBuf[:0]
+	clone.discards = clone.discardsBuf[:0]
+
+	clone.cards = append(clone.cards, d.cards...)
+	clone.discards = append(clone.discards, d.discards...)
+
+	return clone
+}
 
-	return err
+// Len возвращает количество оставшихся в колоде карт
+func (d *Deck) Len() int {
+	return len(d.cards)
 }
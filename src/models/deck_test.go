@@ -0,0 +1,61 @@
+package models
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDealIndexMatchesCard(t *testing.T) {
+	one := NewDeck().ShuffleWithRandom(rand.New(rand.NewSource(3)))
+	other := NewDeck().ShuffleWithRandom(rand.New(rand.NewSource(3)))
+
+	for one.Len() > 0 {
+		i, err := one.DealIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		card, err := other.Card()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if card.Index() != i {
+			t.Fatalf("DealIndex() = %s, Card() = %s", i, card)
+		}
+	}
+
+	if _, err := one.DealIndex(); err == nil {
+		t.Error("DealIndex() dealt from an empty deck")
+	}
+}
+
+func TestDeckDoesNotAllocate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	deck := NewDeck()
+	pocket := MustParse("Ah Kd Qs Jc")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		deck.ShuffleWithRandom(r)
+
+		for deck.Len() > len(pocket) {
+			if _, err := deck.DealIndex(); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := deck.Discard(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		deck.Muck(pocket...)
+
+		if err := deck.Reshuffle(r); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if allocs != 0 {
+		t.Errorf("deal and muck allocated %.1f times per run, want 0", allocs)
+	}
+}
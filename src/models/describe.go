@@ -0,0 +1,277 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Language - язык описаний рук
+type Language string
+
+const (
+	English Language = "en"
+	Russian Language = "ru"
+)
+
+// handValueNames - названия комбинаций по языкам, в порядке HandValue
+var handValueNames = map[Language][]string{
+	English: {
+		"High Card", "Pair", "Two Pair", "Three of a Kind", "Straight",
+		"Flush", "Full House", "Four of a Kind", "Straight Flush", "Royal Flush",
+	},
+	Russian: {
+		"Старшая карта", "Пара", "Две пары", "Тройка", "Стрит",
+		"Флэш", "Фулл-хаус", "Каре", "Стрит-флэш", "Роял-флэш",
+	},
+}
+
+// handValueCodes - короткие коды комбинаций, в порядке HandValue
+var handValueCodes = []string{"HC", "1P", "2P", "3K", "ST", "FL", "FH", "4K", "SF", "RF"}
+
+// cardValueNames - названия значений карт, от двойки до туза
+var cardValueNames = map[Language][]string{
+	English: {"Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten", "Jack", "Queen", "King", "Ace"},
+	// именительный падеж, единственное число
+	Russian: {"двойка", "тройка", "четверка", "пятерка", "шестерка", "семерка", "восьмерка", "девятка", "десятка",
+		"валет", "дама", "король", "туз"},
+}
+
+// cardValuePlurals - названия значений карт во множественном числе
+var cardValuePlurals = map[Language][]string{
+	English: {"Twos", "Threes", "Fours", "Fives", "Sixes", "Sevens", "Eights", "Nines", "Tens", "Jacks", "Queens", "Kings", "Aces"},
+	Russian: {"двойки", "тройки", "четверки", "пятерки", "шестерки", "семерки", "восьмерки", "девятки", "десятки",
+		"валеты", "дамы", "короли", "тузы"},
+}
+
+// russianGenitivePlurals - названия значений карт в родительном падеже множественного числа ("пара королей")
+var russianGenitivePlurals = []string{"двоек", "троек", "четверок", "пятерок", "шестерок", "семерок", "восьмерок",
+	"девяток", "десяток", "валетов", "дам", "королей", "тузов"}
+
+func (v HandValue) String() string {
+	return v.Name(English)
+}
+
+// Name возвращает название комбинации на языке lang
+func (v HandValue) Name(lang Language) string {
+	names, ok := handValueNames[lang]
+	if !ok {
+		names = handValueNames[English]
+	}
+
+	if v < HighCardHand || int(v) >= len(names) {
+		return fmt.Sprintf("HandValue(%d)", int(v))
+	}
+
+	return names[v]
+}
+
+// Code возвращает короткий код комбинации: "HC", "1P", "2P", "3K", "ST", "FL", "FH", "4K", "SF", "RF"
+func (v HandValue) Code() string {
+	if v < HighCardHand || int(v) >= len(handValueCodes) {
+		return "??"
+	}
+
+	return handValueCodes[v]
+}
+
+// Name возвращает название значения карты на языке lang
+func (cv CardValue) Name(lang Language) string {
+	return cardValueName(cardValueNames, lang, cv)
+}
+
+func cardValueName(names map[Language][]string, lang Language, cv CardValue) string {
+	forms, ok := names[lang]
+	if !ok {
+		forms = names[English]
+	}
+
+	return forms[cv-Two]
+}
+
+// kickers возвращает значения карт, определяющие старшинство руки: сначала карты комбинации, затем кикеры
+func (r HandRank) kickers() []CardValue {
+	values := make([]CardValue, 0, HandSize)
+
+	for i := HandSize - 1; i >= 0; i-- {
+		if v := CardValue(r >> (rankValueBits * i) & (1<<rankValueBits - 1)); v != 0 {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// Code возвращает короткий код руки: код комбинации и значения, определяющие старшинство,
+// например "2P:K7A" - две пары, короли и семерки с кикером туз
+func (r HandRank) Code() string {
+	var b strings.Builder
+
+	b.WriteString(r.Value().Code())
+	b.WriteByte(':')
+
+	for _, v := range r.kickers() {
+		b.WriteByte(startingHandValues[v-Two])
+	}
+
+	return b.String()
+}
+
+// ParseHandCode разбирает короткий код руки, возвращенный HandRank.Code
+func ParseHandCode(code string) (HandRank, error) {
+	name, values, ok := strings.Cut(code, ":")
+	if !ok || len(values) > HandSize {
+		return 0, fmt.Errorf("Wrong hand code %s", code)
+	}
+
+	value := HandValue(-1)
+
+	for i, c := range handValueCodes {
+		if c == name {
+			value = HandValue(i)
+		}
+	}
+
+	if value < 0 {
+		return 0, fmt.Errorf("Wrong hand code %s", code)
+	}
+
+	kickers := make([]CardValue, 0, HandSize)
+
+	for i := 0; i < len(values); i++ {
+		idx := strings.IndexByte(startingHandValues, values[i])
+		if idx < 0 {
+			return 0, fmt.Errorf("Wrong hand code %s", code)
+		}
+
+		kickers = append(kickers, NewCardValue(idx))
+	}
+
+	return newHandRank(value, kickers...), nil
+}
+
+func (r HandRank) String() string {
+	return r.Describe(English)
+}
+
+// Describe описывает руку словами на языке lang, например "Two Pair, Kings and Sevens with an Ace kicker"
+// или "Две пары, короли и семерки, кикер туз"
+func (r HandRank) Describe(lang Language) string {
+	if lang == Russian {
+		return r.describeRussian()
+	}
+
+	return r.describeEnglish()
+}
+
+func (r HandRank) describeEnglish() string {
+	value, k := r.Value(), r.kickers()
+	name := func(i int) string { return cardValueName(cardValueNames, English, k[i]) }
+	plural := func(i int) string { return cardValueName(cardValuePlurals, English, k[i]) }
+	kicker := func(i int) string {
+		if i >= len(k) {
+			return ""
+		}
+
+		article := "a"
+		if k[i] == Ace || k[i] == Eight {
+			article = "an"
+		}
+
+		return fmt.Sprintf(" with %s %s kicker", article, name(i))
+	}
+
+	if len(k) == 0 {
+		return value.String()
+	}
+
+	switch value {
+	case PairHand:
+		return "Pair of " + plural(0) + kicker(1)
+	case TwoPairHand:
+		if len(k) < 2 {
+			break
+		}
+
+		return "Two Pair, " + plural(0) + " and " + plural(1) + kicker(2)
+	case ThreeHand:
+		return "Three of a Kind, " + plural(0) + kicker(1)
+	case FullHouseHand:
+		if len(k) < 2 {
+			break
+		}
+
+		return "Full House, " + plural(0) + " full of " + plural(1)
+	case FourHand:
+		return "Four of a Kind, " + plural(0) + kicker(1)
+	case RoyalFlushHand:
+		return value.String()
+	}
+
+	return value.String() + ", " + name(0) + " high"
+}
+
+func (r HandRank) describeRussian() string {
+	value, k := r.Value(), r.kickers()
+	name := func(i int) string { return cardValueName(cardValueNames, Russian, k[i]) }
+	plural := func(i int) string { return cardValueName(cardValuePlurals, Russian, k[i]) }
+	genitive := func(i int) string { return russianGenitivePlurals[k[i]-Two] }
+	kicker := func(i int) string {
+		if i >= len(k) {
+			return ""
+		}
+
+		return ", кикер " + name(i)
+	}
+
+	title := value.Name(Russian)
+
+	if len(k) == 0 {
+		return title
+	}
+
+	switch value {
+	case HighCardHand:
+		return title + " " + name(0)
+	case PairHand:
+		return "Пара " + genitive(0) + kicker(1)
+	case TwoPairHand:
+		if len(k) < 2 {
+			break
+		}
+
+		return title + ", " + plural(0) + " и " + plural(1) + kicker(2)
+	case ThreeHand:
+		return "Тройка " + genitive(0) + kicker(1)
+	case FullHouseHand:
+		if len(k) < 2 {
+			break
+		}
+
+		return title + ", " + plural(0) + " и " + plural(1)
+	case FourHand:
+		return "Каре " + genitive(0) + kicker(1)
+	case RoyalFlushHand:
+		return title
+	}
+
+	return title + ", старшая карта " + name(0)
+}
+
+// Rank возвращает числовую оценку руки
+func (h *Hand) Rank() HandRank {
+	return RankCards(h.Slice())
+}
+
+// Describe описывает руку словами на языке lang
+func (h *Hand) Describe(lang Language) string {
+	return h.Rank().Describe(lang)
+}
+
+// Code возвращает короткий код руки
+func (h *Hand) Code() string {
+	return h.Rank().Code()
+}
+
+func (h *Hand) String() string {
+	return h.Describe(English)
+}
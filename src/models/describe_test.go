@@ -0,0 +1,98 @@
+package models
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		cards   string
+		code    string
+		english string
+		russian string
+	}{
+		{
+			cards: "Ks Kh 7d 7c Ah 2c 3d", code: "2P:K7A",
+			english: "Two Pair, Kings and Sevens with an Ace kicker",
+			russian: "Две пары, короли и семерки, кикер туз",
+		},
+		{
+			cards: "Qh 9h 7h 4h 2h Kc 3d", code: "FL:Q9742",
+			english: "Flush, Queen high",
+			russian: "Флэш, старшая карта дама",
+		},
+		{
+			cards: "Ah Kh Qh Jh Th 2c 3d", code: "RF:A",
+			english: "Royal Flush",
+			russian: "Роял-флэш",
+		},
+		{
+			cards: "9s 9h 9d 9c Kh", code: "4K:9K",
+			english: "Four of a Kind, Nines with a King kicker",
+			russian: "Каре девяток, кикер король",
+		},
+		{
+			cards: "Ks Kh Kd 4c 4h", code: "FH:K4",
+			english: "Full House, Kings full of Fours",
+			russian: "Фулл-хаус, короли и четверки",
+		},
+		{
+			cards: "9h 8d 7c 6s 5h", code: "ST:9",
+			english: "Straight, Nine high",
+			russian: "Стрит, старшая карта девятка",
+		},
+		{
+			cards: "7s 7h 7d Kc 2h", code: "3K:7K2",
+			english: "Three of a Kind, Sevens with a King kicker",
+			russian: "Тройка семерок, кикер король",
+		},
+		{
+			cards: "8s 8h Ad 5c 2h", code: "1P:8A52",
+			english: "Pair of Eights with an Ace kicker",
+			russian: "Пара восьмерок, кикер туз",
+		},
+		{
+			cards: "Js Jh 8d 5c 2h", code: "1P:J852",
+			english: "Pair of Jacks with an Eight kicker",
+			russian: "Пара валетов, кикер восьмерка",
+		},
+		{
+			cards: "Ks Jh 8d 5c 2h", code: "HC:KJ852",
+			english: "High Card, King high",
+			russian: "Старшая карта король",
+		},
+	}
+
+	for _, tt := range tests {
+		rank := RankCards(MustParse(tt.cards))
+
+		if got := rank.Code(); got != tt.code {
+			t.Errorf("RankCards(%s).Code() = %s, want %s", tt.cards, got, tt.code)
+		}
+
+		if got := rank.Describe(English); got != tt.english {
+			t.Errorf("RankCards(%s).Describe(English) = %q, want %q", tt.cards, got, tt.english)
+		}
+
+		if got := rank.Describe(Russian); got != tt.russian {
+			t.Errorf("RankCards(%s).Describe(Russian) = %q, want %q", tt.cards, got, tt.russian)
+		}
+
+		parsed, err := ParseHandCode(tt.code)
+		if err != nil {
+			t.Errorf("ParseHandCode(%s) returned %v", tt.code, err)
+
+			continue
+		}
+
+		if parsed != rank {
+			t.Errorf("ParseHandCode(%s) = %s, want %s", tt.code, parsed.Code(), tt.code)
+		}
+	}
+}
+
+func TestParseHandCodeErrors(t *testing.T) {
+	for _, code := range []string{"", "2P", "XX:AK", "1P:AZ", "HC:AKQJT9"} {
+		if _, err := ParseHandCode(code); err == nil {
+			t.Errorf("ParseHandCode(%q) accepted", code)
+		}
+	}
+}
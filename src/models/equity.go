@@ -0,0 +1,222 @@
+package models
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/stat/combin"
+
+	"hands/src/helpers"
+)
+
+const (
+	// ExactEquityLimit - максимальное количество вариантов доставки общих карт, при котором эквити
+	// считается полным перебором. При большем количестве используется метод Монте-Карло
+	ExactEquityLimit = 2000
+
+	// EquitySamples - количество случайных вариантов доставки общих карт при расчете методом Монте-Карло
+	EquitySamples = 3000
+)
+
+// Equity рассчитывает эквити рук игроков (pockets - карманные карты по ID игроков) - долю банка,
+// которую каждый из них выигрывает в среднем при доставке недостающих общих карт к board из remaining.
+// При дележе банка каждый из победителей получает равную часть.
+func Equity(pockets map[string][]*Card, board, remaining []*Card) (map[string]float64, error) {
+	return EquityWithRandom(pockets, board, remaining, helpers.NewRandom())
+}
+
+// EquityWithRandom рассчитывает эквити так же, как Equity, используя для метода Монте-Карло генератор r
+func EquityWithRandom(pockets map[string][]*Card, board, remaining []*Card, r *rand.Rand) (map[string]float64, error) {
+	return VariantEquity(HoldemVariant, pockets, board, remaining, r)
+}
+
+// VariantEquity рассчитывает эквити так же, как EquityWithRandom, но победителей определяет вариант игры v
+// (Variant.Showdown): в омахе рука собирается из двух карманных карт, а в играх хай-лоу банк делится
+// между старшими и младшими руками. Подходит только для вариантов с общими картами
+func VariantEquity(v Variant, pockets map[string][]*Card, board, remaining []*Card, r *rand.Rand) (map[string]float64, error) {
+	missing := BoardSize - len(board)
+
+	if missing < 0 || missing > len(remaining) {
+		return nil, fmt.Errorf("Wrong board size %d to calculate equity", len(board))
+	}
+
+	if len(pockets) < 2 {
+		return nil, fmt.Errorf("At least two hands are required to calculate equity, got %d", len(pockets))
+	}
+
+	c := newEquityCounter(v, pockets, board)
+
+	if combin.Binomial(len(remaining), missing) <= ExactEquityLimit {
+		return exactEquity(c, remaining, missing)
+	}
+
+	return monteCarloEquity(c, remaining, missing, r)
+}
+
+// equityCounter накапливает доли выигранных банков
+type equityCounter struct {
+	variant Variant
+	ids     []string
+	// pockets - карманные карты игроков в порядке ids
+	pockets [][]*Card
+	board   []*Card
+	wins    map[string]float64
+	total   int
+	// full - буфер для общих карт вместе с доставленными
+	full []*Card
+}
+
+func newEquityCounter(v Variant, pockets map[string][]*Card, board []*Card) *equityCounter {
+	c := &equityCounter{
+		variant: v,
+		ids:     make([]string, 0, len(pockets)),
+		pockets: make([][]*Card, 0, len(pockets)),
+		board:   board,
+		wins:    make(map[string]float64),
+		full:    make([]*Card, 0, BoardSize),
+	}
+
+	for id := range pockets {
+		c.ids = append(c.ids, id)
+	}
+
+	sort.Strings(c.ids)
+
+	for _, id := range c.ids {
+		c.pockets = append(c.pockets, pockets[id])
+	}
+
+	return c
+}
+
+// add разыгрывает банк при доставке карт runout. Банк делится поровну между непустыми группами
+// победителей (хай и лоу), а каждая группа - поровну между ее игроками
+func (c *equityCounter) add(runout []*Card) {
+	c.full = append(append(c.full[:0], c.board...), runout...)
+
+	groups := c.variant.Showdown(c.pockets, c.full)
+	parts := 0
+
+	for _, winners := range groups {
+		if len(winners) > 0 {
+			parts++
+		}
+	}
+
+	for _, winners := range groups {
+		for _, i := range winners {
+			c.wins[c.ids[i]] += 1 / float64(parts) / float64(len(winners))
+		}
+	}
+
+	c.total++
+}
+
+func (c *equityCounter) equity() map[string]float64 {
+	equity := make(map[string]float64)
+
+	for _, id := range c.ids {
+		equity[id] = c.wins[id] / float64(c.total)
+	}
+
+	return equity
+}
+
+func exactEquity(c *equityCounter, remaining []*Card, missing int) (map[string]float64, error) {
+	if missing == 0 {
+		c.add(nil)
+
+		return c.equity(), nil
+	}
+
+	gen := combin.NewCombinationGenerator(len(remaining), missing)
+	combo := make([]int, missing)
+	runout := make([]*Card, missing)
+
+	for gen.Next() {
+		for i, idx := range gen.Combination(combo) {
+			runout[i] = remaining[idx]
+		}
+
+		c.add(runout)
+	}
+
+	return c.equity(), nil
+}
+
+func monteCarloEquity(c *equityCounter, remaining []*Card, missing int, r *rand.Rand) (map[string]float64, error) {
+	cards := append(make([]*Card, 0, len(remaining)), remaining...)
+
+	for i := 0; i < EquitySamples; i++ {
+		// частичное тасование: первые missing карт - случайная выборка
+		for j := 0; j < missing; j++ {
+			k := j + r.Intn(len(cards)-j)
+			cards[j], cards[k] = cards[k], cards[j]
+		}
+
+		c.add(cards[:missing])
+	}
+
+	return c.equity(), nil
+}
+
+// deadCardsDeck возвращает все карты колоды, кроме dead
+func deadCardsDeck(dead ...[]*Card) []*Card {
+	live := FullDeck
+
+	for _, d := range dead {
+		live = live.Difference(NewCardSet(d...))
+	}
+
+	return live.Cards()
+}
+
+// EquityVsRandom рассчитывает методом Монте-Карло эквити руки pocket против opponents случайных рук
+// при общих картах board, используя samples случайных раздач
+func EquityVsRandom(pocket, board []*Card, opponents, samples int, r *rand.Rand) (float64, error) {
+	missing := BoardSize - len(board)
+	cards := deadCardsDeck(pocket, board)
+
+	if len(pocket) != PocketSize || missing < 0 || opponents < 1 || PocketSize*opponents+missing > len(cards) {
+		return 0, fmt.Errorf("Can't calculate equity of %d cards vs %d opponents on board of %d cards",
+			len(pocket), opponents, len(board))
+	}
+
+	hero := make([]*Card, 0, BoardSize+PocketSize)
+	villain := make([]*Card, 0, BoardSize+PocketSize)
+	full := make([]*Card, 0, BoardSize)
+	wins := 0.0
+
+	for i := 0; i < samples; i++ {
+		needed := PocketSize*opponents + missing
+
+		for j := 0; j < needed; j++ {
+			k := j + r.Intn(len(cards)-j)
+			cards[j], cards[k] = cards[k], cards[j]
+		}
+
+		full = append(append(full[:0], board...), cards[:missing]...)
+		best := RankCards(append(append(hero[:0], full...), pocket...))
+		ties := 1
+		lost := false
+
+		for o := 0; o < opponents && !lost; o++ {
+			offset := missing + o*PocketSize
+			rank := RankCards(append(append(villain[:0], full...), cards[offset:offset+PocketSize]...))
+
+			switch {
+			case rank > best:
+				lost = true
+			case rank == best:
+				ties++
+			}
+		}
+
+		if !lost {
+			wins += 1 / float64(ties)
+		}
+	}
+
+	return wins / float64(samples), nil
+}
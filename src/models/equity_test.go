@@ -0,0 +1,221 @@
+package models
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// liveCards возвращает оставшиеся карты колоды без карт рук pockets и общих карт board
+func liveCards(board string, pockets ...string) []*Card {
+	dead := MustParse(board)
+	for _, p := range pockets {
+		dead = append(dead, MustParse(p)...)
+	}
+
+	return FullDeck.Difference(NewCardSet(dead...)).Cards()
+}
+
+func TestExactEquity(t *testing.T) {
+	tests := []struct {
+		name  string
+		board string
+		one   string
+		other string
+		want  float64
+	}{
+		{name: "river", board: "2c 7h 9d Jc 3s", one: "As Ad", other: "Ks Kd", want: 1},
+		// на терне королям помогают только два оставшихся короля из 44 карт
+		{name: "turn", board: "2c 7h 9d Jc", one: "As Ad", other: "Ks Kd", want: 42.0 / 44},
+		{name: "split", board: "2c 7h 9d Jc", one: "Ah Kd", other: "As Kc", want: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pockets := map[string][]*Card{"one": MustParse(tt.one), "other": MustParse(tt.other)}
+
+			equity, err := Equity(pockets, MustParse(tt.board), liveCards(tt.board, tt.one, tt.other))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if math.Abs(equity["one"]-tt.want) > 1e-9 || math.Abs(equity["one"]+equity["other"]-1) > 1e-9 {
+				t.Errorf("equity = %v, want %.4f for one", equity, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonteCarloEquity(t *testing.T) {
+	pockets := map[string][]*Card{"aces": MustParse("As Ad"), "kings": MustParse("Ks Kd")}
+
+	equity, err := EquityWithRandom(pockets, nil, liveCards("", "As Ad", "Ks Kd"), rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// тузы против королей до флопа - около 82%
+	if math.Abs(equity["aces"]-0.82) > 0.03 || math.Abs(equity["aces"]+equity["kings"]-1) > 1e-9 {
+		t.Errorf("AA vs KK equity = %v, want about 0.82", equity)
+	}
+
+	again, err := EquityWithRandom(pockets, nil, liveCards("", "As Ad", "Ks Kd"), rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(equity, again) {
+		t.Errorf("equity with the same seed differs: %v and %v", equity, again)
+	}
+}
+
+func TestEquityErrors(t *testing.T) {
+	pockets := map[string][]*Card{"one": MustParse("As Ad"), "other": MustParse("Ks Kd")}
+	remaining := liveCards("", "As Ad", "Ks Kd")
+
+	if _, err := Equity(map[string][]*Card{"one": MustParse("As Ad")}, nil, remaining); err == nil {
+		t.Error("Equity() accepted a single hand")
+	}
+
+	if _, err := Equity(pockets, MustParse("2c 3c 4c 5c 6c 7c"), remaining); err == nil {
+		t.Error("Equity() accepted a board of six cards")
+	}
+
+	if _, err := Equity(pockets, nil, MustParse("2c 3c")); err == nil {
+		t.Error("Equity() accepted too few remaining cards")
+	}
+}
+
+func TestVariantEquity(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant Variant
+		board   string
+		hero    string
+		villain string
+		want    float64
+	}{
+		// в холдеме у hero роял-флэш, а в омахе нужны две карманные карты, и тройка villain сильнее
+		{name: "holdem", variant: HoldemVariant, board: "Kh Qh Jh Th 9c", hero: "Ah 2c", villain: "9s 9d", want: 1},
+		{name: "omaha", variant: OmahaVariant, board: "Kh Qh Jh Th 9c", hero: "Ah 2c 3d 4s", villain: "9s 9d 5c 6c", want: 0},
+		// hero забирает лоу, villain - хай
+		{name: "omaha hi-lo", variant: OmahaHiLoVariant, board: "Ks Qs 7h 4d 2c", hero: "Ac 3d 9h 9d", villain: "Kd Kh 8s 8c", want: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pockets := map[string][]*Card{"hero": MustParse(tt.hero), "villain": MustParse(tt.villain)}
+
+			remaining := liveCards(tt.board, tt.hero, tt.villain)
+
+			equity, err := VariantEquity(tt.variant, pockets, MustParse(tt.board), remaining, rand.New(rand.NewSource(1)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if math.Abs(equity["hero"]-tt.want) > 1e-9 || math.Abs(equity["hero"]+equity["villain"]-1) > 1e-9 {
+				t.Errorf("equity = %v, want %.2f for hero", equity, tt.want)
+			}
+		})
+	}
+}
+
+func TestRabbitHunt(t *testing.T) {
+	table, _ := newTestTable(t, HoldemVariant, "2c 7h 9d", []testSeat{
+		{name: "first", pocket: "As Ad"},
+		{name: "second", pocket: "Ks Kd", folded: true},
+	})
+
+	// карты сдаются с конца: 4h сжигается, 3h - терн, Qs сжигается, Ks - ривер
+	table.deck = newDeckFromCards(MustParse("Ks Qs 3h 4h"))
+
+	var events []*TableEvent
+	table.Subscribe(func(e *TableEvent) { events = append(events, e) })
+
+	cards, err := table.RabbitHunt()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := NewStringSliceFromCards(cards); !reflect.DeepEqual(got, []string{"3H", "KS"}) {
+		t.Errorf("RabbitHunt() = %v, want [3H KS]", got)
+	}
+
+	if got := NewStringSliceFromCards(table.deck.Remaining()); !reflect.DeepEqual(got, []string{"KS", "QS", "3H", "4H"}) {
+		t.Errorf("RabbitHunt() changed the deck to %v", got)
+	}
+
+	if len(table.Board) != 3 {
+		t.Errorf("RabbitHunt() changed the board to %d cards", len(table.Board))
+	}
+
+	if len(events) != 1 || events[0].Type != RabbitHuntEvent || len(events[0].Board) != BoardSize || len(events[0].Cards) != 2 {
+		t.Errorf("RabbitHunt() emitted %+v", events)
+	}
+}
+
+func TestEquityEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant Variant
+		board   string
+		first   string
+		second  string
+		allIn   bool
+		// equity - ожидается событие EquityEvent
+		equity bool
+	}{
+		{name: "holdem all-in", variant: HoldemVariant, board: "2c 7h 9d", first: "As Ad", second: "Ks Kd", allIn: true, equity: true},
+		{name: "holdem with chips", variant: HoldemVariant, board: "2c 7h 9d", first: "As Ad", second: "Ks Kd"},
+		{
+			name: "omaha all-in", variant: OmahaVariant, board: "2c 7h 9d",
+			first: "As Ad 3c 4c", second: "Ks Kd 5h 6h", allIn: true, equity: true,
+		},
+		{
+			name: "stud all-in", variant: StudVariant,
+			first: "As Ad 3c 4c 8s", second: "Ks Kd 5h 6h 8h", allIn: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newTestTable(t, tt.variant, tt.board, []testSeat{
+				{name: "first", pocket: tt.first},
+				{name: "second", pocket: tt.second},
+			})
+
+			table.deck = NewDeck().ShuffleCards(liveCards(tt.board, tt.first, tt.second), rand.New(rand.NewSource(1)))
+
+			if tt.allIn {
+				for _, p := range players {
+					p.Post(p.GetCurrentChipsAmount())
+				}
+			}
+
+			var events []*TableEvent
+			table.Subscribe(func(e *TableEvent) { events = append(events, e) })
+
+			table.notifyStreet()
+
+			if len(events) == 0 || events[0].Type != StreetEvent {
+				t.Fatalf("notifyStreet() emitted %+v, want a street event first", events)
+			}
+
+			if got := len(events) == 2 && events[1].Type == EquityEvent; got != tt.equity {
+				t.Fatalf("equity event emitted: %t, want %t", got, tt.equity)
+			}
+
+			if !tt.equity {
+				return
+			}
+
+			equity := events[1].Equity
+			first, second := equity[players["first"].ID], equity[players["second"].ID]
+
+			if len(equity) != 2 || math.Abs(first+second-1) > 1e-9 || first <= second {
+				t.Errorf("equity = %v, want the aces ahead", equity)
+			}
+		})
+	}
+}
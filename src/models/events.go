@@ -0,0 +1,140 @@
+package models
+
+import "hands/src/helpers"
+
+// EventType - тип события стола
+type EventType string
+
+const (
+	// StreetEvent - на стол выложены карты очередной улицы
+	StreetEvent EventType = "street"
+	// EquityEvent - эквити игроков, находящихся в олл-ине, на текущей улице. Рассылается только в вариантах
+	// с общими картами: холдем, омаха, пайнэппл
+	EquityEvent EventType = "equity"
+	// RabbitHuntEvent - открыты карты, которые пришли бы, если бы раздача не закончилась досрочно
+	RabbitHuntEvent EventType = "rabbit_hunt"
+)
+
+// TableEvent - событие стола, рассылаемое подписчикам (например, для трансляций)
+type TableEvent struct {
+	Type    EventType
+	TableID string
+	Board   []*Card
+	// Cards - карты, открытые при "охоте на кролика"
+	Cards []*Card
+	// Equity - эквити игроков по их ID
+	Equity map[string]float64
+}
+
+// EventHandler - обработчик событий стола. Вызывается синхронно, без блокировки стола
+type EventHandler func(event *TableEvent)
+
+// Subscribe добавляет обработчик событий стола
+func (t *Table) Subscribe(handler EventHandler) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.handlers = append(t.handlers, handler)
+}
+
+func (t *Table) emit(event *TableEvent) {
+	t.m.RLock()
+	handlers := append(make([]EventHandler, 0, len(t.handlers)), t.handlers...)
+	t.m.RUnlock()
+
+	for _, h := range handlers {
+		h(event)
+	}
+}
+
+// allInPockets возвращает карманные карты игроков, оставшихся в раздаче, если торговля невозможна:
+// фишки остались не более чем у одного из них. Иначе, а также в вариантах без общих карт (стад, дро),
+// где недостающие карты приходят игрокам, а не на стол, возвращает nil
+func (t *Table) allInPockets() map[string][]*Card {
+	if !hasBoard(t.variant()) {
+		return nil
+	}
+
+	pockets := make(map[string][]*Card)
+	withChips := 0
+
+	for _, p := range t.Players {
+		if !p.Active || len(p.GetPocketCards()) == 0 {
+			continue
+		}
+
+		pockets[p.ID] = p.GetPocketCards()
+
+		if p.GetCurrentChipsAmount() > 0 {
+			withChips++
+		}
+	}
+
+	if len(pockets) < 2 || withChips > 1 {
+		return nil
+	}
+
+	return pockets
+}
+
+// notifyStreet оповещает подписчиков о выложенной улице, а если игроки в олл-ине - и об их эквити
+func (t *Table) notifyStreet() {
+	t.m.RLock()
+
+	// эквити дорого считать, а слушать некому
+	if len(t.handlers) == 0 {
+		t.m.RUnlock()
+
+		return
+	}
+
+	board := append(make([]*Card, 0, BoardSize), t.Board...)
+	pockets := t.allInPockets()
+	remaining := t.deck.Remaining()
+	t.m.RUnlock()
+
+	t.emit(&TableEvent{
+		Type:    StreetEvent,
+		TableID: t.ID,
+		Board:   board,
+	})
+
+	if pockets == nil {
+		return
+	}
+
+	// ошибка здесь означает, что раздача еще не дошла до карманных карт - эквити не транслируем
+	equity, err := VariantEquity(t.variant(), pockets, board, remaining, helpers.NewRandom())
+	if err != nil {
+		return
+	}
+
+	t.emit(&TableEvent{
+		Type:    EquityEvent,
+		TableID: t.ID,
+		Board:   board,
+		Equity:  equity,
+	})
+}
+
+// RabbitHunt открывает общие карты, которые пришли бы, если бы раздача не закончилась досрочно.
+// Карты берутся из копии колоды, поэтому колода стола не меняется. Возвращает открытые карты
+func (t *Table) RabbitHunt() ([]*Card, error) {
+	t.m.RLock()
+	board, err := dealRunout(t.deck.Clone(), t.Board)
+	opened := len(t.Board)
+	t.m.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.emit(&TableEvent{
+		Type:    RabbitHuntEvent,
+		TableID: t.ID,
+		Board:   board,
+		Cards:   board[opened:],
+	})
+
+	return board[opened:], nil
+}
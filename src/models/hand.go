@@ -4,7 +4,7 @@ import (
 	"errors"
 	"sort"
 
-	"hands/src/helpers"
+	"gonum.org/v1/gonum/stat/combin"
 )
 
 const (
@@ -34,24 +34,56 @@ const (
 /* Операции с наборами карт, представленными как []string и []*Cards */
 
 // getMaxHand определяет максимальную руку из набора комбинаций из 7 карт по 5.
-// Возвращает ошибку, если len(cards) != 7
+// Возвращает ошибку, если len(cards) != 7 или карта не распознана
 func getMaxHand(cards []string) (*Hand, error) {
 	if len(cards) != HandSize+PocketSize {
 		return nil, errors.New("Wrong number of cards to calculate combinations")
 	}
 
-	combos := helpers.GetCombinations(cards, HandSize)
-	hands := make([]*Hand, len(combos))
+	cc := make([]*Card, len(cards))
+
+	for i, c := range cards {
+		card, err := ParseCard(c)
+		if err != nil {
+			return nil, err
+		}
 
-	for i, c := range combos {
-		hands[i] = NewHandFromStrings(c)
+		cc[i] = card
 	}
 
-	sort.Slice(hands, func(i, j int) bool {
-		return hands[i].Compare(hands[j]) < 0
-	})
+	return getMaxHandFromCards(cc), nil
+}
+
+// GetBestHand определяет максимальную руку из пяти карт среди карт cards (от 5 до 7 карт)
+func GetBestHand(cards []*Card) (*Hand, error) {
+	if len(cards) < HandSize || len(cards) > HandSize+PocketSize {
+		return nil, errors.New("Wrong number of cards to calculate combinations")
+	}
 
-	return hands[len(hands)-1], nil
+	return getMaxHandFromCards(cards), nil
+}
+
+// getMaxHandFromCards определяет максимальную руку из всех комбинаций карт cards по 5
+func getMaxHandFromCards(cards []*Card) *Hand {
+	var best *Hand
+
+	gen := combin.NewCombinationGenerator(len(cards), HandSize)
+	combo := make([]int, HandSize)
+	hand := make([]*Card, HandSize)
+
+	for gen.Next() {
+		for i, num := range gen.Combination(combo) {
+			hand[i] = cards[num]
+		}
+
+		h := NewHandFromCards(hand)
+
+		if best == nil || h.Compare(best) > 0 {
+			best = h
+		}
+	}
+
+	return best
 }
 
 func max(cards []*Card) *Card {
@@ -78,23 +110,31 @@ func deleteCard(cards []*Card, cardsSlice ...*Card) []*Card {
 }
 
 func countDuplicates(hand []*Card) int {
-	visited := make(map[string]bool)
+	var visited CardSet
+
 	count := 0
 
-	for _, card := range hand {
-		if visited[card.String()] {
+	for i, card := range hand {
+		switch idx := card.Index(); {
+		case idx == NoCard && inSlice(hand[:i], card):
 			count++
-		} else {
-			visited[card.String()] = true
+		case visited.Has(idx):
+			count++
+		default:
+			visited = visited.AddIndex(idx)
 		}
 	}
 
 	return count
 }
 
+// inSlice проверяет, есть ли карта card среди cards. Карты сравниваются по номерам,
+// а карты с неизвестным значением или мастью - по строковой записи
 func inSlice(cards []*Card, card *Card) bool {
+	idx := card.Index()
+
 	for _, c := range cards {
-		if card.String() == c.String() {
+		if c.Index() == idx && (idx != NoCard || card.String() == c.String()) {
 			return true
 		}
 	}
@@ -105,19 +145,13 @@ func inSlice(cards []*Card, card *Card) bool {
 /* Hand */
 
 // Hand  - покерная комбинация из пяти карт.
-// Поле cardsMap - мапа, ключи которой - строковые представления карт (QH, 10C),
-// а значения - количество карт данного номинала в комбинации. К примеру, для комбинации []string{"10H","10D","AC","6H","3S"}
-// это будет
-//
-//	map[string]int{
-//		"10H":"2",
-//		"10D":"2",
-//		"AC":"1",
-//		"6H":"1",
-//		"3S":"1"
-//	}
+// Поле set - множество карт комбинации, по которому считаются карты каждого номинала, order - номера карт
+// в исходном порядке для вывода и записи руки, value - величина руки. Карты с неизвестным значением
+// или мастью в руку не входят
 type Hand struct {
-	cardsMap map[string]int
+	set   CardSet
+	order []CardIndex
+	value HandValue
 }
 
 func ValidateHand(hand []*Card) error {
@@ -133,20 +167,19 @@ func ValidateHand(hand []*Card) error {
 }
 
 func NewHandFromCards(cards []*Card) *Hand {
-	m := make(map[CardValue]int)
-
-	for _, card := range cards {
-		m[card.Value]++
-	}
-
 	h := &Hand{
-		cardsMap: make(map[string]int),
+		set:   NewCardSet(cards...),
+		order: make([]CardIndex, 0, len(cards)),
 	}
 
 	for _, card := range cards {
-		h.cardsMap[card.String()] = m[card.Value]
+		if i := card.Index(); i != NoCard {
+			h.order = append(h.order, i)
+		}
 	}
 
+	h.value = h.define()
+
 	return h
 }
 
@@ -160,33 +193,43 @@ func NewHandFromStrings(cards []string) *Hand {
 	return NewHandFromCards(cc)
 }
 
+// Slice возвращает карты руки в исходном порядке
 func (h *Hand) Slice() []*Card {
-	cc := make([]*Card, 0)
+	cards := make([]*Card, len(h.order))
 
-	for card := range h.cardsMap {
-		cc = append(cc, NewCardFromString(card))
+	for i, c := range h.order {
+		cards[i] = c.Card()
 	}
 
-	return cc
+	return cards
 }
 
 func (h *Hand) StringSlice() []string {
-	cc := make([]string, 0)
+	return NewStringSliceFromCards(h.set.Cards())
+}
 
-	for card := range h.cardsMap {
-		cc = append(cc, card)
-	}
+// Set возвращает множество карт руки
+func (h *Hand) Set() CardSet {
+	return h.set
+}
 
-	return cc
+// Contains проверяет, входит ли карта c в руку
+func (h *Hand) Contains(c *Card) bool {
+	return h.set.Contains(c)
 }
 
-// Define определяет величину руки
+// Define возвращает величину руки
 func (h *Hand) Define() HandValue {
+	return h.value
+}
+
+// define определяет величину руки
+func (h *Hand) define() HandValue {
 	isStraight := h.HasStraight()
 	isFlush := h.HasFlush()
 
 	if isStraight && isFlush && isFlush != false {
-		if h.Max().Value == Ace {
+		if h.StraightHigh() == Ace {
 			return RoyalFlushHand
 		} else {
 			return StraightFlushHand
@@ -198,7 +241,7 @@ func (h *Hand) Define() HandValue {
 	}
 
 	if isStraight {
-		return StraightFlushHand
+		return StraightHand
 	}
 
 	ok := h.HasThree()
@@ -241,9 +284,9 @@ func (h *Hand) Compare(other *Hand) int {
 func (h *Hand) CountPairs() int {
 	count := 0
 
-	for _, num := range h.cardsMap {
-		if num == 2 {
-			count++
+	for v := Two; v <= Ace; v++ {
+		if num := h.set.CountValue(v); num == 2 {
+			count += num
 		}
 	}
 
@@ -253,11 +296,13 @@ func (h *Hand) CountPairs() int {
 // GetPair получает комбинацию Pair в случае, если рука была ранее определена как Pair.
 // Возвращает пару, две карты, не входящие в комбинацию и кикер (старшую карту).
 func (h *Hand) GetPair() (hand []*Card, remains []*Card, high *Card) {
-	for card, num := range h.cardsMap {
+	for _, card := range h.Slice() {
+		num := h.set.CountValue(card.Value)
+
 		if num == 2 {
-			hand = append(hand, NewCardFromString(card))
+			hand = append(hand, card)
 		} else {
-			remains = append(remains, NewCardFromString(card))
+			remains = append(remains, card)
 		}
 	}
 
@@ -271,21 +316,23 @@ func (h *Hand) GetPair() (hand []*Card, remains []*Card, high *Card) {
 // GetTwoPair получает комбинацию TwoPair в случае, если рука была ранее определена как TwoPair.
 // Возвращает старшую пару, младшую пару и кикер (старшую карту).
 func (h *Hand) GetTwoPair() (one []*Card, other []*Card, high *Card) {
-	for card, num := range h.cardsMap {
+	for _, card := range h.Slice() {
+		num := h.set.CountValue(card.Value)
+
 		if num == 2 {
 			if len(one) == 0 {
-				one = append(one, NewCardFromString(card))
+				one = append(one, card)
 			} else if len(one) == 1 {
-				if one[0].CompareValues(NewCardFromString(card)) == 0 {
-					one = append(one, NewCardFromString(card))
+				if one[0].CompareValues(card) == 0 {
+					one = append(one, card)
 				} else {
-					other = append(other, NewCardFromString(card))
+					other = append(other, card)
 				}
 			} else {
-				other = append(other, NewCardFromString(card))
+				other = append(other, card)
 			}
 		} else {
-			high = NewCardFromString(card)
+			high = card
 		}
 	}
 
@@ -294,23 +341,19 @@ func (h *Hand) GetTwoPair() (one []*Card, other []*Card, high *Card) {
 
 // HasThree определяет, является ли рука тройкой
 func (h *Hand) HasThree() bool {
-	for _, num := range h.cardsMap {
-		if num == 3 {
-			return true
-		}
-	}
-
-	return false
+	return h.hasGroup(3)
 }
 
 // GetThree получает комбинацию Three в случае, если рука была ранее определена как Three.
 // Возвращает тройку, слайс карт с единственным элементом - картой остатка, - и кикер (старшую карту).
 func (h *Hand) GetThree() (hand []*Card, remains []*Card, high *Card) {
-	for card, num := range h.cardsMap {
+	for _, card := range h.Slice() {
+		num := h.set.CountValue(card.Value)
+
 		if num == 3 {
-			hand = append(hand, NewCardFromString(card))
+			hand = append(hand, card)
 		} else {
-			remains = append(remains, NewCardFromString(card))
+			remains = append(remains, card)
 		}
 	}
 
@@ -323,8 +366,13 @@ func (h *Hand) GetThree() (hand []*Card, remains []*Card, high *Card) {
 
 // HasFour определяет, является ли рука Four
 func (h *Hand) HasFour() bool {
-	for _, num := range h.cardsMap {
-		if num == 4 {
+	return h.hasGroup(4)
+}
+
+// hasGroup проверяет, есть ли в руке ровно size карт одного номинала
+func (h *Hand) hasGroup(size int) bool {
+	for v := Two; v <= Ace; v++ {
+		if h.set.CountValue(v) == size {
 			return true
 		}
 	}
@@ -335,9 +383,11 @@ func (h *Hand) HasFour() bool {
 // GetFour получает комбинацию Four в случае, если рука была ранее определена как Four.
 // Возвращает слайс из четырех карт
 func (h *Hand) GetFour() (hand []*Card) {
-	for card, num := range h.cardsMap {
+	for _, card := range h.Slice() {
+		num := h.set.CountValue(card.Value)
+
 		if num == 4 {
-			hand = append(hand, NewCardFromString(card))
+			hand = append(hand, card)
 		}
 	}
 
@@ -346,39 +396,41 @@ func (h *Hand) GetFour() (hand []*Card) {
 
 // HasFlush определяет, является ли рука Flush
 func (h *Hand) HasFlush() bool {
-	firstCard := ""
-
-	for card := range h.cardsMap {
-		firstCard = card
-		if true {
-			break
-		}
-	}
-
 	ok := true
+	cards := h.Slice()
 
-	for card := range h.cardsMap {
-		ok = ok && NewCardFromString(card).Suite.Compare(NewCardFromString(firstCard).Suite)
+	for _, card := range cards {
+		ok = ok && card.CompareSuites(cards[0])
 	}
 
 	return ok
 }
 
-// HasStraight определяет, является ли рука стритом
+// HasStraight определяет, является ли рука стритом. Туз может быть как старшей картой, так и младшей (A2345)
 func (h *Hand) HasStraight() bool {
-	cards := h.Slice()
+	return h.StraightHigh() != 0
+}
 
-	sort.Slice(cards, func(i, j int) bool {
-		return cards[i].Value < cards[j].Value
-	})
+// StraightHigh возвращает старшую карту стрита (для A2345 это пятерка), или 0, если рука не является стритом
+func (h *Hand) StraightHigh() CardValue {
+	values := sortedValues(h.Slice())
 
-	ok := true
+	if len(values) != HandSize {
+		return 0
+	}
 
-	for i := 1; i < len(cards); i++ {
-		ok = ok && int(cards[i].Value)-int(cards[i-1].Value) == 1
+	// колесо: A5432
+	if values[0] == Ace && values[1] == Five && values[4] == Two {
+		values = append(values[1:], 1)
 	}
 
-	return ok
+	for i := 1; i < len(values); i++ {
+		if int(values[i-1])-int(values[i]) != 1 {
+			return 0
+		}
+	}
+
+	return values[0]
 }
 
 // HasFullHouse определяет, является ли рука FH
@@ -389,11 +441,13 @@ func (h *Hand) HasFullHouse() bool {
 // GetFullHouse получает комбинацию FullHouse в случае, если рука была ранее определена как FullHouse.
 // Возвращает тройку и двойку карт, одинаковых по значению.
 func (h *Hand) GetFullHouse() (three []*Card, two []*Card) {
-	for card, num := range h.cardsMap {
+	for _, card := range h.Slice() {
+		num := h.set.CountValue(card.Value)
+
 		if num == 3 {
-			three = append(three, NewCardFromString(card))
+			three = append(three, card)
 		} else {
-			two = append(two, NewCardFromString(card))
+			two = append(two, card)
 		}
 	}
 
@@ -401,24 +455,12 @@ func (h *Hand) GetFullHouse() (three []*Card, two []*Card) {
 }
 
 func (h *Hand) Max() *Card {
-	cc := make([]*Card, 0)
-
-	for c := range h.cardsMap {
-		cc = append(cc, NewCardFromString(c))
-	}
-
-	return max(cc)
+	return max(h.Slice())
 }
 
 // Same определяет, входят ли в другую руку те же самые карты.
 func (h *Hand) Same(other *Hand) bool {
-	for card, num := range h.cardsMap {
-		if v, ok := other.cardsMap[card]; !ok && v != num {
-			return false
-		}
-	}
-
-	return true
+	return h.set == other.set
 }
 
 func Compare(one, other *Hand) int {
@@ -436,17 +478,48 @@ func GetMaxHandWithBoard(boad, pocket []string) (*Hand, error) {
 // Функции сравнения рук возвращают
 // -1, если первая комбинация меньше второй, 1, если первая комбинация больше, 0, если они равны.
 
+// sortedValues возвращает значения карт, упорядоченные по убыванию
+func sortedValues(cards []*Card) []CardValue {
+	values := make([]CardValue, len(cards))
+
+	for i, c := range cards {
+		values[i] = c.Value
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] > values[j]
+	})
+
+	return values
+}
+
+// compareKickers поочередно сравнивает карты двух наборов, начиная со старших
+func compareKickers(first, other []*Card) int {
+	values1, values2 := sortedValues(first), sortedValues(other)
+
+	for i := 0; i < len(values1) && i < len(values2); i++ {
+		switch {
+		case values1[i] < values2[i]:
+			return -1
+		case values1[i] > values2[i]:
+			return 1
+		}
+	}
+
+	return 0
+}
+
 // comparePairs сравнивает две комбинации Pair
 func comparePairs(first, other *Hand) int {
-	h1, _, hc1 := first.GetPair()
-	h2, _, hc2 := other.GetPair()
+	h1, r1, hc1 := first.GetPair()
+	h2, r2, hc2 := other.GetPair()
 
 	c := h1[0].CompareValues(h2[0])
 
 	if c != 0 {
 		return c
 	} else {
-		return hc1.CompareValues(hc2)
+		return compareKickers(append(r1, hc1), append(r2, hc2))
 	}
 }
 
@@ -503,15 +576,15 @@ func compareTwoPairs(first, other *Hand) int {
 
 // compareThrees сравнивает две комбинации Three
 func compareThrees(first, other *Hand) int {
-	hand1, _, high1 := first.GetThree()
-	hand2, _, high2 := other.GetThree()
+	hand1, r1, high1 := first.GetThree()
+	hand2, r2, high2 := other.GetThree()
 
 	if hand1[0].CompareValues(hand2[0]) < 0 {
 		return -1
 	} else if hand1[0].CompareValues(hand2[0]) > 0 {
 		return 1
 	} else {
-		return high1.CompareValues(high2)
+		return compareKickers(append(r1, high1), append(r2, high2))
 	}
 }
 
@@ -520,48 +593,58 @@ func compareFours(first, other *Hand) int {
 	hand1 := first.GetFour()
 	hand2 := other.GetFour()
 
-	return hand1[0].CompareValues(hand2[0])
+	if c := hand1[0].CompareValues(hand2[0]); c != 0 {
+		return c
+	}
+
+	return compareKickers(deleteCard(first.Slice(), hand1...), deleteCard(other.Slice(), hand2...))
 }
 
 // compareFullHouses сравнивает две комбинации FullHouse
 func compareFullHouses(first, other *Hand) int {
-	three1, _ := first.GetFullHouse()
-	three2, _ := other.GetFullHouse()
+	three1, two1 := first.GetFullHouse()
+	three2, two2 := other.GetFullHouse()
+
+	if c := three1[0].CompareValues(three2[0]); c != 0 {
+		return c
+	}
 
-	return three1[0].CompareValues(three2[0])
+	return two1[0].CompareValues(two2[0])
 }
 
-// compareStraightsOrFlushs сравнивает две комбинации Straight или Flush
-func compareStraightsOrFlushs(first, other *Hand) int {
-	return first.Max().CompareValues(other.Max())
+// compareStraights сравнивает две комбинации Straight или StraightFlush по старшей карте стрита
+func compareStraights(first, other *Hand) int {
+	high1, high2 := first.StraightHigh(), other.StraightHigh()
+
+	switch {
+	case high1 < high2:
+		return -1
+	case high1 > high2:
+		return 1
+	}
+
+	return 0
 }
 
 // compareSimilarHands сравнивает руки одинаковой величины
 func compareSimilarHands(first, other *Hand) int {
 	firstHand := first.Define()
 
-	firstMax := first.Max()
-	otherMax := other.Max()
-
 	switch {
+	case firstHand == HighCardHand || firstHand == FlushHand:
+		return compareKickers(first.Slice(), other.Slice())
 	case firstHand == PairHand:
 		return comparePairs(first, other)
 	case firstHand == TwoPairHand:
 		return compareTwoPairs(first, other)
 	case firstHand == ThreeHand:
 		return compareThrees(first, other)
-	case firstHand == StraightHand || firstHand == FlushHand:
-		return compareStraightsOrFlushs(first, other)
+	case firstHand == StraightHand || firstHand == StraightFlushHand:
+		return compareStraights(first, other)
 	case firstHand == FullHouseHand:
 		return compareFullHouses(first, other)
 	case firstHand == FourHand:
 		return compareFours(first, other)
-	case firstHand == StraightFlushHand:
-		if firstMax.Value < otherMax.Value {
-			return -1
-		} else if firstMax.Value > otherMax.Value {
-			return 1
-		}
 	}
 
 	return 0
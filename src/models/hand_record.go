@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"hands/src/helpers"
+)
+
+// HandRecord - запись о завершенной раздаче
+type HandRecord struct {
+	ID         string
+	TableID    string
+	FinishedAt time.Time
+	BigBlind   Chips
+	Board      []string
+	// Pockets - карманные карты игроков по их ID
+	Pockets map[string][]string
+	// Stacks - стеки игроков перед началом раздачи
+	Stacks map[string]Chips
+	Pot    Chips
+	// Payouts - выигрыши игроков
+	Payouts map[string]Chips
+	// Positions - позиции игроков в раздаче
+	Positions map[string]Position
+	// Actions - действия игроков в порядке их совершения
+	Actions []Action
+	// Showdown - ID игроков, дошедших до вскрытия
+	Showdown []string
+	// ShowdownHands - короткие коды рук игроков, дошедших до вскрытия (HandRank.Code), по их ID.
+	// Руки оцениваются по правилам варианта игры (Variant.ShowdownHand)
+	ShowdownHands map[string]string
+}
+
+// NewHandRecord возвращает запись о завершенной раздаче с выигрышами payouts.
+// Вызывается до Award, пока банк еще не обнулен
+func (t *Table) NewHandRecord(payouts map[string]Chips) *HandRecord {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	r := &HandRecord{
+		ID:            helpers.GenerateDefaultRandomID(),
+		TableID:       t.ID,
+		FinishedAt:    time.Now(),
+		BigBlind:      t.BigBlind,
+		Board:         NewStringSliceFromCards(t.Board),
+		Pockets:       make(map[string][]string),
+		Stacks:        copyChipsMap(t.startingStacks),
+		Pot:           t.Pot.TotalChipsNum,
+		Payouts:       copyChipsMap(payouts),
+		Positions:     make(map[string]Position),
+		Actions:       append(make([]Action, 0, len(t.actions)), t.actions...),
+		Showdown:      make([]string, 0),
+		ShowdownHands: make(map[string]string),
+	}
+
+	for id, position := range t.handPositions {
+		r.Positions[id] = position
+	}
+
+	for _, p := range t.Players {
+		if cards := p.GetPocketCards(); len(cards) > 0 {
+			r.Pockets[p.ID] = NewStringSliceFromCards(cards)
+		}
+
+		if p.Active && t.inHand() > 1 {
+			r.Showdown = append(r.Showdown, p.ID)
+
+			pocket := p.GetPocketCards()
+			if len(pocket)+len(t.Board) < HandSize {
+				continue
+			}
+
+			if rank := t.variant().ShowdownHand(pocket, t.Board); rank != 0 {
+				r.ShowdownHands[p.ID] = rank.Code()
+			}
+		}
+	}
+
+	return r
+}
+
+// PlayerIDs возвращает ID игроков, участвовавших в раздаче
+func (r *HandRecord) PlayerIDs() []string {
+	ids := make([]string, 0, len(r.Stacks))
+
+	for id := range r.Stacks {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// DescribeShowdown описывает словами на языке lang руку игрока playerID, показанную на вскрытии
+func (r *HandRecord) DescribeShowdown(playerID string, lang Language) (string, error) {
+	code, ok := r.ShowdownHands[playerID]
+	if !ok {
+		return "", fmt.Errorf("Player with ID %s has no showdown hand in hand %s", playerID, r.ID)
+	}
+
+	rank, err := ParseHandCode(code)
+	if err != nil {
+		return "", err
+	}
+
+	return rank.Describe(lang), nil
+}
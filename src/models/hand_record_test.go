@@ -0,0 +1,71 @@
+package models
+
+import (
+	"math/rand"
+	"testing"
+
+	"hands/src/helpers"
+)
+
+func TestShowdownHandsUseVariant(t *testing.T) {
+	tests := []struct {
+		variant Variant
+		board   string
+		pocket  string
+		want    string
+	}{
+		{variant: HoldemVariant, board: "Ks Kh 7d 2c 3d", pocket: "7c Ah", want: "2P:K7A"},
+		// в омахе нужны ровно две карманные карты: роял-флэша с одной Jh нет
+		{variant: OmahaVariant, board: "Ah Kh Qh Th 2d", pocket: "Jh 9s 8s 3c", want: "ST:K"},
+		{variant: StudVariant, pocket: "As Ad 7c 7h 2s Kd 9c", want: "2P:A7K"},
+		// в раззе туз младший, а пара королей не входит в лучшую руку
+		{variant: RazzVariant, pocket: "As 2d 3c 4h 6s Kd Kc", want: "HC:6432A"},
+		// в 2-7 туз старший, и A2345 - не стрит
+		{variant: TripleDrawVariant, pocket: "As 2d 3c 4h 5s", want: "HC:A5432"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variant.Name(), func(t *testing.T) {
+			table, players := newTestTable(t, tt.variant, tt.board, []testSeat{
+				{name: "hero", pocket: tt.pocket},
+				{name: "villain", pocket: ""},
+			})
+
+			r := table.NewHandRecord(nil)
+
+			if got := r.ShowdownHands[players["hero"].ID]; got != tt.want {
+				t.Errorf("ShowdownHands = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandRecordStacksSkipSittingOut(t *testing.T) {
+	table := NewTable("test", "t", helpers.NewDefaultIdGenerator(), CasheTableType, 4, 10, 5).
+		WithRandom(rand.New(rand.NewSource(1)))
+
+	players := make([]*Player, 4)
+	for i := range players {
+		players[i] = NewPlayer("p", helpers.NewDefaultIdGenerator(), 100)
+
+		if err := table.Register(players[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	players[3].SitOut()
+
+	if _, err := table.NewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := table.NewHandRecord(nil)
+
+	if len(r.Stacks) != 3 {
+		t.Errorf("Stacks has %d players, want 3", len(r.Stacks))
+	}
+
+	if _, ok := r.Stacks[players[3].ID]; ok {
+		t.Error("Stacks includes a sitting-out player")
+	}
+}
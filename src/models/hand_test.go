@@ -0,0 +1,73 @@
+package models
+
+import "testing"
+
+func TestHandDefine(t *testing.T) {
+	tests := []struct {
+		cards string
+		want  HandValue
+	}{
+		{cards: "Ah Kh Qh Jh Th", want: RoyalFlushHand},
+		{cards: "5d 4d 3d 2d Ad", want: StraightFlushHand},
+		{cards: "9s 9h 9d 9c 2h", want: FourHand},
+		{cards: "Ks Kh Kd 4c 4h", want: FullHouseHand},
+		{cards: "Ac 9c 7c 4c 2c", want: FlushHand},
+		{cards: "Ah 2d 3c 4s 5h", want: StraightHand},
+		{cards: "7s 7h 7d Kc 2h", want: ThreeHand},
+		{cards: "Js Jh 5d 5c 2h", want: TwoPairHand},
+		{cards: "Qs Qh 8d 5c 2h", want: PairHand},
+		{cards: "Ks Jh 8d 5c 2h", want: HighCardHand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cards, func(t *testing.T) {
+			h := NewHandFromCards(MustParse(tt.cards))
+
+			if got := h.Define(); got != tt.want {
+				t.Errorf("Define() = %v, want %v", got, tt.want)
+			}
+
+			if got := NewCardSet(h.Slice()...); got != h.Set() {
+				t.Errorf("Slice() = %v, set %v", h.Slice(), h.Set())
+			}
+		})
+	}
+}
+
+func TestHandGroups(t *testing.T) {
+	h := NewHandFromCards(MustParse("Ks 4h Kh 4c Kd"))
+
+	three, two := h.GetFullHouse()
+	if len(three) != 3 || len(two) != 2 || three[0].Value != King || two[0].Value != Four {
+		t.Errorf("GetFullHouse() = %v, %v", three, two)
+	}
+
+	one, other, high := NewHandFromCards(MustParse("Js 5d Jh 2h 5c")).GetTwoPair()
+	if len(one) != 2 || len(other) != 2 || high.Value != Two {
+		t.Errorf("GetTwoPair() = %v, %v, %v", one, other, high)
+	}
+
+	if got := NewHandFromCards(MustParse("Qs Qh 8d 5c 2h")).CountPairs(); got != 2 {
+		t.Errorf("CountPairs() = %d, want 2", got)
+	}
+}
+
+func TestHandKeepsOrder(t *testing.T) {
+	cards := "7c Ah 2d Ks 9h"
+	h := NewHandFromCards(MustParse(cards))
+
+	text, err := h.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(text) != cards {
+		t.Errorf("MarshalText() = %s, want %s", text, cards)
+	}
+
+	for i, c := range h.Slice() {
+		if want := MustParse(cards)[i]; c.String() != want.String() {
+			t.Errorf("card %d = %s, want %s", i, c, want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// LowRank - числовая оценка руки в лоуболе: чем больше, тем сильнее (ниже) рука.
+// Содержит HandRank руки, взятый с обратным знаком, поэтому Value и значения карт извлекаются так же
+type LowRank int32
+
+// lowAce - значение туза в A-5 лоуболе, где туз - младшая карта
+const lowAce CardValue = 1
+
+// Value возвращает величину руки: в A-5 лоуболе - только пары, тройки и каре
+func (r LowRank) Value() HandValue {
+	return HandRank(-r).Value()
+}
+
+// Compare сравнивает руки так же, как Hand.Compare: положительное значение, если r сильнее other,
+// отрицательное, если слабее, и ноль при равенстве
+func (r LowRank) Compare(other LowRank) int {
+	return int(r) - int(other)
+}
+
+// String возвращает величину руки и значения карт от старшей к младшей, например "7-5-4-3-A"
+// или "Pair 2-7-5-4"
+func (r LowRank) String() string {
+	values := HandRank(-r).kickers()
+	names := make([]string, len(values))
+
+	for i, v := range values {
+		if v == lowAce {
+			v = Ace
+		}
+
+		names[i] = string(startingHandValues[v-Two])
+	}
+
+	if r.Value() == HighCardHand {
+		return strings.Join(names, "-")
+	}
+
+	return r.Value().String() + " " + strings.Join(names, "-")
+}
+
+// HandRank возвращает оценку той же руки как HandRank, пригодную для Describe и Code.
+// Младший туз A-5 лоубола записывается как обычный туз
+func (r LowRank) HandRank() HandRank {
+	values := HandRank(-r).kickers()
+
+	for i, v := range values {
+		if v == lowAce {
+			values[i] = Ace
+		}
+	}
+
+	return newHandRank(r.Value(), values...)
+}
+
+// RazzRank оценивает лучшую руку из пяти карт среди cards в A-5 лоуболе (разз): туз младший,
+// стриты и флэши не учитываются. Если карт меньше пяти, оцениваются все карты
+func RazzRank(cards []*Card) LowRank {
+	return bestLow(cards, razzHighRank)
+}
+
+// DeuceToSevenRank оценивает лучшую руку из пяти карт среди cards в 2-7 лоуболе: туз старший,
+// стриты и флэши считаются против игрока, A2345 - не стрит. Лучшая рука - 7-5-4-3-2 разномастные
+func DeuceToSevenRank(cards []*Card) LowRank {
+	return bestLow(cards, func(cards []*Card) HandRank {
+		return rankCards(cards, false)
+	})
+}
+
+// CompareRazz сравнивает руки в A-5 лоуболе, аналогично Hand.Compare
+func CompareRazz(one, other []*Card) int {
+	return RazzRank(one).Compare(RazzRank(other))
+}
+
+// CompareDeuceToSeven сравнивает руки в 2-7 лоуболе, аналогично Hand.Compare
+func CompareDeuceToSeven(one, other []*Card) int {
+	return DeuceToSevenRank(one).Compare(DeuceToSevenRank(other))
+}
+
+// BestRazzHand возвращает лучшие пять карт среди cards в A-5 лоуболе
+func BestRazzHand(cards []*Card) []*Card {
+	return bestLowCards(cards, razzHighRank)
+}
+
+// bestLow возвращает оценку самой низкой руки из пяти карт среди cards
+func bestLow(cards []*Card, rank func([]*Card) HandRank) LowRank {
+	return LowRank(-rank(bestLowCards(cards, rank)))
+}
+
+// bestLowCards перебирает руки из пяти карт среди cards и возвращает руку с наименьшей оценкой rank
+func bestLowCards(cards []*Card, rank func([]*Card) HandRank) []*Card {
+	if len(cards) <= HandSize {
+		return append(make([]*Card, 0, HandSize), cards...)
+	}
+
+	var (
+		best     []*Card
+		bestRank HandRank
+		hand     = make([]*Card, HandSize)
+		indexes  = make([]int, HandSize)
+	)
+
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	for {
+		for i, idx := range indexes {
+			hand[i] = cards[idx]
+		}
+
+		if r := rank(hand); best == nil || r < bestRank {
+			best, bestRank = append(best[:0], hand...), r
+		}
+
+		// следующее сочетание индексов в лексикографическом порядке
+		i := HandSize - 1
+		for i >= 0 && indexes[i] == len(cards)-HandSize+i {
+			i--
+		}
+
+		if i < 0 {
+			return best
+		}
+
+		indexes[i]++
+
+		for j := i + 1; j < HandSize; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
+
+// razzHighRank оценивает руку как в обычном покере, но с младшим тузом и без стритов и флэшей.
+// Чем меньше оценка, тем ниже рука
+func razzHighRank(cards []*Card) HandRank {
+	var counts [King + 1]int
+
+	for _, c := range cards {
+		if c.Value == Ace {
+			counts[lowAce]++
+		} else {
+			counts[c.Value]++
+		}
+	}
+
+	values := make([]CardValue, 0, HandSize)
+
+	for v := King; v >= lowAce; v-- {
+		if counts[v] > 0 {
+			values = append(values, v)
+		}
+	}
+
+	// сначала большие группы одинаковых карт, затем старшие значения
+	sort.SliceStable(values, func(i, j int) bool {
+		return counts[values[i]] > counts[values[j]]
+	})
+
+	value := HighCardHand
+
+	switch first, second := groupSize(counts[:], values, 0), groupSize(counts[:], values, 1); {
+	case first == 4:
+		value = FourHand
+	case first == 3 && second >= 2:
+		value = FullHouseHand
+	case first == 3:
+		value = ThreeHand
+	case first == 2 && second == 2:
+		value = TwoPairHand
+	case first == 2:
+		value = PairHand
+	}
+
+	return newHandRank(value, values...)
+}
+
+// groupSize возвращает количество карт значения values[i], или 0, если такого значения нет
+func groupSize(counts []int, values []CardValue, i int) int {
+	if i >= len(values) {
+		return 0
+	}
+
+	return counts[values[i]]
+}
@@ -0,0 +1,122 @@
+package models
+
+import "testing"
+
+func TestRazzRankOrder(t *testing.T) {
+	// руки по убыванию силы: стриты и флэши не учитываются, туз - младшая карта
+	hands := []string{
+		"Ah 2h 3h 4h 5h",
+		"As 2d 3c 4h 6s",
+		"2s 3d 4c 5h 6s",
+		"As 2d 3c 4h 7s",
+		"Ks Qd Jc Th 9s",
+		"As Ad 2c 3h 4s",
+		"2s 2d 3c 4h 5s",
+		"As Ad 2c 2h 3s",
+		"Ks Kd Kc Qh Qs",
+	}
+
+	assertDescending(t, hands, func(cards []*Card) int { return int(RazzRank(cards)) })
+}
+
+func TestDeuceToSevenRankOrder(t *testing.T) {
+	// руки по убыванию силы: туз старший, стриты и флэши против игрока
+	hands := []string{
+		"7s 5d 4c 3h 2s",
+		"7s 6d 4c 3h 2s",
+		"8s 5d 4c 3h 2s",
+		"8s 6d 5c 4h 2s",
+		"Ks Qd Jc Th 8s",
+		"As 5d 4c 3h 2s",
+		"2s 2d 5c 4h 3s",
+		"As Ad Kc Qh Js",
+		"7s 6d 5c 4h 3s",
+		"7h 5h 4h 3h 2h",
+	}
+
+	assertDescending(t, hands, func(cards []*Card) int { return int(DeuceToSevenRank(cards)) })
+}
+
+func TestLowRankString(t *testing.T) {
+	tests := []struct {
+		name  string
+		rank  LowRank
+		want  string
+		value HandValue
+	}{
+		{name: "wheel", rank: RazzRank(MustParse("5h 4d 3c 2s Ah")), want: "5-4-3-2-A", value: HighCardHand},
+		{
+			name:  "pair of aces from seven cards",
+			rank:  RazzRank(MustParse("As Ad 2c 2d 3h 3s 4c")),
+			want:  "Pair A-4-3-2",
+			value: PairHand,
+		},
+		{name: "deuce to seven", rank: DeuceToSevenRank(MustParse("7s 5d 4c 3h 2s")), want: "7-5-4-3-2", value: HighCardHand},
+		{name: "straight", rank: DeuceToSevenRank(MustParse("7s 6d 5c 4h 3s")), want: "Straight 7", value: StraightHand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rank.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+
+			if got := tt.rank.Value(); got != tt.value {
+				t.Errorf("Value() = %v, want %v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestBestRazzHand(t *testing.T) {
+	tests := []struct {
+		cards string
+		want  string
+	}{
+		{cards: "Ah 2d 3c 4s 5h Kd Kc", want: "5-4-3-2-A"},
+		{cards: "Kh Qd 8c 8s 7h 6d 6c", want: "K-Q-8-7-6"},
+		{cards: "9h 9d 9c 9s 2h 2d 3c", want: "Two Pair 9-2-3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cards, func(t *testing.T) {
+			cards := MustParse(tt.cards)
+			best := BestRazzHand(cards)
+
+			if len(best) != HandSize {
+				t.Fatalf("BestRazzHand() = %v", best)
+			}
+
+			if got := RazzRank(best); got != RazzRank(cards) || got.String() != tt.want {
+				t.Errorf("BestRazzHand() = %v (%s), want %s", best, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareLowball(t *testing.T) {
+	wheel, seven := MustParse("Ah 2h 3h 4h 5h"), MustParse("7s 5d 4c 3h 2s")
+
+	if CompareRazz(wheel, seven) <= 0 {
+		t.Error("wheel must beat 7-5 in razz")
+	}
+
+	if CompareDeuceToSeven(wheel, seven) >= 0 {
+		t.Error("7-5 must beat a flush in deuce to seven")
+	}
+
+	if CompareRazz(wheel, MustParse("As 2d 3c 4h 5s")) != 0 {
+		t.Error("suits must not matter in razz")
+	}
+}
+
+// assertDescending проверяет, что каждая рука hands оценивается rank выше следующей
+func assertDescending(t *testing.T, hands []string, rank func([]*Card) int) {
+	t.Helper()
+
+	for i := 1; i < len(hands); i++ {
+		if rank(MustParse(hands[i-1])) <= rank(MustParse(hands[i])) {
+			t.Errorf("%s is not better than %s", hands[i-1], hands[i])
+		}
+	}
+}
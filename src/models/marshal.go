@@ -0,0 +1,258 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Текстовые и JSON-представления моделей. Карты записываются как "Ah", "Td": значение (T - десятка)
+// и масть строчной буквой; при чтении принимаются все записи, которые понимает ParseCard.
+// Схема представлений описана в docs/encoding.md
+
+// MarshalText возвращает запись карты, например "Ah" или "Td"
+func (same *Card) MarshalText() ([]byte, error) {
+	i := same.Index()
+	if i == NoCard {
+		return nil, fmt.Errorf("Can't encode card %s", same)
+	}
+
+	return []byte{startingHandValues[same.Value-Two], strings.ToLower(string(same.Suite.Suite))[0]}, nil
+}
+
+// UnmarshalText читает карту в любой записи, которую понимает ParseCard. Карта получает собственную масть,
+// не разделяемую с другими картами
+func (same *Card) UnmarshalText(text []byte) error {
+	c, err := ParseCard(string(text))
+	if err != nil {
+		return err
+	}
+
+	*same = Card{
+		Value: c.Value,
+		Suite: &CardSuite{
+			Color: c.Suite.Color,
+			Suite: c.Suite.Suite,
+		},
+	}
+
+	return nil
+}
+
+// MarshalText возвращает запись карты с номером i
+func (i CardIndex) MarshalText() ([]byte, error) {
+	c := i.shared()
+	if c == nil {
+		return nil, fmt.Errorf("Can't encode card index %d", i)
+	}
+
+	return c.MarshalText()
+}
+
+// UnmarshalText читает номер карты по ее записи
+func (i *CardIndex) UnmarshalText(text []byte) error {
+	c, err := ParseCard(string(text))
+	if err != nil {
+		return err
+	}
+
+	*i = c.Index()
+
+	return nil
+}
+
+// MarshalText возвращает карты множества через пробел в порядке номеров, например "Ah Kd"
+func (s CardSet) MarshalText() ([]byte, error) {
+	names := make([]string, 0, s.Len())
+
+	for rest := s; rest != 0; {
+		var i CardIndex
+
+		i, rest = rest.Pop()
+
+		text, err := i.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, string(text))
+	}
+
+	return []byte(strings.Join(names, " ")), nil
+}
+
+// UnmarshalText читает множество карт в записи, которую понимает ParseCards
+func (s *CardSet) UnmarshalText(text []byte) error {
+	cards, err := ParseCards(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = NewCardSet(cards...)
+
+	return nil
+}
+
+// MarshalText возвращает масть строчной буквой: "h", "d", "s" или "c"
+func (s Suite) MarshalText() ([]byte, error) {
+	if suitIndex(s) < 0 {
+		return nil, fmt.Errorf("Can't encode suit %q", string(s))
+	}
+
+	return []byte(strings.ToLower(string(s))), nil
+}
+
+// UnmarshalText читает масть в любом регистре или символом юникода
+func (s *Suite) UnmarshalText(text []byte) error {
+	r := []rune(string(text))
+
+	suite, ok := Suite(""), len(r) == 1
+	if ok {
+		suite, ok = suitRunes[r[0]]
+	}
+
+	if !ok {
+		return fmt.Errorf("Can't decode suit %q", string(text))
+	}
+
+	*s = suite
+
+	return nil
+}
+
+// MarshalText возвращает короткий код комбинации, например "2P"
+func (v HandValue) MarshalText() ([]byte, error) {
+	if v < HighCardHand || int(v) >= len(handValueCodes) {
+		return nil, fmt.Errorf("Can't encode hand value %d", int(v))
+	}
+
+	return []byte(v.Code()), nil
+}
+
+// UnmarshalText читает комбинацию по короткому коду
+func (v *HandValue) UnmarshalText(text []byte) error {
+	for i, code := range handValueCodes {
+		if code == string(text) {
+			*v = HandValue(i)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Can't decode hand value %q", string(text))
+}
+
+// MarshalText возвращает короткий код руки, например "2P:K7A"
+func (r HandRank) MarshalText() ([]byte, error) {
+	return []byte(r.Code()), nil
+}
+
+// UnmarshalText читает руку по короткому коду, см. ParseHandCode
+func (r *HandRank) UnmarshalText(text []byte) error {
+	rank, err := ParseHandCode(string(text))
+	if err != nil {
+		return err
+	}
+
+	*r = rank
+
+	return nil
+}
+
+// MarshalText возвращает карты руки через пробел в исходном порядке, например "Ah Kd Qs Jc Th"
+func (h *Hand) MarshalText() ([]byte, error) {
+	names := make([]string, 0, len(h.order))
+
+	for _, i := range h.order {
+		text, err := i.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, string(text))
+	}
+
+	return []byte(strings.Join(names, " ")), nil
+}
+
+// UnmarshalText читает руку из пяти разных карт
+func (h *Hand) UnmarshalText(text []byte) error {
+	cards, err := ParseCards(string(text))
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateHand(cards); err != nil {
+		return err
+	}
+
+	*h = *NewHandFromCards(cards)
+
+	return nil
+}
+
+// MarshalText возвращает количество фишек десятичным числом
+func (c Chips) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(int(c))), nil
+}
+
+// UnmarshalText читает количество фишек из десятичного числа
+func (c *Chips) UnmarshalText(text []byte) error {
+	n, err := strconv.Atoi(string(text))
+	if err != nil {
+		return fmt.Errorf("Can't decode chips %q", string(text))
+	}
+
+	*c = Chips(n)
+
+	return nil
+}
+
+// MarshalJSON записывает фишки числом, а не строкой, как сделал бы MarshalText
+func (c Chips) MarshalJSON() ([]byte, error) {
+	return c.MarshalText()
+}
+
+// UnmarshalJSON читает фишки из числа или из строки с числом
+func (c *Chips) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	return c.UnmarshalText([]byte(strings.Trim(string(data), `"`)))
+}
+
+// MarshalJSON записывает игрока его снимком, включая стек и карманные карты
+func (p *Player) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Snapshot())
+}
+
+// UnmarshalJSON восстанавливает игрока из снимка
+func (p *Player) UnmarshalJSON(data []byte) error {
+	var s PlayerSnapshot
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	p.restore(s)
+
+	return nil
+}
+
+// MarshalJSON записывает стол его снимком, включая колоду и состояние раздачи
+func (t *Table) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Snapshot())
+}
+
+// UnmarshalJSON восстанавливает стол из снимка. Подписчики на события стола не восстанавливаются
+func (t *Table) UnmarshalJSON(data []byte) error {
+	var s TableSnapshot
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return t.restore(&s)
+}
@@ -0,0 +1,293 @@
+package models
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// textValue - значение, записываемое текстом: new создает пустое значение того же типа для чтения
+type textValue struct {
+	name  string
+	value encoding.TextMarshaler
+	text  string
+	new   func() encoding.TextUnmarshaler
+}
+
+func textValues() []textValue {
+	return []textValue{
+		{name: "card", value: MustParse("Ah")[0], text: "Ah", new: func() encoding.TextUnmarshaler { return &Card{} }},
+		{name: "ten", value: MustParse("10d")[0], text: "Td", new: func() encoding.TextUnmarshaler { return &Card{} }},
+		{name: "card index", value: CardIndex(12), text: "Ah", new: func() encoding.TextUnmarshaler { return new(CardIndex) }},
+		{
+			name:  "card set",
+			value: NewCardSet(MustParse("2c Kd Ah")...),
+			text:  "Ah Kd 2c",
+			new:   func() encoding.TextUnmarshaler { return new(CardSet) },
+		},
+		{name: "empty card set", value: CardSet(0), text: "", new: func() encoding.TextUnmarshaler { return new(CardSet) }},
+		{name: "suit", value: Spades, text: "s", new: func() encoding.TextUnmarshaler { return new(Suite) }},
+		{name: "hand value", value: FullHouseHand, text: "FH", new: func() encoding.TextUnmarshaler { return new(HandValue) }},
+		{
+			name:  "hand rank",
+			value: RankCards(MustParse("Kh Kd 7c 7s Ah")),
+			text:  "2P:K7A",
+			new:   func() encoding.TextUnmarshaler { return new(HandRank) },
+		},
+		{
+			name:  "hand",
+			value: NewHandFromCards(MustParse("Ah Kd Qs Jc Th")),
+			text:  "Ah Kd Qs Jc Th",
+			new:   func() encoding.TextUnmarshaler { return &Hand{} },
+		},
+		{name: "chips", value: Chips(42), text: "42", new: func() encoding.TextUnmarshaler { return new(Chips) }},
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	for _, tt := range textValues() {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := tt.value.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(text) != tt.text {
+				t.Errorf("MarshalText() = %q, want %q", text, tt.text)
+			}
+
+			decoded := tt.new()
+			if err := decoded.UnmarshalText(text); err != nil {
+				t.Fatal(err)
+			}
+
+			again, err := decoded.(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(again) != tt.text {
+				t.Errorf("decoded value encodes as %q, want %q", again, tt.text)
+			}
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, tt := range textValues() {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded := tt.new()
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			again, err := json.Marshal(decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(data, again) {
+				t.Errorf("round trip %s gives %s", data, again)
+			}
+		})
+	}
+}
+
+func TestDecodeLegacyCards(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		new   func() encoding.TextUnmarshaler
+		want  string
+	}{
+		{name: "card", input: "AH", new: func() encoding.TextUnmarshaler { return &Card{} }, want: "Ah"},
+		{name: "ten", input: "10D", new: func() encoding.TextUnmarshaler { return &Card{} }, want: "Td"},
+		{name: "card index", input: "10D", new: func() encoding.TextUnmarshaler { return new(CardIndex) }, want: "Td"},
+		{name: "card set", input: "AH 10D", new: func() encoding.TextUnmarshaler { return new(CardSet) }, want: "Ah Td"},
+		{name: "suit", input: "H", new: func() encoding.TextUnmarshaler { return new(Suite) }, want: "h"},
+		{name: "unicode suit", input: "♠", new: func() encoding.TextUnmarshaler { return new(Suite) }, want: "s"},
+		{
+			name:  "hand",
+			input: "AH KD QS JC 10H",
+			new:   func() encoding.TextUnmarshaler { return &Hand{} },
+			want:  "Ah Kd Qs Jc Th",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded := tt.new()
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			text, err := decoded.(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(text) != tt.want {
+				t.Errorf("%s decodes as %q, want %q", tt.input, text, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		new   func() interface{}
+	}{
+		{name: "card", input: `"Zz"`, new: func() interface{} { return &Card{} }},
+		{name: "card set duplicate", input: `"Ah ah"`, new: func() interface{} { return new(CardSet) }},
+		{name: "suit", input: `"x"`, new: func() interface{} { return new(Suite) }},
+		{name: "hand value", input: `"XX"`, new: func() interface{} { return new(HandValue) }},
+		{name: "short hand", input: `"Ah Kd Qs"`, new: func() interface{} { return &Hand{} }},
+		{name: "chips", input: `"many"`, new: func() interface{} { return new(Chips) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.input), tt.new()); err == nil {
+				t.Errorf("%s decoded without error", tt.input)
+			}
+		})
+	}
+}
+
+func TestDecodeChips(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Chips
+	}{
+		{input: `42`, want: 42},
+		{input: `"42"`, want: 42},
+		{input: `"-5"`, want: -5},
+		{input: `0`, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var v struct{ Chips Chips }
+
+			if err := json.Unmarshal([]byte(`{"Chips": `+tt.input+`}`), &v); err != nil {
+				t.Fatal(err)
+			}
+
+			if v.Chips != tt.want {
+				t.Errorf("Chips = %d, want %d", v.Chips, tt.want)
+			}
+		})
+	}
+
+	data, err := json.Marshal(struct{ Chips Chips }{Chips: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"Chips":42}` {
+		t.Errorf("chips encode as %s, want a number", data)
+	}
+}
+
+func TestPotJSONRoundTrip(t *testing.T) {
+	pot := NewPot("main")
+
+	for id, chips := range map[string]Chips{"a": 50, "b": 200} {
+		if err := pot.Register(id); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := pot.AddPlayerBet(chips, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := json.Marshal(pot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Pot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(&decoded, pot) {
+		t.Errorf("pot %+v decoded as %+v", pot, decoded)
+	}
+}
+
+func TestPlayerJSONRoundTrip(t *testing.T) {
+	table := newPositionsTable(t, 3)
+	player := table.Players[0]
+	player.SitOut()
+
+	data, err := json.Marshal(player)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Player
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := decoded.Snapshot(), player.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("player %+v decoded as %+v", want, got)
+	}
+}
+
+func TestTableJSONRoundTrip(t *testing.T) {
+	table := newPositionsTable(t, 4)
+
+	// раздача продолжается после первых действий на префлопе
+	for _, action := range []ActionType{CallAction, RaiseAction} {
+		if _, err := table.Act(table.NextToAct().ID, action, table.BigBlind); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := json.Marshal(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &Table{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, again) {
+		t.Fatalf("round trip changed the table:\n%s\n%s", data, again)
+	}
+
+	// восстановленный стол играет раздачу так же, как исходный
+	for _, tbl := range []*Table{table, decoded} {
+		if _, err := tbl.Act(tbl.NextToAct().ID, CallAction, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !reflect.DeepEqual(decoded.Snapshot(), table.Snapshot()) {
+		t.Error("restored table diverged after the next action")
+	}
+}
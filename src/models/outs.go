@@ -0,0 +1,328 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DrawType - тип дро
+type DrawType string
+
+const (
+	FlushDraw             DrawType = "flush draw"
+	OpenEndedStraightDraw DrawType = "open-ended straight draw"
+	GutshotDraw           DrawType = "gutshot"
+	BackdoorFlushDraw     DrawType = "backdoor flush draw"
+	BackdoorStraightDraw  DrawType = "backdoor straight draw"
+	// ComboDraw - одновременно флэш-дро и стрит-дро
+	ComboDraw DrawType = "combo draw"
+)
+
+// flushDrawSize - количество карт одной масти во флэш-дро
+const flushDrawSize = HandSize - 1
+
+// Out - карта, улучшающая руку игрока
+type Out struct {
+	Card *Card
+	// Value - величина руки после выхода карты
+	Value HandValue
+	// Tainted - карта улучшает руку, но открывает соперникам более сильную комбинацию, чем рука игрока
+	Tainted bool
+}
+
+// OutsAnalysis - ауты и дро руки на флопе или терне
+type OutsAnalysis struct {
+	Street Street
+	// Current - величина руки сейчас
+	Current HandValue
+	Outs    []*Out
+	// ByValue - ауты по величине руки, до которой они улучшают
+	ByValue map[HandValue][]*Card
+	Draws   []DrawType
+	// Unseen - количество неизвестных игроку карт
+	Unseen int
+	// CleanOuts - количество аутов, не открывающих соперникам более сильных комбинаций
+	CleanOuts int
+	// RuleOfTwoAndFour - приблизительная вероятность улучшения к риверу по правилу 2 и 4, в процентах
+	RuleOfTwoAndFour float64
+	// NextCard - точная вероятность улучшения следующей картой
+	NextCard float64
+	// ByRiver - точная вероятность улучшения к риверу
+	ByRiver float64
+}
+
+// improves проверяет, улучшает ли набор общих карт board руку pocket по сравнению с величиной current.
+// Улучшение засчитывается, только если в комбинации играют карманные карты, т.е. она сильнее комбинации
+// одних общих карт
+func improves(pocket, board []*Card, current HandValue) (HandValue, bool) {
+	cards := append(append(make([]*Card, 0, BoardSize+PocketSize), board...), pocket...)
+	value := RankCards(cards).Value()
+
+	return value, value > current && value > RankCards(board).Value()
+}
+
+// bestOpponentValue возвращает максимальную величину руки, которую может собрать соперник при общих картах board,
+// не имея карт dead
+func bestOpponentValue(board []*Card, dead []*Card) HandValue {
+	best := HighCardHand
+	cards := make([]*Card, 0, BoardSize+PocketSize)
+
+	for _, pocket := range UniformRange(board, dead) {
+		if v := RankCards(append(append(cards[:0], board...), pocket...)).Value(); v > best {
+			best = v
+		}
+	}
+
+	return best
+}
+
+// AnalyzeOuts находит ауты руки pocket при общих картах board (флоп или терн), классифицирует дро
+// и рассчитывает вероятность улучшения. Аут считается "грязным" (Tainted), если после его выхода
+// соперник может собрать комбинацию сильнее, чем игрок, и до выхода карты такой комбинации не было
+func AnalyzeOuts(pocket, board []*Card) (*OutsAnalysis, error) {
+	if len(pocket) != PocketSize {
+		return nil, fmt.Errorf("Outs analysis requires %d pocket cards, got %d", PocketSize, len(pocket))
+	}
+
+	if len(board) != CardsOnFlopNumber && len(board) != BoardSize-1 {
+		return nil, fmt.Errorf("Outs analysis requires flop or turn board, got %d cards", len(board))
+	}
+
+	current := RankCards(append(append(make([]*Card, 0, BoardSize+PocketSize), board...), pocket...)).Value()
+	unseen := deadCardsDeck(pocket, board)
+	threat := bestOpponentValue(board, pocket)
+
+	a := &OutsAnalysis{
+		Street:  FlopStreet,
+		Current: current,
+		Outs:    make([]*Out, 0),
+		ByValue: make(map[HandValue][]*Card),
+		Draws:   classifyDraws(pocket, board),
+		Unseen:  len(unseen),
+	}
+
+	if len(board) == BoardSize-1 {
+		a.Street = TurnStreet
+	}
+
+	next := append(make([]*Card, 0, BoardSize), board...)
+
+	for _, c := range unseen {
+		next = append(next[:len(board)], c)
+
+		value, ok := improves(pocket, next, current)
+		if !ok {
+			continue
+		}
+
+		opponent := bestOpponentValue(next, pocket)
+		out := &Out{
+			Card:    c,
+			Value:   value,
+			Tainted: opponent > value && opponent > threat,
+		}
+
+		a.Outs = append(a.Outs, out)
+		a.ByValue[value] = append(a.ByValue[value], c)
+
+		if !out.Tainted {
+			a.CleanOuts++
+		}
+	}
+
+	a.NextCard = float64(len(a.Outs)) / float64(a.Unseen)
+	a.ByRiver = a.NextCard
+	a.RuleOfTwoAndFour = float64(2 * len(a.Outs))
+
+	if a.Street == FlopStreet {
+		a.RuleOfTwoAndFour = float64(4 * len(a.Outs))
+		a.ByRiver = improveByRiver(pocket, board, unseen, current)
+	}
+
+	if a.RuleOfTwoAndFour > 100 {
+		a.RuleOfTwoAndFour = 100
+	}
+
+	return a, nil
+}
+
+// improveByRiver возвращает точную вероятность улучшения руки на флопе к риверу
+func improveByRiver(pocket, board, unseen []*Card, current HandValue) float64 {
+	improved, total := 0, 0
+	runout := append(make([]*Card, 0, BoardSize), board...)
+
+	for i := range unseen {
+		for j := i + 1; j < len(unseen); j++ {
+			runout = append(runout[:len(board)], unseen[i], unseen[j])
+
+			if _, ok := improves(pocket, runout, current); ok {
+				improved++
+			}
+
+			total++
+		}
+	}
+
+	return float64(improved) / float64(total)
+}
+
+// classifyDraws определяет дро руки pocket при общих картах board
+func classifyDraws(pocket, board []*Card) []DrawType {
+	draws := make([]DrawType, 0)
+	cards := append(append(make([]*Card, 0, BoardSize+PocketSize), board...), pocket...)
+	current := RankCards(cards).Value()
+
+	flush, backdoorFlush := false, false
+
+	if current < FlushHand {
+		for _, suite := range Suites {
+			total, own := suiteCount(cards, suite), suiteCount(pocket, suite)
+
+			switch {
+			case own > 0 && total == flushDrawSize:
+				flush = true
+			case own > 0 && total == flushDrawSize-1 && len(board) == CardsOnFlopNumber:
+				backdoorFlush = true
+			}
+		}
+	}
+
+	straight := 0
+	backdoorStraight := false
+
+	if current < StraightHand {
+		mask, boardMask := valuesMask(cards), valuesMask(board)
+
+		for v := Two; v <= Ace; v++ {
+			if straightHighFromMask(mask|1<<v) != 0 && straightHighFromMask(mask|1<<v) != straightHighFromMask(boardMask|1<<v) {
+				straight++
+			}
+		}
+
+		if straight == 0 && len(board) == CardsOnFlopNumber {
+			for v := Two; v <= Ace && !backdoorStraight; v++ {
+				for w := v + 1; w <= Ace; w++ {
+					if straightHighFromMask(mask|1<<v|1<<w) != 0 && straightHighFromMask(boardMask|1<<v|1<<w) == 0 {
+						backdoorStraight = true
+
+						break
+					}
+				}
+			}
+		}
+	}
+
+	if flush {
+		draws = append(draws, FlushDraw)
+	}
+
+	switch {
+	case straight >= 2:
+		draws = append(draws, OpenEndedStraightDraw)
+	case straight == 1:
+		draws = append(draws, GutshotDraw)
+	}
+
+	if flush && straight > 0 {
+		draws = append(draws, ComboDraw)
+	}
+
+	if backdoorFlush && !flush {
+		draws = append(draws, BackdoorFlushDraw)
+	}
+
+	if backdoorStraight {
+		draws = append(draws, BackdoorStraightDraw)
+	}
+
+	return draws
+}
+
+func suiteCount(cards []*Card, suite Suite) int {
+	n := 0
+
+	for _, c := range cards {
+		if c.Suite.Suite == suite {
+			n++
+		}
+	}
+
+	return n
+}
+
+// valuesMask возвращает битовую маску значений карт: бит v - значение v
+func valuesMask(cards []*Card) uint16 {
+	var mask uint16
+
+	for _, c := range cards {
+		mask |= 1 << c.Value
+	}
+
+	return mask
+}
+
+// HasDraw проверяет, есть ли у руки дро draw
+func (a *OutsAnalysis) HasDraw(draw DrawType) bool {
+	for _, d := range a.Draws {
+		if d == draw {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String объясняет анализ: дро, ауты и вероятность улучшения
+func (a *OutsAnalysis) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s: %v", a.Street, a.Current)
+
+	if len(a.Draws) > 0 {
+		draws := make([]string, len(a.Draws))
+
+		for i, d := range a.Draws {
+			draws[i] = string(d)
+		}
+
+		fmt.Fprintf(&b, ", %s", strings.Join(draws, ", "))
+	}
+
+	fmt.Fprintf(&b, "; %d outs (%d clean) of %d unseen cards", len(a.Outs), a.CleanOuts, a.Unseen)
+
+	for v := RoyalFlushHand; v >= HighCardHand; v-- {
+		if cards, ok := a.ByValue[v]; ok {
+			fmt.Fprintf(&b, "; %v: %s", v, strings.Join(NewStringSliceFromCards(cards), " "))
+		}
+	}
+
+	fmt.Fprintf(&b, "; next card %.1f%%", 100*a.NextCard)
+
+	if a.Street == FlopStreet {
+		fmt.Fprintf(&b, ", by river %.1f%% (rule of 4: %.0f%%)", 100*a.ByRiver, a.RuleOfTwoAndFour)
+	} else {
+		fmt.Fprintf(&b, " (rule of 2: %.0f%%)", a.RuleOfTwoAndFour)
+	}
+
+	return b.String()
+}
+
+// AnalyzeStreets анализирует ауты на каждой улице: на флопе и, если открыт терн, на терне
+func AnalyzeStreets(pocket, board []*Card) ([]*OutsAnalysis, error) {
+	if len(board) < CardsOnFlopNumber {
+		return nil, fmt.Errorf("Outs analysis requires at least %d board cards, got %d", CardsOnFlopNumber, len(board))
+	}
+
+	analyses := make([]*OutsAnalysis, 0, BoardSize-CardsOnFlopNumber)
+
+	for n := CardsOnFlopNumber; n < BoardSize && n <= len(board); n++ {
+		a, err := AnalyzeOuts(pocket, board[:n])
+		if err != nil {
+			return nil, err
+		}
+
+		analyses = append(analyses, a)
+	}
+
+	return analyses, nil
+}
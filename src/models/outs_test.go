@@ -0,0 +1,194 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeOuts(t *testing.T) {
+	tests := []struct {
+		name   string
+		pocket string
+		board  string
+		street Street
+		outs   map[HandValue]int
+		// clean - ауты, после которых соперник не может собрать комбинацию сильнее
+		clean int
+		draws []DrawType
+	}{
+		{
+			// тузы открывают колесо, двойка и четверка червей спаривают доску
+			name:   "nut flush draw on the turn",
+			pocket: "Ah 9h",
+			board:  "Kh 7h 2c 4s",
+			street: TurnStreet,
+			outs:   map[HandValue]int{FlushHand: 9, PairHand: 6},
+			clean:  10,
+			draws:  []DrawType{FlushDraw},
+		},
+		{
+			// девятки и восьмерки дают пару, но открывают соперникам стрит
+			name:   "open-ended straight draw",
+			pocket: "9c 8d",
+			board:  "7h 6s 2c",
+			street: FlopStreet,
+			outs:   map[HandValue]int{StraightHand: 8, PairHand: 6},
+			clean:  8,
+			draws:  []DrawType{OpenEndedStraightDraw},
+		},
+		{
+			name:   "gutshot",
+			pocket: "9c 8d",
+			board:  "Jh 7s 2c",
+			street: FlopStreet,
+			outs:   map[HandValue]int{StraightHand: 4, PairHand: 6},
+			clean:  4,
+			draws:  []DrawType{GutshotDraw},
+		},
+		{
+			// чистые ауты: 5h и Th, Ah Kh Qh Jh и шесть десяток и пятерок других мастей
+			name:   "combo draw",
+			pocket: "9h 8h",
+			board:  "7h 6h 2c",
+			street: FlopStreet,
+			outs:   map[HandValue]int{StraightFlushHand: 2, FlushHand: 7, StraightHand: 6, PairHand: 6},
+			clean:  12,
+			draws:  []DrawType{FlushDraw, OpenEndedStraightDraw, ComboDraw},
+		},
+		{
+			name:   "backdoor draws",
+			pocket: "Ah Kh",
+			board:  "Qh 7c 2d",
+			street: FlopStreet,
+			outs:   map[HandValue]int{PairHand: 6},
+			clean:  6,
+			draws:  []DrawType{BackdoorFlushDraw, BackdoorStraightDraw},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pocket, board := MustParse(tt.pocket), MustParse(tt.board)
+
+			a, err := AnalyzeOuts(pocket, board)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			outs := make(map[HandValue]int)
+			for v, cards := range a.ByValue {
+				outs[v] = len(cards)
+			}
+
+			if !reflect.DeepEqual(outs, tt.outs) {
+				t.Errorf("outs = %v, want %v", outs, tt.outs)
+			}
+
+			if a.Street != tt.street || a.CleanOuts != tt.clean {
+				t.Errorf("street %s, %d clean outs, want %s, %d", a.Street, a.CleanOuts, tt.street, tt.clean)
+			}
+
+			if !reflect.DeepEqual(a.Draws, tt.draws) {
+				t.Errorf("draws = %v, want %v", a.Draws, tt.draws)
+			}
+
+			if unseen := DeckLength - len(pocket) - len(board); a.Unseen != unseen {
+				t.Errorf("unseen = %d, want %d", a.Unseen, unseen)
+			}
+
+			if want := float64(len(a.Outs)) / float64(a.Unseen); a.NextCard != want {
+				t.Errorf("next card = %f, want %f", a.NextCard, want)
+			}
+
+			if a.ByRiver < a.NextCard || a.ByRiver > 1 {
+				t.Errorf("by river = %f, next card %f", a.ByRiver, a.NextCard)
+			}
+		})
+	}
+}
+
+func TestAnalyzeOutsByRiver(t *testing.T) {
+	// с двумя картами до ривера рука улучшается чаще, чем следующей картой, но не всегда
+	a, err := AnalyzeOuts(MustParse("Ac Kd"), MustParse("9h 5s 2c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.RuleOfTwoAndFour != float64(4*len(a.Outs)) {
+		t.Errorf("rule of 4 = %.0f for %d outs", a.RuleOfTwoAndFour, len(a.Outs))
+	}
+
+	if a.ByRiver <= a.NextCard || a.ByRiver >= 1 {
+		t.Errorf("by river = %f, next card %f", a.ByRiver, a.NextCard)
+	}
+}
+
+func TestAnalyzeOutsErrors(t *testing.T) {
+	tests := []struct {
+		pocket string
+		board  string
+	}{
+		{pocket: "Ah", board: "Kd 7c 2s"},
+		{pocket: "Ah Ad", board: "Kd 7c"},
+		{pocket: "Ah Ad", board: "Kd 7c 2s 3s 4s"},
+	}
+
+	for _, tt := range tests {
+		if _, err := AnalyzeOuts(MustParse(tt.pocket), MustParse(tt.board)); err == nil {
+			t.Errorf("AnalyzeOuts(%s, %s) accepted", tt.pocket, tt.board)
+		}
+	}
+}
+
+func TestAnalyzeStreets(t *testing.T) {
+	tests := []struct {
+		board   string
+		streets []Street
+		err     bool
+	}{
+		{board: "Kh 7h 2c", streets: []Street{FlopStreet}},
+		{board: "Kh 7h 2c 4s", streets: []Street{FlopStreet, TurnStreet}},
+		{board: "Kh 7h 2c 4s 9d", streets: []Street{FlopStreet, TurnStreet}},
+		{board: "Kh 7h", err: true},
+	}
+
+	for _, tt := range tests {
+		analyses, err := AnalyzeStreets(MustParse("Ah 9h"), MustParse(tt.board))
+		if tt.err {
+			if err == nil {
+				t.Errorf("AnalyzeStreets(%s) accepted", tt.board)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		streets := make([]Street, len(analyses))
+		for i, a := range analyses {
+			streets[i] = a.Street
+		}
+
+		if !reflect.DeepEqual(streets, tt.streets) {
+			t.Errorf("AnalyzeStreets(%s) streets = %v, want %v", tt.board, streets, tt.streets)
+		}
+	}
+}
+
+func TestOutsAnalysisString(t *testing.T) {
+	a, err := AnalyzeOuts(MustParse("Ah 9h"), MustParse("Kh 7h 2c 4s"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := a.String()
+
+	for _, want := range []string{"flush draw", "15 outs (10 clean) of 46 unseen cards", "rule of 2: 30%"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("String() = %q, want it to contain %q", s, want)
+		}
+	}
+}
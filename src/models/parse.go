@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseErrorKind - причина ошибки разбора карты
+type ParseErrorKind int
+
+const (
+	// EmptyCardError - пустая строка вместо карты
+	EmptyCardError ParseErrorKind = iota
+	// UnknownValueError - неизвестное значение карты
+	UnknownValueError
+	// UnknownSuitError - неизвестная или отсутствующая масть
+	UnknownSuitError
+	// DuplicateCardError - карта повторяется в наборе карт
+	DuplicateCardError
+)
+
+var parseErrorReasons = []string{"empty card", "unknown value", "unknown suit", "duplicate card"}
+
+// ParseError - ошибка разбора карты Input
+type ParseError struct {
+	Input string
+	Kind  ParseErrorKind
+}
+
+func NewParseError(input string, kind ParseErrorKind) ParseError {
+	return ParseError{Input: input, Kind: kind}
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("Can't parse card %q: %s", e.Input, parseErrorReasons[e.Kind])
+}
+
+// suitRunes - обозначения мастей: буквы в любом регистре и символы юникода
+var suitRunes = map[rune]Suite{
+	'H': Hearts, 'h': Hearts, '♥': Hearts, '♡': Hearts,
+	'D': Diamonds, 'd': Diamonds, '♦': Diamonds, '♢': Diamonds,
+	'S': Spades, 's': Spades, '♠': Spades, '♤': Spades,
+	'C': Crosses, 'c': Crosses, '♣': Crosses, '♧': Crosses,
+}
+
+// parseValue разбирает значение карты: 2-9, T или 10, J, Q, K, A в любом регистре
+func parseValue(s string) (CardValue, bool) {
+	s = strings.ToUpper(s)
+
+	if s == "T" {
+		return Ten, true
+	}
+
+	if v, ok := picCardsValues[s]; ok {
+		return v, true
+	}
+
+	for i, v := range cardValuesStrings {
+		if v == s {
+			return CardValue(i) + Two, true
+		}
+	}
+
+	return 0, false
+}
+
+// ParseCard разбирает карту в одной из распространенных записей: "AH", "Ah", "10d", "Td", "A♠"
+func ParseCard(s string) (*Card, error) {
+	input := s
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return nil, NewParseError(input, EmptyCardError)
+	}
+
+	r, size := utf8.DecodeLastRuneInString(s)
+
+	suite, ok := suitRunes[r]
+	if !ok {
+		// масть не указана или неизвестна, но значение распознано
+		_, whole := parseValue(s)
+		_, prefix := parseValue(s[:len(s)-size])
+
+		if whole || prefix {
+			return nil, NewParseError(input, UnknownSuitError)
+		}
+
+		return nil, NewParseError(input, UnknownValueError)
+	}
+
+	value, ok := parseValue(s[:len(s)-size])
+	if !ok {
+		return nil, NewParseError(input, UnknownValueError)
+	}
+
+	return newCard(value, suite), nil
+}
+
+// ParseCards разбирает набор карт, разделенных пробелами или запятыми, или записанных слитно:
+// "Ah Kd", "Ah,Kd", "AhKd10c". Повтор карты считается ошибкой
+func ParseCards(s string) ([]*Card, error) {
+	cards := make([]*Card, 0)
+
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+
+	for _, field := range fields {
+		for _, token := range splitCards(field) {
+			card, err := ParseCard(token)
+			if err != nil {
+				return nil, err
+			}
+
+			if inSlice(cards, card) {
+				return nil, NewParseError(token, DuplicateCardError)
+			}
+
+			cards = append(cards, card)
+		}
+	}
+
+	return cards, nil
+}
+
+// splitCards делит слитную запись карт на отдельные карты по символам мастей.
+// Если масти в записи не найдены, запись возвращается целиком
+func splitCards(s string) []string {
+	tokens := make([]string, 0, 1)
+	start := 0
+
+	for i, r := range s {
+		if _, ok := suitRunes[r]; ok && i > start {
+			end := i + utf8.RuneLen(r)
+
+			tokens = append(tokens, s[start:end])
+			start = end
+		}
+	}
+
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+
+	return tokens
+}
+
+// MustParse разбирает набор карт как ParseCards и паникует при ошибке. Предназначена для тестов
+// и заранее известных наборов карт
+func MustParse(s string) []*Card {
+	cards, err := ParseCards(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return cards
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		kind  ParseErrorKind
+		err   bool
+	}{
+		{input: "Ah", want: "AH"},
+		{input: "AH", want: "AH"},
+		{input: "10d", want: "10D"},
+		{input: "Td", want: "10D"},
+		{input: "tD", want: "10D"},
+		{input: "A♠", want: "AS"},
+		{input: "q♧", want: "QC"},
+		{input: " 2c ", want: "2C"},
+		{input: "", kind: EmptyCardError, err: true},
+		{input: "  ", kind: EmptyCardError, err: true},
+		{input: "1h", kind: UnknownValueError, err: true},
+		{input: "Xs", kind: UnknownValueError, err: true},
+		{input: "A", kind: UnknownSuitError, err: true},
+		{input: "Ax", kind: UnknownSuitError, err: true},
+		{input: "10", kind: UnknownSuitError, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			card, err := ParseCard(tt.input)
+
+			if !tt.err {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if card.String() != tt.want {
+					t.Errorf("ParseCard(%q) = %s, want %s", tt.input, card, tt.want)
+				}
+
+				return
+			}
+
+			var perr ParseError
+			if !errors.As(err, &perr) || perr.Kind != tt.kind || perr.Input != tt.input {
+				t.Errorf("ParseCard(%q) error = %v, want kind %d", tt.input, err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestParseCards(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+		err   bool
+	}{
+		{input: "Ah Kd", want: []string{"AH", "KD"}},
+		{input: "Ah,Kd", want: []string{"AH", "KD"}},
+		{input: "AhKd10c", want: []string{"AH", "KD", "10C"}},
+		{input: "A♥ K♦, 2♣", want: []string{"AH", "KD", "2C"}},
+		{input: "", want: []string{}},
+		{input: "Ah ah", err: true},
+		{input: "Ah Zz", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cards, err := ParseCards(tt.input)
+
+			if tt.err {
+				if err == nil {
+					t.Errorf("ParseCards(%q) = %v, want error", tt.input, cards)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(cards) != len(tt.want) {
+				t.Fatalf("ParseCards(%q) = %v, want %v", tt.input, cards, tt.want)
+			}
+
+			for i, c := range cards {
+				if c.String() != tt.want[i] {
+					t.Errorf("card %d = %s, want %s", i, c, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewCardFromStringUnknown(t *testing.T) {
+	tests := []string{"Xh", "Ax", "", "1"}
+
+	for _, input := range tests {
+		if i := NewCardFromString(input).Index(); i != NoCard {
+			t.Errorf("NewCardFromString(%q).Index() = %d, want NoCard", input, i)
+		}
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	err := NewParseError("Zz", UnknownValueError)
+
+	if got, want := err.Error(), `Can't parse card "Zz": unknown value`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -1,7 +1,6 @@
 package models
 
 import (
-	"errors"
 	"fmt"
 	"hands/src/helpers"
 	"sync"
@@ -11,9 +10,19 @@ type Player struct {
 	ID     string
 	Name   string
 	Active bool
+	// Seat - номер места игрока за столом
+	Seat int
 
 	currentChipsAmount Chips
-	pocketCards        []*Card
+	// pocketCards - карманные карты в порядке сдачи, в котором они показываются и записываются в историю
+	// раздачи; множество карт возвращает PocketSet
+	pocketCards []*Card
+	// pocketSize - количество карт игрока в текущем варианте игры; 0 означает PocketSize
+	pocketSize int
+	// sittingOut - игрок пропускает раздачи, оставаясь за столом
+	sittingOut bool
+	// dealtIn - игрок сдан в текущую раздачу, даже если он уже сбросил карты или пошел олл-ин
+	dealtIn bool
 	sync.RWMutex
 }
 
@@ -53,12 +62,25 @@ func (p *Player) GetPocketCards() []*Card {
 	return p.pocketCards
 }
 
-func (p *Player) AddCard(card *Card) error {
+// PocketSet возвращает множество карманных карт игрока
+func (p *Player) PocketSet() CardSet {
 	p.RLock()
 	defer p.RUnlock()
 
-	if len(p.pocketCards) == PocketSize {
-		return errors.New("only two pocket cards allowed")
+	return NewCardSet(p.pocketCards...)
+}
+
+// AddCard добавляет карту игроку. Количество карт ограничено размером руки текущего варианта игры
+func (p *Player) AddCard(card *Card) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if len(p.pocketCards) >= p.maxPocketSize() {
+		return fmt.Errorf("only %d pocket cards allowed", p.maxPocketSize())
+	}
+
+	if inSlice(p.pocketCards, card) {
+		return fmt.Errorf("card %s is in the pocket already", card)
 	}
 
 	p.pocketCards = append(p.pocketCards, card)
@@ -87,7 +109,7 @@ func (p *Player) Raise(bet *Bet) (Chips, error) {
 	p.RLock()
 	defer p.RUnlock()
 
-	if bet.Bet > p.currentChipsAmount {
+	if bet.Bet+bet.Over > p.currentChipsAmount {
 		return 0, fmt.Errorf("Wrong bet amount %d", bet)
 	}
 
@@ -105,3 +127,110 @@ func (p *Player) Fall(bet *Bet) (Chips, error) {
 
 	return bet.Bet, nil
 }
+
+// Post списывает со стека игрока принудительную ставку (блайнд, анте, стрэддл).
+// Если фишек у игрока меньше, чем amount, списываются все оставшиеся фишки.
+// Возвращает фактически внесенную сумму
+func (p *Player) Post(amount Chips) Chips {
+	p.Lock()
+	defer p.Unlock()
+
+	if amount > p.currentChipsAmount {
+		amount = p.currentChipsAmount
+	}
+
+	p.currentChipsAmount -= amount
+
+	return amount
+}
+
+// AddChips добавляет фишки в стек игрока (выигрыш банка или докупка)
+func (p *Player) AddChips(amount Chips) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.currentChipsAmount += amount
+}
+
+func (p *Player) maxPocketSize() int {
+	if p.pocketSize == 0 {
+		return PocketSize
+	}
+
+	return p.pocketSize
+}
+
+// replaceCards заменяет карты игрока discards на cards (обмен в дро-покере)
+func (p *Player) replaceCards(discards, cards []*Card) {
+	p.Lock()
+	defer p.Unlock()
+
+	kept := make([]*Card, 0, len(p.pocketCards))
+	mucked := NewCardSet(discards...)
+
+	for _, c := range p.pocketCards {
+		if !mucked.Contains(c) {
+			kept = append(kept, c)
+		}
+	}
+
+	p.pocketCards = append(kept, cards...)
+}
+
+// resetHand готовит игрока к новой раздаче с pocketSize картами, см. DealIn
+func (p *Player) resetHand(pocketSize int) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.pocketCards = make([]*Card, 0, pocketSize)
+	p.pocketSize = pocketSize
+	p.dealIn()
+}
+
+// DealIn начинает для игрока новую раздачу в играх, которые ведут раздачи сами: игрок сдается в раздачу,
+// если он не пропускает раздачи и у него есть фишки. Возвращает true, если игрок сдан
+func (p *Player) DealIn() bool {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.dealIn()
+}
+
+func (p *Player) dealIn() bool {
+	p.dealtIn = !p.sittingOut && p.currentChipsAmount > 0
+	p.Active = p.dealtIn
+
+	return p.dealtIn
+}
+
+// SitOut отмечает, что игрок пропускает раздачи, начиная со следующей. Текущую раздачу игрок доигрывает
+func (p *Player) SitOut() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.sittingOut = true
+}
+
+// SitIn возвращает игрока в игру со следующей раздачи
+func (p *Player) SitIn() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.sittingOut = false
+}
+
+// IsSittingOut проверяет, пропускает ли игрок раздачи
+func (p *Player) IsSittingOut() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.sittingOut
+}
+
+// isSeated проверяет, участвует ли игрок в игре: сдан в текущую раздачу или готов к следующей
+func (p *Player) isSeated() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.dealtIn || !p.sittingOut && p.currentChipsAmount > 0
+}
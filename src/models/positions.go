@@ -0,0 +1,253 @@
+package models
+
+import (
+	"fmt"
+	"hands/src/helpers"
+)
+
+// Position - название позиции игрока относительно баттона
+type Position string
+
+const (
+	ButtonPosition     Position = "BTN"
+	SmallBlindPosition Position = "SB"
+	BigBlindPosition   Position = "BB"
+	UTGPosition        Position = "UTG"
+	LojackPosition     Position = "LJ"
+	HijackPosition     Position = "HJ"
+	CutoffPosition     Position = "CO"
+)
+
+// HeadsUpPlayersNum - количество игроков в игре один на один
+const HeadsUpPlayersNum = 2
+
+// latePositions - названия поздних позиций перед баттоном, в порядке действий
+var latePositions = []Position{
+	LojackPosition,
+	HijackPosition,
+	CutoffPosition,
+}
+
+// utgPosition возвращает название n-й позиции, начиная с UTG: UTG, UTG+1, UTG+2...
+func utgPosition(n int) Position {
+	if n == 0 {
+		return UTGPosition
+	}
+
+	return Position(fmt.Sprintf("%s+%d", UTGPosition, n))
+}
+
+// middlePositions возвращает названия позиций между большим блайндом и баттоном, в порядке действий.
+// Поздние позиции (CO, HJ, LJ) заполняются от баттона, остальные именуются от UTG, причем UTG есть всегда
+func middlePositions(num int) []Position {
+	if num == 0 {
+		return nil
+	}
+
+	early := num - len(latePositions)
+	if early < 1 {
+		early = 1
+	}
+
+	positions := make([]Position, 0, num)
+
+	for i := 0; i < early; i++ {
+		positions = append(positions, utgPosition(i))
+	}
+
+	return append(positions, latePositions[len(latePositions)-(num-early):]...)
+}
+
+// seated возвращает игроков, участвующих в игре: сданных в текущую раздачу, в том числе олл-ин,
+// и готовых к следующей - не пропускающих раздачи и имеющих фишки
+func (t *Table) seated() []*Player {
+	players := make([]*Player, 0, len(t.Players))
+
+	for _, p := range t.Players {
+		if p.isSeated() {
+			players = append(players, p)
+		}
+	}
+
+	return players
+}
+
+// playerAtSeat возвращает игрока, сидящего на месте seat и участвующего в игре, или nil
+func (t *Table) playerAtSeat(seat int) *Player {
+	for _, p := range t.seated() {
+		if p.Seat == seat {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// nextSeated возвращает первого участвующего в игре игрока после места seat по часовой стрелке
+func (t *Table) nextSeated(seat int) *Player {
+	players := t.seated()
+	if len(players) == 0 {
+		return nil
+	}
+
+	for _, p := range players {
+		if p.Seat > seat {
+			return p
+		}
+	}
+
+	return players[0]
+}
+
+// orderFrom возвращает участвующих в игре игроков по часовой стрелке, начиная с первого после места seat
+func (t *Table) orderFrom(seat int) []*Player {
+	players := t.seated()
+	order := make([]*Player, 0, len(players))
+
+	for _, p := range players {
+		if p.Seat > seat {
+			order = append(order, p)
+		}
+	}
+
+	for _, p := range players {
+		if p.Seat <= seat {
+			order = append(order, p)
+		}
+	}
+
+	return order
+}
+
+// placeButton расставляет баттон и блайнды заново, начиная с места seat.
+// В игре один на один баттон ставит малый блайнд
+func (t *Table) placeButton(seat int) {
+	t.Dealer = seat
+
+	if len(t.seated()) == HeadsUpPlayersNum {
+		t.SmallBlindSeat = seat
+		t.BigBlindSeat = t.nextSeated(seat).Seat
+
+		return
+	}
+
+	t.SmallBlindSeat = t.nextSeated(seat).Seat
+	t.BigBlindSeat = t.nextSeated(t.SmallBlindSeat).Seat
+}
+
+// setDealer выбирает баттон случайным образом
+func (t *Table) setDealer() *Table {
+	players := t.seated()
+	num := 0
+
+	if t.random != nil {
+		num = t.random.Intn(len(players))
+	} else {
+		num = helpers.GenerateRandomNumInRange(len(players))
+	}
+
+	t.placeButton(players[num].Seat)
+
+	return t
+}
+
+// NextDealer передвигает баттон по правилу мертвого баттона: большой блайнд всегда переходит
+// к следующему игроку, малый блайнд занимает место прошлого большого (и становится мертвым, если игрок ушел),
+// а баттон - место прошлого малого (и становится мертвым, если место пусто).
+// В игре один на один баттон ставит малый блайнд. Возвращает номер места баттона
+func (t *Table) NextDealer() int {
+	players := t.seated()
+	if len(players) < HeadsUpPlayersNum {
+		return t.Dealer
+	}
+
+	if t.isDealerInactive {
+		t.isDealerInactive = false
+
+		return t.setDealer().Dealer
+	}
+
+	bigBlind := t.nextSeated(t.BigBlindSeat)
+
+	if len(players) == HeadsUpPlayersNum {
+		for _, p := range players {
+			if p != bigBlind {
+				t.Dealer, t.SmallBlindSeat = p.Seat, p.Seat
+			}
+		}
+
+		t.BigBlindSeat = bigBlind.Seat
+
+		return t.Dealer
+	}
+
+	button, smallBlind := t.SmallBlindSeat, t.BigBlindSeat
+
+	// после игры один на один, или если большой блайнд обошел баттон, расставляем позиции заново
+	if t.Dealer == t.SmallBlindSeat || bigBlind.Seat == button || bigBlind.Seat == smallBlind {
+		t.placeButton(t.nextSeated(t.Dealer).Seat)
+
+		return t.Dealer
+	}
+
+	t.Dealer, t.SmallBlindSeat, t.BigBlindSeat = button, smallBlind, bigBlind.Seat
+
+	return t.Dealer
+}
+
+// GetDealer возвращает игрока на баттоне, или nil, если баттон мертвый
+func (t *Table) GetDealer() *Player {
+	return t.playerAtSeat(t.Dealer)
+}
+
+// GetFirstPosition возвращает игрока на малом блайнде, или nil, если малый блайнд мертвый
+func (t *Table) GetFirstPosition() *Player {
+	return t.playerAtSeat(t.SmallBlindSeat)
+}
+
+// GetSecondPosition возвращает игрока на большом блайнде
+func (t *Table) GetSecondPosition() *Player {
+	return t.playerAtSeat(t.BigBlindSeat)
+}
+
+// PreflopOrder возвращает игроков в порядке действий на префлопе: от игрока после большого блайнда
+// до большого блайнда. В игре один на один первым действует баттон
+func (t *Table) PreflopOrder() []*Player {
+	return t.orderFrom(t.BigBlindSeat)
+}
+
+// PostflopOrder возвращает игроков в порядке действий после флопа: от первого игрока после баттона
+// до баттона. В игре один на один баттон действует последним
+func (t *Table) PostflopOrder() []*Player {
+	return t.orderFrom(t.Dealer)
+}
+
+// Positions возвращает названия позиций всех участвующих в игре игроков, по их ID
+func (t *Table) Positions() map[string]Position {
+	positions := make(map[string]Position)
+	middle := make([]*Player, 0)
+
+	for _, p := range t.PreflopOrder() {
+		switch p.Seat {
+		case t.Dealer:
+			positions[p.ID] = ButtonPosition
+		case t.SmallBlindSeat:
+			positions[p.ID] = SmallBlindPosition
+		case t.BigBlindSeat:
+			positions[p.ID] = BigBlindPosition
+		default:
+			middle = append(middle, p)
+		}
+	}
+
+	for i, position := range middlePositions(len(middle)) {
+		positions[middle[i].ID] = position
+	}
+
+	return positions
+}
+
+// GetPosition возвращает название позиции игрока, или пустую строку, если игрок не участвует в игре
+func (t *Table) GetPosition(playerID string) Position {
+	return t.Positions()[playerID]
+}
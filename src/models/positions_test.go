@@ -0,0 +1,135 @@
+package models
+
+import (
+	"math/rand"
+	"testing"
+
+	"hands/src/helpers"
+)
+
+// newPositionsTable сажает за стол num игроков со стеком 100 и начинает раздачу
+func newPositionsTable(t *testing.T, num int) *Table {
+	t.Helper()
+
+	table := NewTable("test", "t", helpers.NewDefaultIdGenerator(), CasheTableType, num, 10, 5).
+		WithRandom(rand.New(rand.NewSource(1)))
+
+	for i := 0; i < num; i++ {
+		if err := table.Register(NewPlayer("p", helpers.NewDefaultIdGenerator(), 100)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := table.NewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	return table
+}
+
+func hasPlayer(players []*Player, id string) bool {
+	for _, p := range players {
+		if p.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestAllInPlayerKeepsPosition(t *testing.T) {
+	table := newPositionsTable(t, 4)
+	dealer := table.GetDealer()
+	before := table.Positions()
+
+	// баттон идет олл-ин
+	dealer.Post(dealer.GetCurrentChipsAmount())
+
+	tests := []struct {
+		name    string
+		players []*Player
+	}{
+		{"preflop order", table.PreflopOrder()},
+		{"postflop order", table.PostflopOrder()},
+	}
+
+	for _, tt := range tests {
+		if !hasPlayer(tt.players, dealer.ID) {
+			t.Errorf("%s lost the all-in player", tt.name)
+		}
+	}
+
+	if table.GetDealer() != dealer {
+		t.Errorf("GetDealer() = %v, want the all-in button", table.GetDealer())
+	}
+
+	if after := table.Positions(); after[dealer.ID] != before[dealer.ID] || len(after) != len(before) {
+		t.Errorf("Positions() = %v, want %v", after, before)
+	}
+}
+
+func TestSittingOut(t *testing.T) {
+	table := newPositionsTable(t, 3)
+	player := table.Players[0]
+
+	player.SitOut()
+
+	if !hasPlayer(table.PostflopOrder(), player.ID) {
+		t.Fatal("player sitting out left the current hand")
+	}
+
+	if _, err := table.NewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if hasPlayer(table.PostflopOrder(), player.ID) || player.Active {
+		t.Error("player sitting out was dealt into the next hand")
+	}
+
+	player.SitIn()
+
+	if _, err := table.NewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !hasPlayer(table.PostflopOrder(), player.ID) || !player.Active {
+		t.Error("player was not dealt in after sitting in")
+	}
+}
+
+func TestBustedPlayerSitsOutNextHand(t *testing.T) {
+	table := newPositionsTable(t, 3)
+	player := table.Players[0]
+
+	player.Post(player.GetCurrentChipsAmount())
+
+	if _, err := table.NewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if hasPlayer(table.PostflopOrder(), player.ID) {
+		t.Error("player without chips was dealt in")
+	}
+}
+
+func TestUnregisterAndRegisterAgain(t *testing.T) {
+	table := newPositionsTable(t, 3)
+	player := table.Players[0]
+	total := table.Pot.TotalChipsNum
+
+	if err := table.Unregister(player.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := table.Pot.PlayersChips[player.ID]; ok {
+		t.Error("pot still has the unregistered player")
+	}
+
+	if table.Pot.TotalChipsNum != total {
+		t.Errorf("pot total = %d, want %d", table.Pot.TotalChipsNum, total)
+	}
+
+	if err := table.Register(player); err != nil {
+		t.Errorf("Register() after Unregister() = %v", err)
+	}
+}
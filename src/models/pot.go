@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"sort"
 )
 
 // Chips - условные игровые фишки, на которые ведется игра
@@ -24,7 +25,7 @@ func NewPot(tag string) *Pot {
 }
 
 func (p *Pot) Register(playerID string) error {
-	if _, ok := p.PlayersChips[playerID]; !ok {
+	if _, ok := p.PlayersChips[playerID]; ok {
 		return fmt.Errorf("Player with ID %s is in this game already", playerID)
 	}
 
@@ -33,6 +34,11 @@ func (p *Pot) Register(playerID string) error {
 	return nil
 }
 
+// Unregister удаляет игрока из банка. Его вклад в текущую раздачу остается в банке мертвыми фишками
+func (p *Pot) Unregister(playerID string) {
+	delete(p.PlayersChips, playerID)
+}
+
 func (p *Pot) AddPlayerBet(bet Chips, playerID string) (int, error) {
 	if _, ok := p.PlayersChips[playerID]; !ok {
 		return 0, fmt.Errorf("Player with ID %s is not in this game", playerID)
@@ -56,6 +62,84 @@ func (p *Pot) GetPlayerAmount(playerID string) (int, error) {
 	return int(val), nil
 }
 
+// Reset обнуляет банк перед новой раздачей, сохраняя зарегистрированных игроков
 func (p *Pot) Reset() {
-	p.PlayersChips = make(map[string]Chips)
+	for id := range p.PlayersChips {
+		p.PlayersChips[id] = 0
+	}
+
+	p.TotalChipsNum = 0
+}
+
+// SidePot - часть банка и ID игроков, претендующих на нее
+type SidePot struct {
+	Amount  Chips
+	Players []string
+}
+
+// SidePots делит банк на основной и побочные банки по вкладам игроков. players - ID игроков, не сбросивших
+// карты; каждый из них претендует только на части банка, которые он уравнял. Первым возвращается основной банк.
+// Фишки сбросивших игроков остаются в частях банка, в которые они внесены
+func (p *Pot) SidePots(players []string) []*SidePot {
+	levels := make([]Chips, 0, len(players))
+
+	for _, id := range players {
+		if c := p.PlayersChips[id]; c > 0 && !inChips(levels, c) {
+			levels = append(levels, c)
+		}
+	}
+
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i] < levels[j]
+	})
+
+	pots := make([]*SidePot, 0, len(levels))
+	rest, prev := p.TotalChipsNum, Chips(0)
+
+	for _, level := range levels {
+		pot := &SidePot{}
+
+		for _, c := range p.PlayersChips {
+			pot.Amount += minChips(c, level) - minChips(c, prev)
+		}
+
+		for _, id := range players {
+			if p.PlayersChips[id] >= level {
+				pot.Players = append(pot.Players, id)
+			}
+		}
+
+		pots = append(pots, pot)
+		rest -= pot.Amount
+		prev = level
+	}
+
+	// фишки сбросивших игроков сверх вкладов оставшихся разыгрываются в последней части банка
+	if rest > 0 && len(players) > 0 {
+		if len(pots) == 0 {
+			pots = append(pots, &SidePot{Players: append(make([]string, 0, len(players)), players...)})
+		}
+
+		pots[len(pots)-1].Amount += rest
+	}
+
+	return pots
+}
+
+func inChips(cc []Chips, c Chips) bool {
+	for _, v := range cc {
+		if v == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func minChips(a, b Chips) Chips {
+	if a < b {
+		return a
+	}
+
+	return b
 }
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSidePots(t *testing.T) {
+	tests := []struct {
+		name    string
+		chips   map[string]Chips
+		players []string
+		want    []*SidePot
+	}{
+		{
+			name:    "equal stacks",
+			chips:   map[string]Chips{"a": 100, "b": 100, "c": 100},
+			players: []string{"a", "b", "c"},
+			want:    []*SidePot{{Amount: 300, Players: []string{"a", "b", "c"}}},
+		},
+		{
+			name:    "short all-in",
+			chips:   map[string]Chips{"a": 50, "b": 200, "c": 200},
+			players: []string{"a", "b", "c"},
+			want: []*SidePot{
+				{Amount: 150, Players: []string{"a", "b", "c"}},
+				{Amount: 300, Players: []string{"b", "c"}},
+			},
+		},
+		{
+			name:    "two all-ins",
+			chips:   map[string]Chips{"a": 30, "b": 80, "c": 200, "d": 200},
+			players: []string{"a", "b", "c", "d"},
+			want: []*SidePot{
+				{Amount: 120, Players: []string{"a", "b", "c", "d"}},
+				{Amount: 150, Players: []string{"b", "c", "d"}},
+				{Amount: 240, Players: []string{"c", "d"}},
+			},
+		},
+		{
+			name:    "folded chips stay in the pots they were put in",
+			chips:   map[string]Chips{"a": 50, "b": 200, "c": 200, "f": 100},
+			players: []string{"a", "b", "c"},
+			want: []*SidePot{
+				{Amount: 200, Players: []string{"a", "b", "c"}},
+				{Amount: 350, Players: []string{"b", "c"}},
+			},
+		},
+		{
+			name:    "folded chips above the last call",
+			chips:   map[string]Chips{"a": 40, "f": 100},
+			players: []string{"a"},
+			want:    []*SidePot{{Amount: 140, Players: []string{"a"}}},
+		},
+		{
+			name:    "uncalled bet",
+			chips:   map[string]Chips{"a": 100, "b": 300},
+			players: []string{"a", "b"},
+			want: []*SidePot{
+				{Amount: 200, Players: []string{"a", "b"}},
+				{Amount: 200, Players: []string{"b"}},
+			},
+		},
+		{
+			name:    "empty pot",
+			chips:   map[string]Chips{"a": 0, "b": 0},
+			players: []string{"a", "b"},
+			want:    []*SidePot{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPot("t")
+
+			for id, c := range tt.chips {
+				if err := p.Register(id); err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err := p.AddPlayerBet(c, id); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := p.SidePots(tt.players)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SidePots() = %s, want %s", sidePotsString(got), sidePotsString(tt.want))
+			}
+
+			total := Chips(0)
+			for _, pot := range got {
+				total += pot.Amount
+			}
+
+			if len(got) > 0 && total != p.TotalChipsNum {
+				t.Errorf("pots sum to %d, total is %d", total, p.TotalChipsNum)
+			}
+		})
+	}
+}
+
+func sidePotsString(pots []*SidePot) string {
+	s := make([]string, len(pots))
+
+	for i, pot := range pots {
+		s[i] = fmt.Sprintf("%d%v", pot.Amount, pot.Players)
+	}
+
+	return fmt.Sprint(s)
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+// Исходы сравнения руки игрока с рукой соперника
+const (
+	aheadIndex = iota
+	tiedIndex
+	behindIndex
+	outcomesNum
+)
+
+// HandPotential - сила руки и ее потенциал (по Billings et al.) против диапазона соперника
+type HandPotential struct {
+	// HS - текущая сила руки: доля рук соперника, которые рука опережает (ничьи - наполовину)
+	HS float64
+	// PPot - положительный потенциал: вероятность обогнать соперника, который сейчас впереди
+	PPot float64
+	// NPot - отрицательный потенциал: вероятность отстать от соперника, которого рука сейчас опережает
+	NPot float64
+	// EHS - эффективная сила руки: HS * (1 - NPot) + (1 - HS) * PPot
+	EHS float64
+}
+
+// EffectiveStrength возвращает эффективную силу руки против opponents соперников с таким же диапазоном
+func (p *HandPotential) EffectiveStrength(opponents int) float64 {
+	hs := 1.0
+
+	for i := 0; i < opponents; i++ {
+		hs *= p.HS
+	}
+
+	return hs*(1-p.NPot) + (1-hs)*p.PPot
+}
+
+// UniformRange возвращает все сочетания карманных карт, не содержащие карт dead
+func UniformRange(dead ...[]*Card) [][]*Card {
+	cards := deadCardsDeck(dead...)
+	pockets := make([][]*Card, 0, len(cards)*(len(cards)-1)/2)
+
+	for i := range cards {
+		for j := i + 1; j < len(cards); j++ {
+			pockets = append(pockets, []*Card{cards[i], cards[j]})
+		}
+	}
+
+	return pockets
+}
+
+// compareRanks возвращает индекс исхода сравнения руки hero с рукой villain
+func compareRanks(hero, villain HandRank) int {
+	switch {
+	case hero > villain:
+		return aheadIndex
+	case hero < villain:
+		return behindIndex
+	}
+
+	return tiedIndex
+}
+
+// CurrentHandStrength возвращает текущую силу руки pocket при общих картах board против рук opponents
+// (nil - любые руки). Руки соперника, пересекающиеся с pocket или board, не учитываются
+func CurrentHandStrength(pocket, board []*Card, opponents [][]*Card) (float64, error) {
+	p, err := Potential(pocket, board, opponents, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	return p.HS, nil
+}
+
+// Potential рассчитывает силу руки pocket при общих картах board против рук opponents (nil - любые руки)
+// и ее потенциал при доставке следующих lookahead общих карт, перебирая все варианты доставки.
+// При lookahead == 0 потенциал равен нулю
+func Potential(pocket, board []*Card, opponents [][]*Card, lookahead int) (*HandPotential, error) {
+	if len(pocket) != PocketSize {
+		return nil, fmt.Errorf("Hand potential requires %d pocket cards, got %d", PocketSize, len(pocket))
+	}
+
+	if len(board) < CardsOnFlopNumber || len(board) > BoardSize {
+		return nil, fmt.Errorf("Hand potential requires %d to %d board cards, got %d", CardsOnFlopNumber, BoardSize, len(board))
+	}
+
+	if lookahead < 0 || len(board)+lookahead > BoardSize {
+		return nil, fmt.Errorf("Can't look %d cards ahead on board of %d cards", lookahead, len(board))
+	}
+
+	if opponents == nil {
+		opponents = UniformRange(pocket, board)
+	}
+
+	var (
+		// current - исходы сравнения сейчас, по рукам соперника
+		current [outcomesNum]float64
+		// potential - исходы сравнения сейчас и после доставки карт, по вариантам доставки
+		potential [outcomesNum][outcomesNum]float64
+		totals    [outcomesNum]float64
+	)
+
+	deck := deadCardsDeck(pocket, board)
+	hero := make([]*Card, 0, BoardSize+PocketSize)
+	villain := make([]*Card, 0, BoardSize+PocketSize)
+	runout := make([]*Card, lookahead)
+	combo := make([]int, lookahead)
+	remaining := make([]*Card, 0, len(deck))
+
+	heroRank := RankCards(append(append(hero[:0], board...), pocket...))
+
+	dead := NewCardSet(pocket...).Union(NewCardSet(board...))
+
+	for _, opp := range opponents {
+		if len(opp) != PocketSize || dead.Contains(opp[0]) || dead.Contains(opp[1]) {
+			continue
+		}
+
+		now := compareRanks(heroRank, RankCards(append(append(villain[:0], board...), opp...)))
+		current[now]++
+
+		if lookahead == 0 {
+			continue
+		}
+
+		oppSet := NewCardSet(opp...)
+
+		remaining = remaining[:0]
+		for _, c := range deck {
+			if !oppSet.Contains(c) {
+				remaining = append(remaining, c)
+			}
+		}
+
+		gen := combin.NewCombinationGenerator(len(remaining), lookahead)
+
+		for gen.Next() {
+			for i, idx := range gen.Combination(combo) {
+				runout[i] = remaining[idx]
+			}
+
+			later := compareRanks(
+				RankCards(append(append(append(hero[:0], board...), runout...), pocket...)),
+				RankCards(append(append(append(villain[:0], board...), runout...), opp...)),
+			)
+
+			potential[now][later]++
+			totals[now]++
+		}
+	}
+
+	total := current[aheadIndex] + current[tiedIndex] + current[behindIndex]
+	if total == 0 {
+		return nil, fmt.Errorf("No opponent hands compatible with pocket and board")
+	}
+
+	p := &HandPotential{
+		HS: (current[aheadIndex] + current[tiedIndex]/2) / total,
+	}
+
+	if d := totals[behindIndex] + totals[tiedIndex]/2; d > 0 {
+		p.PPot = (potential[behindIndex][aheadIndex] + potential[behindIndex][tiedIndex]/2 +
+			potential[tiedIndex][aheadIndex]/2) / d
+	}
+
+	if d := totals[aheadIndex] + totals[tiedIndex]/2; d > 0 {
+		p.NPot = (potential[aheadIndex][behindIndex] + potential[tiedIndex][behindIndex]/2 +
+			potential[aheadIndex][tiedIndex]/2) / d
+	}
+
+	p.EHS = p.HS*(1-p.NPot) + (1-p.HS)*p.PPot
+
+	return p, nil
+}
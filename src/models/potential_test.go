@@ -0,0 +1,163 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPotential(t *testing.T) {
+	tests := []struct {
+		name      string
+		pocket    string
+		board     string
+		opponents []string
+		lookahead int
+		want      HandPotential
+	}{
+		{
+			name:   "quads are the nuts against any hand",
+			pocket: "Ah Ad",
+			board:  "As Ac Kd 7h 2s",
+			want:   HandPotential{HS: 1, EHS: 1},
+		},
+		{
+			name:   "royal flush on board ties every hand",
+			pocket: "2c 3d",
+			board:  "Ah Kh Qh Jh Th",
+			want:   HandPotential{HS: 0.5, EHS: 0.5},
+		},
+		{
+			name:      "drawing dead",
+			pocket:    "2c 3d",
+			board:     "Ah Ac 7d",
+			opponents: []string{"As Ad"},
+			lookahead: 1,
+			want:      HandPotential{},
+		},
+		{
+			// 8 из 45 оставшихся карт делают флэш: двойка червей дает сету фулл-хаус
+			name:      "flush draw against a set",
+			pocket:    "9h 8h",
+			board:     "Kh 5h 2c",
+			opponents: []string{"Ks Kd"},
+			lookahead: 1,
+			want:      HandPotential{PPot: 8.0 / 45, EHS: 8.0 / 45},
+		},
+		{
+			name:      "set against a flush draw",
+			pocket:    "Ks Kd",
+			board:     "Kh 5h 2c",
+			opponents: []string{"9h 8h"},
+			lookahead: 1,
+			want:      HandPotential{HS: 1, NPot: 8.0 / 45, EHS: 1 - 8.0/45},
+		},
+		{
+			name:      "opponent hands overlapping the board are skipped",
+			pocket:    "Ks Kd",
+			board:     "Kh 5h 2c",
+			opponents: []string{"9h 8h", "Kh Qh", "Ks 2d"},
+			lookahead: 1,
+			want:      HandPotential{HS: 1, NPot: 8.0 / 45, EHS: 1 - 8.0/45},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opponents [][]*Card
+			for _, opp := range tt.opponents {
+				opponents = append(opponents, MustParse(opp))
+			}
+
+			got, err := Potential(MustParse(tt.pocket), MustParse(tt.board), opponents, tt.lookahead)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !closePotential(got, &tt.want) {
+				t.Errorf("Potential() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPotentialErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		pocket    string
+		board     string
+		opponents []string
+		lookahead int
+	}{
+		{name: "one pocket card", pocket: "Ah", board: "Kd 7c 2s"},
+		{name: "preflop", pocket: "Ah Ad", board: "Kd 7c"},
+		{name: "past the river", pocket: "Ah Ad", board: "Kd 7c 2s 3s", lookahead: 2},
+		{name: "negative lookahead", pocket: "Ah Ad", board: "Kd 7c 2s", lookahead: -1},
+		{name: "no compatible opponents", pocket: "Ah Ad", board: "Kd 7c 2s", opponents: []string{"Ah Kh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opponents [][]*Card
+			for _, opp := range tt.opponents {
+				opponents = append(opponents, MustParse(opp))
+			}
+
+			if _, err := Potential(MustParse(tt.pocket), MustParse(tt.board), opponents, tt.lookahead); err == nil {
+				t.Error("Potential() accepted the input")
+			}
+		})
+	}
+}
+
+func TestCurrentHandStrength(t *testing.T) {
+	hs, err := CurrentHandStrength(MustParse("Ah Ad"), MustParse("As Ac Kd 7h 2s"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hs != 1 {
+		t.Errorf("CurrentHandStrength() = %f, want 1", hs)
+	}
+}
+
+func TestEffectiveStrength(t *testing.T) {
+	p := &HandPotential{HS: 0.5, PPot: 0.2, NPot: 0.1}
+
+	tests := []struct {
+		opponents int
+		want      float64
+	}{
+		{opponents: 1, want: 0.55},
+		{opponents: 2, want: 0.375},
+		{opponents: 0, want: 0.9},
+	}
+
+	for _, tt := range tests {
+		if got := p.EffectiveStrength(tt.opponents); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("EffectiveStrength(%d) = %f, want %f", tt.opponents, got, tt.want)
+		}
+	}
+}
+
+func TestUniformRange(t *testing.T) {
+	pockets := UniformRange(MustParse("Ah Ad"), MustParse("Kd 7c 2s"))
+
+	// 47 живых карт дают 47 * 46 / 2 сочетаний
+	if len(pockets) != 1081 {
+		t.Errorf("len(UniformRange()) = %d, want 1081", len(pockets))
+	}
+
+	dead := NewCardSet(MustParse("Ah Ad Kd 7c 2s")...)
+	for _, p := range pockets {
+		if dead.Contains(p[0]) || dead.Contains(p[1]) {
+			t.Fatalf("range contains dead card: %v", p)
+		}
+	}
+}
+
+func closePotential(got, want *HandPotential) bool {
+	const eps = 1e-9
+
+	return math.Abs(got.HS-want.HS) < eps && math.Abs(got.PPot-want.PPot) < eps &&
+		math.Abs(got.NPot-want.NPot) < eps && math.Abs(got.EHS-want.EHS) < eps
+}
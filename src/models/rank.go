@@ -0,0 +1,191 @@
+package models
+
+// HandRank - числовая оценка лучшей руки из пяти карт: чем больше, тем сильнее рука.
+// Старшие биты содержат HandValue, младшие - значения карт, определяющие старшинство внутри комбинации
+// (по 4 бита на карту). Сравнение HandRank эквивалентно Hand.Compare, но не требует выделения памяти
+type HandRank int32
+
+// rankValueBits - количество бит на значение карты в HandRank
+const rankValueBits = 4
+
+// Value возвращает величину руки
+func (r HandRank) Value() HandValue {
+	return HandValue(r >> (rankValueBits * HandSize))
+}
+
+// High возвращает значение карты, определяющей комбинацию: пары, тройки, старшей карты стрита или руки
+func (r HandRank) High() CardValue {
+	return CardValue(r >> (rankValueBits * (HandSize - 1)) & (1<<rankValueBits - 1))
+}
+
+func newHandRank(value HandValue, kickers ...CardValue) HandRank {
+	r := HandRank(value)
+
+	for i := 0; i < HandSize; i++ {
+		r <<= rankValueBits
+
+		if i < len(kickers) {
+			r |= HandRank(kickers[i])
+		}
+	}
+
+	return r
+}
+
+// straightHighFromMask возвращает старшую карту стрита по битовой маске значений (бит v - значение v),
+// или 0, если стрита нет. Учитывает колесо A2345
+func straightHighFromMask(mask uint16) CardValue {
+	if mask&(1<<Ace) != 0 {
+		mask |= 1 << 1
+	}
+
+	for high := Ace; high >= Five; high-- {
+		straight := uint16(0x1f) << (high - 4)
+
+		if mask&straight == straight {
+			return high
+		}
+	}
+
+	return 0
+}
+
+// topValues возвращает до n старших значений из маски, исключая значения except
+func topValues(mask uint16, n int, except ...CardValue) []CardValue {
+	values := make([]CardValue, 0, n)
+
+	for v := Ace; v >= Two && len(values) < n; v-- {
+		if mask&(1<<v) == 0 {
+			continue
+		}
+
+		skip := false
+		for _, e := range except {
+			skip = skip || e == v
+		}
+
+		if !skip {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// RankCards оценивает лучшую руку из пяти карт среди cards (без повторов).
+// Если карт меньше пяти, недостающие кикеры считаются нулевыми
+func RankCards(cards []*Card) HandRank {
+	return rankCards(cards, true)
+}
+
+// rankCards оценивает лучшую руку из пяти карт. Если wheel ложно, A2345 не считается стритом
+func rankCards(cards []*Card, wheel bool) HandRank {
+	// стрит до пятерки возможен только как колесо: более старшие стриты находятся раньше
+	straightHigh := func(mask uint16) CardValue {
+		if high := straightHighFromMask(mask); wheel || high != Five {
+			return high
+		}
+
+		return 0
+	}
+
+	var (
+		counts    [Ace + 1]int
+		suitMasks [SuitesNum]uint16
+		mask      uint16
+	)
+
+	for _, c := range cards {
+		counts[c.Value]++
+		mask |= 1 << c.Value
+
+		if s := suitIndex(c.Suite.Suite); s >= 0 {
+			suitMasks[s] |= 1 << c.Value
+		}
+	}
+
+	// при восьми и более картах флеш может сочетаться с каре или фулл-хаусом,
+	// поэтому флеш выбирается только после них
+	var straightFlush, flush HandRank
+
+	for _, suitMask := range suitMasks {
+		if bitsCount(suitMask) < HandSize {
+			continue
+		}
+
+		if high := straightHigh(suitMask); high != 0 {
+			value := StraightFlushHand
+			if high == Ace {
+				value = RoyalFlushHand
+			}
+
+			if rank := newHandRank(value, high); rank > straightFlush {
+				straightFlush = rank
+			}
+		}
+
+		if rank := newHandRank(FlushHand, topValues(suitMask, HandSize)...); rank > flush {
+			flush = rank
+		}
+	}
+
+	if straightFlush != 0 {
+		return straightFlush
+	}
+
+	var four, three, secondThree, pair, secondPair CardValue
+
+	for v := Ace; v >= Two; v-- {
+		switch {
+		case counts[v] == 4:
+			four = v
+		case counts[v] == 3 && three == 0:
+			three = v
+		case counts[v] == 3:
+			secondThree = v
+		case counts[v] == 2 && pair == 0:
+			pair = v
+		case counts[v] == 2 && secondPair == 0:
+			secondPair = v
+		}
+	}
+
+	// младшая тройка может служить парой фулл-хауса
+	if secondThree > pair {
+		pair = secondThree
+	}
+
+	switch {
+	case four != 0:
+		return newHandRank(FourHand, append([]CardValue{four}, topValues(mask, 1, four)...)...)
+	case three != 0 && pair != 0:
+		return newHandRank(FullHouseHand, three, pair)
+	case flush != 0:
+		return flush
+	}
+
+	if high := straightHigh(mask); high != 0 {
+		return newHandRank(StraightHand, high)
+	}
+
+	switch {
+	case three != 0:
+		return newHandRank(ThreeHand, append([]CardValue{three}, topValues(mask, 2, three)...)...)
+	case pair != 0 && secondPair != 0:
+		return newHandRank(TwoPairHand, append([]CardValue{pair, secondPair}, topValues(mask, 1, pair, secondPair)...)...)
+	case pair != 0:
+		return newHandRank(PairHand, append([]CardValue{pair}, topValues(mask, 3, pair)...)...)
+	}
+
+	return newHandRank(HighCardHand, topValues(mask, HandSize)...)
+}
+
+func bitsCount(mask uint16) int {
+	n := 0
+
+	for ; mask != 0; mask &= mask - 1 {
+		n++
+	}
+
+	return n
+}
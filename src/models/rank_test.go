@@ -0,0 +1,83 @@
+package models
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRankCards(t *testing.T) {
+	tests := []struct {
+		cards string
+		want  string
+	}{
+		{cards: "Ah Kh Qh Jh Th 2c 3d", want: "RF:A"},
+		{cards: "5d 4d 3d 2d Ad Kc Ks", want: "SF:5"},
+		{cards: "9s 9h 9d 9c Kh Ks 2c", want: "4K:9K"},
+		{cards: "Ks Kh Kd 4c 4h 4s 2c", want: "FH:K4"},
+		{cards: "Ac 9c 7c 4c 2c Kc 3h", want: "FL:AK974"},
+		{cards: "Ah 2d 3c 4s 5h Kd Kc", want: "ST:5"},
+		{cards: "7s 7h 7d Kc 2h 4d 9s", want: "3K:7K9"},
+		{cards: "Js Jh 5d 5c 2h 2d Ac", want: "2P:J5A"},
+		{cards: "Qs Qh 8d 5c 2h", want: "1P:Q852"},
+		{cards: "Ks Jh 8d 5c 2h 3s 4d", want: "HC:KJ854"},
+		// восемь и более карт: флеш не должен перебивать каре и фулл-хаус
+		{cards: "Ah Ad As Ac Kh Qh Jh 9h", want: "4K:AK"},
+		{cards: "Ah Kh Qh Jh 9h Ad As Kd", want: "FH:AK"},
+		{cards: "Ah Kh Qh Jh 9h 8s 7s 6s 5s 4s", want: "SF:8"},
+	}
+
+	for _, tt := range tests {
+		if got := RankCards(MustParse(tt.cards)).Code(); got != tt.want {
+			t.Errorf("RankCards(%s) = %s, want %s", tt.cards, got, tt.want)
+		}
+	}
+}
+
+func TestRankCardsMatchesHandCompare(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	deck := NewDeck()
+
+	for i := 0; i < 2000; i++ {
+		deck.ShuffleWithRandom(r)
+
+		hands := make([][]*Card, 2)
+		for h := range hands {
+			for len(hands[h]) < HandSize+PocketSize {
+				card, err := deck.Card()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				hands[h] = append(hands[h], card)
+			}
+		}
+
+		one, err := GetBestHand(hands[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		other, err := GetBestHand(hands[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := sign(one.Compare(other))
+		got := sign(int(RankCards(hands[0]) - RankCards(hands[1])))
+
+		if got != want {
+			t.Fatalf("%v vs %v: RankCards compares %d, Hand.Compare %d", hands[0], hands[1], got, want)
+		}
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+
+	return 0
+}
@@ -0,0 +1,217 @@
+package models
+
+import "fmt"
+
+// MaxRunouts - максимальное количество вариантов доставки общих карт
+const MaxRunouts = 3
+
+// Runout - часть банка, разыгранная в одном из вариантов доставки недостающих общих карт
+type Runout struct {
+	Board []*Card
+	// Pot - номер части банка в Pot.SidePots: 0 - основной банк
+	Pot     int
+	Winners []string
+	// Share - часть банка, разыгранная в этом варианте
+	Share Chips
+}
+
+// dealRunout доставляет из колоды deck недостающие общие карты к board, сжигая карту перед каждой улицей
+func dealRunout(deck *Deck, board []*Card) ([]*Card, error) {
+	runout := append(make([]*Card, 0, BoardSize), board...)
+
+	for len(runout) < BoardSize {
+		if err := deck.Discard(); err != nil {
+			return nil, err
+		}
+
+		num := 1
+		if len(runout) == 0 {
+			num = CardsOnFlopNumber
+		}
+
+		for i := 0; i < num; i++ {
+			card, err := deck.Card()
+			if err != nil {
+				return nil, err
+			}
+
+			runout = append(runout, card)
+		}
+	}
+
+	return runout, nil
+}
+
+// splitPot делит amount поровну между winners. Нечетные фишки достаются победителям,
+// сидящим ближе к баттону по часовой стрелке
+func (t *Table) splitPot(amount Chips, winners []string) map[string]Chips {
+	payouts := make(map[string]Chips)
+
+	if len(winners) == 0 {
+		return payouts
+	}
+
+	isWinner := make(map[string]struct{})
+	for _, id := range winners {
+		isWinner[id] = struct{}{}
+	}
+
+	ordered := make([]string, 0, len(winners))
+	for _, p := range t.orderFrom(t.Dealer) {
+		if _, ok := isWinner[p.ID]; ok {
+			ordered = append(ordered, p.ID)
+		}
+	}
+
+	// победители, у которых не осталось фишек, не входят в orderFrom
+	for _, id := range winners {
+		if !inStrings(ordered, id) {
+			ordered = append(ordered, id)
+		}
+	}
+
+	share, odd := amount/Chips(len(ordered)), amount%Chips(len(ordered))
+
+	for i, id := range ordered {
+		payouts[id] = share
+
+		if Chips(i) < odd {
+			payouts[id]++
+		}
+	}
+
+	return payouts
+}
+
+func inStrings(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// potRunouts разыгрывает части банка pots на текущих общих картах. Каждая часть разыгрывается
+// в размере share(Amount) между претендующими на нее игроками: в играх хай-лоу она делится пополам между
+// старшими и младшими руками (нечетная фишка - старшим), а если младшей руки нет, достается старшим
+func (t *Table) potRunouts(pots []*SidePot, share func(amount Chips) Chips) ([]*Runout, error) {
+	runouts := make([]*Runout, 0, len(pots))
+
+	for i, pot := range pots {
+		groups, err := t.groupsAmong(pot.Players)
+		if err != nil {
+			return nil, err
+		}
+
+		parts := make([][]string, 0, len(groups))
+
+		for _, winners := range groups {
+			if len(winners) > 0 {
+				parts = append(parts, winners)
+			}
+		}
+
+		amount := share(pot.Amount)
+
+		for j, winners := range parts {
+			r := &Runout{
+				Board:   append(make([]*Card, 0, len(t.Board)), t.Board...),
+				Pot:     i,
+				Winners: winners,
+				Share:   amount / Chips(len(parts)),
+			}
+
+			if j == 0 {
+				r.Share += amount % Chips(len(parts))
+			}
+
+			runouts = append(runouts, r)
+		}
+	}
+
+	return runouts, nil
+}
+
+// RunItTimes доставляет недостающие общие карты times раз из одной и той же колоды. Основной и побочные
+// банки (см. Pot.SidePots) делятся между вариантами поровну (нечетные фишки достаются первому варианту),
+// и в каждом варианте каждую часть банка выигрывают сильнейшие руки среди претендующих на нее игроков.
+// Возвращает по одному Runout на каждую часть банка в каждом варианте. Board после вызова остается прежним.
+func (t *Table) RunItTimes(times int) ([]*Runout, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if times < 1 || times > MaxRunouts {
+		return nil, fmt.Errorf("Wrong runouts number %d, must be from 1 to %d", times, MaxRunouts)
+	}
+
+	base := t.Board
+	defer func() {
+		t.Board = base
+	}()
+
+	pots := t.Pot.SidePots(t.contenders())
+	runouts := make([]*Runout, 0, times*len(pots))
+
+	for i := 0; i < times; i++ {
+		board, err := dealRunout(t.deck, base)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Board = board
+		first := i == 0
+
+		parts, err := t.potRunouts(pots, func(amount Chips) Chips {
+			if first {
+				return amount/Chips(times) + amount%Chips(times)
+			}
+
+			return amount / Chips(times)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		runouts = append(runouts, parts...)
+	}
+
+	return runouts, nil
+}
+
+// SplitRunouts возвращает выигрыши игроков по их ID: каждая часть банка, разыгранная в варианте,
+// делится между ее победителями
+func (t *Table) SplitRunouts(runouts []*Runout) map[string]Chips {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	payouts := make(map[string]Chips)
+
+	for _, r := range runouts {
+		for id, amount := range t.splitPot(r.Share, r.Winners) {
+			payouts[id] += amount
+		}
+	}
+
+	return payouts
+}
+
+// Award зачисляет выигрыши игрокам и обнуляет банк
+func (t *Table) Award(payouts map[string]Chips) error {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	for id, amount := range payouts {
+		player := t.GetPlayerByID(id)
+		if player == nil {
+			return fmt.Errorf("Player with ID %s is not in this game", id)
+		}
+
+		player.AddChips(amount)
+	}
+
+	t.Pot.Reset()
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"hands/src/helpers"
+)
+
+// testSeat - игрок тестового стола: карманные карты, вклад в банк и признак сброса карт
+type testSeat struct {
+	name   string
+	pocket string
+	chips  Chips
+	folded bool
+}
+
+// newTestTable сажает игроков seats за стол варианта variant с общими картами board и возвращает стол
+// и игроков по именам
+func newTestTable(t *testing.T, variant Variant, board string, seats []testSeat) (*Table, map[string]*Player) {
+	t.Helper()
+
+	table := NewTable("test", "t", helpers.NewDefaultIdGenerator(), CasheTableType, len(seats), 10, 5)
+	table.Variant = variant
+	players := make(map[string]*Player, len(seats))
+
+	for _, s := range seats {
+		p := NewPlayer(s.name, helpers.NewDefaultIdGenerator(), 1000)
+		if err := table.Register(p); err != nil {
+			t.Fatal(err)
+		}
+
+		p.resetHand(variant.PocketSize())
+
+		for _, c := range MustParse(s.pocket) {
+			if err := p.AddCard(c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := table.Pot.AddPlayerBet(s.chips, p.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		p.Active = !s.folded
+		players[s.name] = p
+	}
+
+	table.Board = MustParse(board)
+
+	return table, players
+}
+
+// payoutsByName возвращает выигрыши по именам игроков
+func payoutsByName(payouts map[string]Chips, players map[string]*Player) map[string]Chips {
+	byName := make(map[string]Chips, len(payouts))
+
+	for name, p := range players {
+		if amount := payouts[p.ID]; amount != 0 {
+			byName[name] = amount
+		}
+	}
+
+	return byName
+}
+
+func TestRunItTimesSidePots(t *testing.T) {
+	seats := []testSeat{
+		{name: "short", pocket: "As Ad", chips: 50},
+		{name: "kings", pocket: "Ks Kc", chips: 200},
+		{name: "queens", pocket: "Qs Qc", chips: 200},
+		{name: "folded", pocket: "9s 9c", chips: 100, folded: true},
+	}
+
+	tests := []struct {
+		name  string
+		board string
+		// deck - карты в порядке сдачи, включая сожженные
+		deck  string
+		times int
+		want  map[string]Chips
+	}{
+		{
+			name:  "short stack wins only the main pot",
+			board: "Ah Kd 7c 2s 3d",
+			times: 1,
+			want:  map[string]Chips{"short": 200, "kings": 350},
+		},
+		{
+			name:  "each runout splits each pot",
+			board: "Ah Kd 7c",
+			// первый вариант: 2s 3d, второй: Qd Qh
+			deck:  "5c 2s 6c 3d 8c Qd 9c Qh",
+			times: 2,
+			want:  map[string]Chips{"short": 100, "kings": 175, "queens": 275},
+		},
+		{
+			name:  "odd chips go to the first runout",
+			board: "Ah Kd 7c",
+			deck:  "5c 2s 6c 3d 8c Qd 9c Qh 4c 4h Th 5h",
+			times: 3,
+			// основной банк 200: 68 + 66 + 66, побочный 350: 118 + 116 + 116
+			want: map[string]Chips{"short": 68 + 66, "kings": 118 + 116, "queens": 66 + 116},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newTestTable(t, HoldemVariant, tt.board, seats)
+
+			deck := MustParse(tt.deck)
+			for i, j := 0, len(deck)-1; i < j; i, j = i+1, j-1 {
+				deck[i], deck[j] = deck[j], deck[i]
+			}
+
+			table.deck = newDeckFromCards(deck)
+
+			runouts, err := table.RunItTimes(tt.times)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := payoutsByName(table.SplitRunouts(runouts), players)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("payouts = %v, want %v", got, tt.want)
+			}
+
+			if len(table.Board) != len(MustParse(tt.board)) {
+				t.Errorf("board changed to %v", table.Board)
+			}
+		})
+	}
+}
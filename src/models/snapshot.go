@@ -0,0 +1,308 @@
+package models
+
+import (
+	"sort"
+	"sync"
+)
+
+// PlayerSnapshot - состояние игрока, пригодное для сохранения и восстановления
+type PlayerSnapshot struct {
+	ID          string
+	Name        string
+	Active      bool
+	Seat        int
+	Chips       Chips
+	PocketCards []*Card
+	PocketSize  int
+	SittingOut  bool
+	DealtIn     bool
+}
+
+// TableSnapshot - полное состояние стола, включая порядок карт в колоде,
+// позволяющее восстановить стол посреди раздачи
+type TableSnapshot struct {
+	ID             string
+	Type           TableType
+	Rules          TableRules
+	Name           string
+	Pot            Pot
+	Players        []PlayerSnapshot
+	MaxPlayersNum  int
+	BigBlind       Chips
+	SmallBlind     Chips
+	Board          []*Card
+	Dealer         int
+	SmallBlindSeat int
+	BigBlindSeat   int
+	CurrentMove    int
+	CurrentBet     Chips
+	Deck           []*Card
+	// Muck - сброшенные карты колоды, которые перетасовываются в колоду, когда она закончится
+	Muck             []*Card
+	IsDealerInactive bool
+	RoundBets        map[string]Chips
+	Newcomers        []string
+	StartingStacks   map[string]Chips
+	Street           Street
+	HandPositions    map[string]Position
+	Actions          []Action
+	Acted            map[string]bool
+	MinRaise         Chips
+	Variant          string
+	Rotation         []string
+	RotationIndex    int
+	OrbitHands       int
+	UpCards          map[string][]*Card
+	Bets             int
+	Drawing          bool
+	Drawn            []string
+	DiscardNum       int
+	PendingBoard     int
+}
+
+func copyChipsMap(m map[string]Chips) map[string]Chips {
+	c := make(map[string]Chips, len(m))
+
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
+// Snapshot возвращает снимок состояния игрока
+func (p *Player) Snapshot() PlayerSnapshot {
+	p.RLock()
+	defer p.RUnlock()
+
+	return PlayerSnapshot{
+		ID:          p.ID,
+		Name:        p.Name,
+		Active:      p.Active,
+		Seat:        p.Seat,
+		Chips:       p.currentChipsAmount,
+		PocketCards: append(make([]*Card, 0, len(p.pocketCards)), p.pocketCards...),
+		PocketSize:  p.pocketSize,
+		SittingOut:  p.sittingOut,
+		DealtIn:     p.dealtIn,
+	}
+}
+
+// RestorePlayer восстанавливает игрока из снимка
+func RestorePlayer(s PlayerSnapshot) *Player {
+	p := &Player{}
+	p.restore(s)
+
+	return p
+}
+
+// restore заменяет состояние игрока p состоянием из снимка
+func (p *Player) restore(s PlayerSnapshot) {
+	*p = Player{
+		ID:                 s.ID,
+		Name:               s.Name,
+		Active:             s.Active,
+		Seat:               s.Seat,
+		currentChipsAmount: s.Chips,
+		pocketCards:        append(make([]*Card, 0, len(s.PocketCards)), s.PocketCards...),
+		pocketSize:         s.PocketSize,
+		sittingOut:         s.SittingOut,
+		dealtIn:            s.DealtIn,
+		RWMutex:            sync.RWMutex{},
+	}
+}
+
+// Snapshot возвращает снимок состояния стола
+func (t *Table) Snapshot() *TableSnapshot {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	s := &TableSnapshot{
+		ID:    t.ID,
+		Type:  t.Type,
+		Rules: *t.rules(),
+		Name:  t.Name,
+		Pot: Pot{
+			Tag:           t.Pot.Tag,
+			TotalChipsNum: t.Pot.TotalChipsNum,
+			PlayersChips:  copyChipsMap(t.Pot.PlayersChips),
+		},
+		Players:          make([]PlayerSnapshot, len(t.Players)),
+		MaxPlayersNum:    t.MaxPlayersNum,
+		BigBlind:         t.BigBlind,
+		SmallBlind:       t.SmallBlind,
+		Board:            append(make([]*Card, 0, len(t.Board)), t.Board...),
+		Dealer:           t.Dealer,
+		SmallBlindSeat:   t.SmallBlindSeat,
+		BigBlindSeat:     t.BigBlindSeat,
+		CurrentMove:      t.CurrentMove,
+		CurrentBet:       t.CurrentBet,
+		Deck:             t.deck.Remaining(),
+		Muck:             t.deck.Mucked(),
+		IsDealerInactive: t.isDealerInactive,
+		RoundBets:        copyChipsMap(t.roundBets),
+		Newcomers:        make([]string, 0, len(t.newcomers)),
+		StartingStacks:   copyChipsMap(t.startingStacks),
+		Street:           t.Street,
+		HandPositions:    make(map[string]Position),
+		Actions:          append(make([]Action, 0, len(t.actions)), t.actions...),
+		Acted:            make(map[string]bool),
+		MinRaise:         t.minRaise,
+		Variant:          t.variant().Name(),
+		RotationIndex:    t.rotationIndex,
+		OrbitHands:       t.orbitHands,
+		UpCards:          make(map[string][]*Card),
+		Bets:             t.bets,
+		Drawing:          t.drawing,
+		DiscardNum:       t.discardNum,
+		PendingBoard:     t.pendingBoard,
+	}
+
+	for _, v := range t.rotation {
+		s.Rotation = append(s.Rotation, v.Name())
+	}
+
+	for id, cards := range t.upCards {
+		s.UpCards[id] = append(make([]*Card, 0, len(cards)), cards...)
+	}
+
+	for id := range t.drawn {
+		s.Drawn = append(s.Drawn, id)
+	}
+
+	for i, p := range t.Players {
+		s.Players[i] = p.Snapshot()
+	}
+
+	for id, position := range t.handPositions {
+		s.HandPositions[id] = position
+	}
+
+	for id, acted := range t.acted {
+		s.Acted[id] = acted
+	}
+
+	for id := range t.newcomers {
+		s.Newcomers = append(s.Newcomers, id)
+	}
+
+	// drawn и newcomers хранятся в map - сортируем, чтобы снимок не зависел от порядка обхода
+	sort.Strings(s.Drawn)
+	sort.Strings(s.Newcomers)
+
+	return s
+}
+
+// RestoreTable восстанавливает стол из снимка. Подписчики на события стола не сохраняются.
+// Возвращает ошибку, если вариант игры или ротации в снимке неизвестен
+func RestoreTable(s *TableSnapshot) (*Table, error) {
+	t := &Table{}
+	if err := t.restore(s); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// restore заменяет состояние стола t состоянием из снимка. Пустое название варианта означает холдем
+func (t *Table) restore(s *TableSnapshot) error {
+	variant, err := snapshotVariant(s.Variant)
+	if err != nil {
+		return err
+	}
+
+	rotation := make([]Variant, 0, len(s.Rotation))
+
+	for _, name := range s.Rotation {
+		v, err := VariantByName(name)
+		if err != nil {
+			return err
+		}
+
+		rotation = append(rotation, v)
+	}
+
+	rules := s.Rules
+
+	*t = Table{
+		ID:         s.ID,
+		Type:       s.Type,
+		TableRules: &rules,
+		Name:       s.Name,
+		Pot: &Pot{
+			Tag:           s.Pot.Tag,
+			TotalChipsNum: s.Pot.TotalChipsNum,
+			PlayersChips:  copyChipsMap(s.Pot.PlayersChips),
+		},
+		Players:           make([]*Player, len(s.Players)),
+		MaxPlayersNum:     s.MaxPlayersNum,
+		CurrentPlayersNum: len(s.Players),
+		BigBlind:          s.BigBlind,
+		SmallBlind:        s.SmallBlind,
+		Board:             append(make([]*Card, 0, len(s.Board)), s.Board...),
+		Dealer:            s.Dealer,
+		SmallBlindSeat:    s.SmallBlindSeat,
+		BigBlindSeat:      s.BigBlindSeat,
+		CurrentMove:       s.CurrentMove,
+		CurrentBet:        s.CurrentBet,
+		deck:              newDeckFromCards(s.Deck),
+		Variant:           variant,
+		rotation:          rotation,
+		m:                 sync.RWMutex{},
+		isDealerInactive:  s.IsDealerInactive,
+		roundBets:         copyChipsMap(s.RoundBets),
+		newcomers:         make(map[string]struct{}),
+		startingStacks:    copyChipsMap(s.StartingStacks),
+		Street:            s.Street,
+		handPositions:     make(map[string]Position),
+		actions:           append(make([]Action, 0, len(s.Actions)), s.Actions...),
+		acted:             make(map[string]bool),
+		minRaise:          s.MinRaise,
+		upCards:           make(map[string][]*Card),
+		bets:              s.Bets,
+		drawing:           s.Drawing,
+		drawn:             make(map[string]bool),
+		discardNum:        s.DiscardNum,
+		pendingBoard:      s.PendingBoard,
+		rotationIndex:     s.RotationIndex,
+		orbitHands:        s.OrbitHands,
+	}
+
+	for id, position := range s.HandPositions {
+		t.handPositions[id] = position
+	}
+
+	for id, acted := range s.Acted {
+		t.acted[id] = acted
+	}
+
+	for i, p := range s.Players {
+		t.Players[i] = RestorePlayer(p)
+	}
+
+	for _, id := range s.Newcomers {
+		t.newcomers[id] = struct{}{}
+	}
+
+	for id, cards := range s.UpCards {
+		t.upCards[id] = append(make([]*Card, 0, len(cards)), cards...)
+	}
+
+	for _, id := range s.Drawn {
+		t.drawn[id] = true
+	}
+
+	t.deck.Muck(s.Muck...)
+	t.buildDealFuncs()
+
+	return nil
+}
+
+// snapshotVariant возвращает вариант игры по названию из снимка, или nil для пустого названия
+func snapshotVariant(name string) (Variant, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	return VariantByName(name)
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRestoreDrawTableMidHand(t *testing.T) {
+	table, players := newTestTable(t, TripleDrawVariant, "", []testSeat{
+		{name: "first", pocket: "2s 3s 4s 5s 7d"},
+		{name: "second", pocket: "8c 9c Kd Qd Jd"},
+	})
+
+	// в колоде две карты, в сбросе - карты прошлого обмена
+	table.deck = newDeckFromCards(MustParse("Tc Jc"))
+	table.deck.Muck(MustParse("Qh Kh")...)
+	table.drawing = true
+	table.CurrentMove = table.playerIndex(players["first"].ID)
+
+	if _, err := table.Draw(players["first"].ID, MustParse("7d")); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := RestoreTable(table.Snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(restored.Snapshot(), table.Snapshot()) {
+		t.Fatal("restored table differs from the original")
+	}
+
+	// второму игроку не хватает карт колоды, и обе таблицы перетасовывают одинаковый сброс
+	for _, tbl := range []*Table{table, restored} {
+		tbl.WithRandom(rand.New(rand.NewSource(1)))
+
+		if _, err := tbl.Draw(players["second"].ID, MustParse("8c 9c Kd")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !reflect.DeepEqual(restored.Snapshot(), table.Snapshot()) {
+		t.Error("restored table diverged after the reshuffle")
+	}
+}
+
+func TestRestoreUnknownVariant(t *testing.T) {
+	table := newPositionsTable(t, 3)
+
+	tests := []struct {
+		name   string
+		change func(s *TableSnapshot)
+	}{
+		{name: "variant", change: func(s *TableSnapshot) { s.Variant = "NLHEE" }},
+		{name: "rotation", change: func(s *TableSnapshot) { s.Rotation = []string{"Razz", "Studd"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := table.Snapshot()
+			tt.change(s)
+
+			if _, err := RestoreTable(s); err == nil {
+				t.Error("RestoreTable() accepted an unknown variant")
+			}
+		})
+	}
+
+	s := table.Snapshot()
+	s.Variant = ""
+
+	restored, err := RestoreTable(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if restored.variant() != HoldemVariant {
+		t.Errorf("empty variant restored as %s", restored.variant().Name())
+	}
+}
@@ -0,0 +1,231 @@
+package models
+
+//go:generate go run ../../cmd/startinghands -out starting_hands_equity.go
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// StartingHandsNum - количество стартовых рук без учета мастей: 13 пар, 78 одномастных и 78 разномастных
+	StartingHandsNum = 169
+	// MaxOpponents - максимальное количество соперников в таблицах силы стартовых рук
+	MaxOpponents = 9
+	// SklanskyUnranked - группа Склански для рук, не вошедших в восемь групп
+	SklanskyUnranked = 9
+)
+
+// startingHandValues - обозначения значений карт в названиях стартовых рук, от двойки до туза
+const startingHandValues = "23456789TJQKA"
+
+// StartingHand - стартовая рука без учета мастей: пара, одномастные или разномастные карты.
+// High - старшая карта, Low - младшая
+type StartingHand struct {
+	High   CardValue
+	Low    CardValue
+	Suited bool
+}
+
+// NewStartingHand возвращает стартовую руку, к которой относятся карманные карты pocket
+func NewStartingHand(pocket []*Card) (StartingHand, error) {
+	if len(pocket) != PocketSize {
+		return StartingHand{}, fmt.Errorf("Starting hand requires %d pocket cards, got %d", PocketSize, len(pocket))
+	}
+
+	high, low := pocket[0], pocket[1]
+	if low.Value > high.Value {
+		high, low = low, high
+	}
+
+	return StartingHand{
+		High:   high.Value,
+		Low:    low.Value,
+		Suited: high.Value != low.Value && high.CompareSuites(low),
+	}, nil
+}
+
+// ParseStartingHand разбирает название стартовой руки: "AA", "AKs", "T9o"
+func ParseStartingHand(s string) (StartingHand, error) {
+	if len(s) < 2 || len(s) > 3 {
+		return StartingHand{}, fmt.Errorf("Wrong starting hand %s", s)
+	}
+
+	high := strings.IndexByte(startingHandValues, s[0])
+	low := strings.IndexByte(startingHandValues, s[1])
+	kind := s[2:]
+
+	switch {
+	case high < 0 || low < 0 || low > high:
+		return StartingHand{}, fmt.Errorf("Wrong starting hand %s", s)
+	case high == low && kind != "":
+		return StartingHand{}, fmt.Errorf("Wrong starting hand %s", s)
+	case high != low && kind != "s" && kind != "o":
+		return StartingHand{}, fmt.Errorf("Wrong starting hand %s", s)
+	}
+
+	return StartingHand{
+		High:   NewCardValue(high),
+		Low:    NewCardValue(low),
+		Suited: kind == "s",
+	}, nil
+}
+
+// StartingHands возвращает все 169 стартовых рук: от старших к младшим, одномастные перед разномастными
+func StartingHands() []StartingHand {
+	hands := make([]StartingHand, 0, StartingHandsNum)
+
+	for high := Ace; high >= Two; high-- {
+		for low := high; low >= Two; low-- {
+			if high == low {
+				hands = append(hands, StartingHand{High: high, Low: low})
+
+				continue
+			}
+
+			hands = append(hands,
+				StartingHand{High: high, Low: low, Suited: true},
+				StartingHand{High: high, Low: low},
+			)
+		}
+	}
+
+	return hands
+}
+
+func (h StartingHand) String() string {
+	s := string([]byte{startingHandValues[h.High-Two], startingHandValues[h.Low-Two]})
+
+	switch {
+	case h.IsPair():
+		return s
+	case h.Suited:
+		return s + "s"
+	}
+
+	return s + "o"
+}
+
+func (h StartingHand) IsPair() bool {
+	return h.High == h.Low
+}
+
+// Combos возвращает все сочетания карманных карт стартовой руки (6 для пары, 4 для одномастных,
+// 12 для разномастных), не содержащие карт dead
+func (h StartingHand) Combos(dead ...*Card) [][]*Card {
+	combos := make([][]*Card, 0)
+	deadSet := NewCardSet(dead...)
+
+	for i, first := range Suites {
+		for j, second := range Suites {
+			switch {
+			case h.IsPair() && j <= i:
+				continue
+			case !h.IsPair() && h.Suited != (first == second):
+				continue
+			}
+
+			pocket := []*Card{newCard(h.High, first), newCard(h.Low, second)}
+
+			if !deadSet.Contains(pocket[0]) && !deadSet.Contains(pocket[1]) {
+				combos = append(combos, pocket)
+			}
+		}
+	}
+
+	return combos
+}
+
+// Pocket возвращает одно из сочетаний карманных карт стартовой руки
+func (h StartingHand) Pocket() []*Card {
+	return h.Combos()[0]
+}
+
+// newCard возвращает новую карту значения value масти suite, в том числе с неизвестными значением или мастью
+func newCard(value CardValue, suite Suite) *Card {
+	return &Card{
+		Value: value,
+		Suite: &CardSuite{
+			Color: suite.Color(),
+			Suite: suite,
+		},
+	}
+}
+
+// sklanskyGroups - группы стартовых рук Склански-Малмута, от сильнейших (1) к слабейшим (8)
+var sklanskyGroups = [][]string{
+	{"AA", "KK", "QQ", "JJ", "AKs"},
+	{"TT", "AQs", "AJs", "KQs", "AKo"},
+	{"99", "ATs", "KJs", "QJs", "JTs", "AQo"},
+	{"88", "KTs", "QTs", "J9s", "T9s", "98s", "AJo", "KQo"},
+	{"77", "A9s", "A8s", "A7s", "A6s", "A5s", "A4s", "A3s", "A2s", "Q9s", "T8s", "97s", "87s", "76s", "KJo", "QJo", "JTo"},
+	{"66", "55", "K9s", "J8s", "86s", "75s", "54s", "ATo", "KTo", "QTo"},
+	{"44", "33", "22", "K8s", "K7s", "K6s", "K5s", "K4s", "K3s", "K2s", "Q8s", "T7s", "64s", "53s", "43s", "J9o", "T9o", "98o"},
+	{"87o", "A9o", "Q9o", "76o", "42s", "32s", "96s", "85s", "J8o", "J7s", "65o", "54o", "74s", "K9o", "T8o"},
+}
+
+// SklanskyGroup возвращает группу Склански-Малмута стартовой руки (от 1 до 8), или SklanskyUnranked
+func (h StartingHand) SklanskyGroup() int {
+	name := h.String()
+
+	for i, group := range sklanskyGroups {
+		for _, hand := range group {
+			if hand == name {
+				return i + 1
+			}
+		}
+	}
+
+	return SklanskyUnranked
+}
+
+// ChenScore оценивает стартовую руку по формуле Чена
+func (h StartingHand) ChenScore() int {
+	score, _ := ChenScore(h.Pocket())
+
+	return score
+}
+
+// Equity возвращает эквити стартовой руки в олл-ине против opponents случайных рук (от 1 до MaxOpponents)
+// по предрассчитанной таблице
+func (h StartingHand) Equity(opponents int) (float64, error) {
+	if opponents < 1 || opponents > MaxOpponents {
+		return 0, fmt.Errorf("Starting hand equity is available for 1..%d opponents, got %d", MaxOpponents, opponents)
+	}
+
+	equity, ok := startingHandsEquity[h.String()]
+	if !ok {
+		return 0, fmt.Errorf("No equity for starting hand %s", h)
+	}
+
+	return equity[opponents-1], nil
+}
+
+// HandStrength - сила стартовой руки
+type HandStrength struct {
+	Hand StartingHand
+	// Equity - эквити в олл-ине против заданного количества случайных рук
+	Equity        float64
+	ChenScore     int
+	SklanskyGroup int
+}
+
+// StartingHandStrength возвращает силу карманных карт pocket против opponents случайных рук
+func StartingHandStrength(pocket []*Card, opponents int) (*HandStrength, error) {
+	h, err := NewStartingHand(pocket)
+	if err != nil {
+		return nil, err
+	}
+
+	equity, err := h.Equity(opponents)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HandStrength{
+		Hand:          h,
+		Equity:        equity,
+		ChenScore:     h.ChenScore(),
+		SklanskyGroup: h.SklanskyGroup(),
+	}, nil
+}
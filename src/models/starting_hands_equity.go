@@ -0,0 +1,176 @@
+// Code generated by cmd/startinghands; DO NOT EDIT.
+
+package models
+
+// startingHandsEquity - эквити стартовых рук в олл-ине против 1..9 случайных рук, 20000 раздач
+var startingHandsEquity = map[string][MaxOpponents]float64{
+	"AA":  {0.8509, 0.7339, 0.6436, 0.5605, 0.4917, 0.4344, 0.3910, 0.3402, 0.3075},
+	"AKs": {0.6685, 0.5086, 0.4083, 0.3522, 0.3064, 0.2804, 0.2452, 0.2252, 0.2075},
+	"AKo": {0.6536, 0.4891, 0.3859, 0.3210, 0.2852, 0.2403, 0.2089, 0.1921, 0.1725},
+	"AQs": {0.6583, 0.4920, 0.4005, 0.3346, 0.2931, 0.2565, 0.2370, 0.2081, 0.1923},
+	"AQo": {0.6483, 0.4722, 0.3689, 0.3060, 0.2580, 0.2249, 0.1954, 0.1763, 0.1550},
+	"AJs": {0.6512, 0.4749, 0.3864, 0.3250, 0.2807, 0.2512, 0.2188, 0.1989, 0.1839},
+	"AJo": {0.6375, 0.4586, 0.3496, 0.2849, 0.2487, 0.2109, 0.1858, 0.1626, 0.1394},
+	"ATs": {0.6436, 0.4709, 0.3717, 0.3116, 0.2618, 0.2357, 0.2053, 0.1910, 0.1690},
+	"ATo": {0.6268, 0.4424, 0.3441, 0.2754, 0.2375, 0.1963, 0.1714, 0.1531, 0.1351},
+	"A9s": {0.6283, 0.4459, 0.3456, 0.2882, 0.2431, 0.2074, 0.1857, 0.1671, 0.1562},
+	"A9o": {0.6142, 0.4211, 0.3062, 0.2436, 0.2019, 0.1727, 0.1461, 0.1245, 0.1161},
+	"A8s": {0.6193, 0.4387, 0.3334, 0.2785, 0.2387, 0.2005, 0.1792, 0.1673, 0.1491},
+	"A8o": {0.5966, 0.4027, 0.3013, 0.2329, 0.1915, 0.1605, 0.1398, 0.1203, 0.1047},
+	"A7s": {0.6053, 0.4275, 0.3221, 0.2678, 0.2292, 0.1962, 0.1719, 0.1623, 0.1407},
+	"A7o": {0.5888, 0.3933, 0.2857, 0.2227, 0.1862, 0.1520, 0.1258, 0.1144, 0.1000},
+	"A6s": {0.5983, 0.4121, 0.3133, 0.2551, 0.2161, 0.1890, 0.1680, 0.1487, 0.1379},
+	"A6o": {0.5763, 0.3794, 0.2684, 0.2100, 0.1745, 0.1441, 0.1231, 0.1060, 0.0979},
+	"A5s": {0.5998, 0.4173, 0.3194, 0.2620, 0.2211, 0.1940, 0.1714, 0.1574, 0.1448},
+	"A5o": {0.5801, 0.3850, 0.2828, 0.2215, 0.1788, 0.1533, 0.1336, 0.1156, 0.1024},
+	"A4s": {0.5960, 0.4106, 0.3064, 0.2516, 0.2149, 0.1908, 0.1723, 0.1548, 0.1440},
+	"A4o": {0.5675, 0.3700, 0.2716, 0.2106, 0.1694, 0.1467, 0.1263, 0.1110, 0.0993},
+	"A3s": {0.5847, 0.3932, 0.3024, 0.2478, 0.2113, 0.1820, 0.1672, 0.1486, 0.1422},
+	"A3o": {0.5579, 0.3567, 0.2636, 0.2043, 0.1679, 0.1412, 0.1211, 0.1066, 0.0942},
+	"A2s": {0.5764, 0.3869, 0.2980, 0.2447, 0.2037, 0.1811, 0.1658, 0.1455, 0.1308},
+	"A2o": {0.5545, 0.3519, 0.2602, 0.1999, 0.1646, 0.1382, 0.1194, 0.1027, 0.0939},
+	"KK":  {0.8247, 0.6916, 0.5841, 0.5042, 0.4329, 0.3686, 0.3252, 0.2904, 0.2602},
+	"KQs": {0.6387, 0.4643, 0.3823, 0.3261, 0.2868, 0.2490, 0.2255, 0.2008, 0.1824},
+	"KQo": {0.6131, 0.4485, 0.3491, 0.2898, 0.2495, 0.2197, 0.1907, 0.1620, 0.1504},
+	"KJs": {0.6271, 0.4560, 0.3684, 0.3042, 0.2712, 0.2401, 0.2150, 0.1930, 0.1724},
+	"KJo": {0.6029, 0.4277, 0.3367, 0.2753, 0.2331, 0.2030, 0.1753, 0.1572, 0.1379},
+	"KTs": {0.6156, 0.4474, 0.3601, 0.2975, 0.2604, 0.2223, 0.2072, 0.1857, 0.1703},
+	"KTo": {0.6043, 0.4227, 0.3250, 0.2664, 0.2221, 0.1916, 0.1637, 0.1502, 0.1314},
+	"K9s": {0.6038, 0.4238, 0.3293, 0.2712, 0.2324, 0.2011, 0.1825, 0.1667, 0.1483},
+	"K9o": {0.5792, 0.3978, 0.2880, 0.2316, 0.1988, 0.1631, 0.1402, 0.1215, 0.1101},
+	"K8s": {0.5841, 0.4006, 0.3097, 0.2536, 0.2122, 0.1857, 0.1684, 0.1511, 0.1386},
+	"K8o": {0.5590, 0.3663, 0.2717, 0.2130, 0.1699, 0.1436, 0.1296, 0.1070, 0.0941},
+	"K7s": {0.5755, 0.3937, 0.2971, 0.2415, 0.2103, 0.1842, 0.1566, 0.1459, 0.1305},
+	"K7o": {0.5505, 0.3579, 0.2598, 0.2031, 0.1597, 0.1398, 0.1221, 0.1034, 0.0878},
+	"K6s": {0.5686, 0.3845, 0.2960, 0.2353, 0.1965, 0.1699, 0.1551, 0.1373, 0.1219},
+	"K6o": {0.5456, 0.3423, 0.2440, 0.2013, 0.1580, 0.1381, 0.1111, 0.0967, 0.0841},
+	"K5s": {0.5612, 0.3721, 0.2857, 0.2355, 0.1966, 0.1685, 0.1503, 0.1381, 0.1294},
+	"K5o": {0.5294, 0.3431, 0.2412, 0.1855, 0.1517, 0.1239, 0.1117, 0.0926, 0.0844},
+	"K4s": {0.5467, 0.3631, 0.2766, 0.2296, 0.1907, 0.1718, 0.1474, 0.1310, 0.1249},
+	"K4o": {0.5257, 0.3310, 0.2339, 0.1834, 0.1489, 0.1218, 0.1044, 0.0918, 0.0806},
+	"K3s": {0.5415, 0.3542, 0.2723, 0.2175, 0.1838, 0.1667, 0.1471, 0.1325, 0.1255},
+	"K3o": {0.5109, 0.3223, 0.2311, 0.1763, 0.1470, 0.1178, 0.1022, 0.0881, 0.0742},
+	"K2s": {0.5308, 0.3472, 0.2623, 0.2098, 0.1829, 0.1581, 0.1380, 0.1322, 0.1229},
+	"K2o": {0.5077, 0.3108, 0.2271, 0.1715, 0.1406, 0.1164, 0.0996, 0.0878, 0.0751},
+	"QQ":  {0.8035, 0.6492, 0.5395, 0.4479, 0.3849, 0.3220, 0.2831, 0.2499, 0.2211},
+	"QJs": {0.6031, 0.4425, 0.3538, 0.3102, 0.2598, 0.2336, 0.2088, 0.1857, 0.1724},
+	"QJo": {0.5848, 0.4082, 0.3280, 0.2713, 0.2246, 0.1975, 0.1715, 0.1526, 0.1413},
+	"QTs": {0.5926, 0.4385, 0.3455, 0.2946, 0.2528, 0.2247, 0.2015, 0.1791, 0.1662},
+	"QTo": {0.5736, 0.4093, 0.3067, 0.2626, 0.2135, 0.1864, 0.1620, 0.1442, 0.1252},
+	"Q9s": {0.5825, 0.4121, 0.3191, 0.2665, 0.2307, 0.1982, 0.1747, 0.1600, 0.1455},
+	"Q9o": {0.5512, 0.3744, 0.2796, 0.2269, 0.1901, 0.1592, 0.1366, 0.1199, 0.1058},
+	"Q8s": {0.5588, 0.3866, 0.3030, 0.2468, 0.2095, 0.1802, 0.1608, 0.1452, 0.1320},
+	"Q8o": {0.5363, 0.3520, 0.2592, 0.2116, 0.1710, 0.1393, 0.1227, 0.1084, 0.0938},
+	"Q7s": {0.5400, 0.3662, 0.2762, 0.2264, 0.1953, 0.1676, 0.1467, 0.1335, 0.1208},
+	"Q7o": {0.5175, 0.3272, 0.2419, 0.1803, 0.1476, 0.1239, 0.1066, 0.0898, 0.0823},
+	"Q6s": {0.5381, 0.3584, 0.2675, 0.2135, 0.1876, 0.1639, 0.1458, 0.1303, 0.1199},
+	"Q6o": {0.5090, 0.3187, 0.2323, 0.1806, 0.1433, 0.1193, 0.1005, 0.0874, 0.0761},
+	"Q5s": {0.5280, 0.3548, 0.2606, 0.2157, 0.1796, 0.1536, 0.1378, 0.1211, 0.1124},
+	"Q5o": {0.5045, 0.3236, 0.2187, 0.1715, 0.1401, 0.1139, 0.0944, 0.0860, 0.0768},
+	"Q4s": {0.5248, 0.3400, 0.2535, 0.2047, 0.1703, 0.1499, 0.1355, 0.1191, 0.1147},
+	"Q4o": {0.4857, 0.3054, 0.2170, 0.1662, 0.1358, 0.1104, 0.0977, 0.0852, 0.0697},
+	"Q3s": {0.5121, 0.3281, 0.2478, 0.2025, 0.1725, 0.1502, 0.1360, 0.1235, 0.1061},
+	"Q3o": {0.4829, 0.2996, 0.2054, 0.1642, 0.1288, 0.1085, 0.0907, 0.0791, 0.0668},
+	"Q2s": {0.5042, 0.3268, 0.2424, 0.1952, 0.1667, 0.1481, 0.1295, 0.1171, 0.1103},
+	"Q2o": {0.4703, 0.2916, 0.2006, 0.1541, 0.1234, 0.1060, 0.0880, 0.0760, 0.0689},
+	"JJ":  {0.7762, 0.6100, 0.5000, 0.4105, 0.3377, 0.2871, 0.2444, 0.2169, 0.1909},
+	"JTs": {0.5806, 0.4253, 0.3403, 0.2835, 0.2488, 0.2210, 0.2009, 0.1814, 0.1629},
+	"JTo": {0.5574, 0.3970, 0.3038, 0.2579, 0.2170, 0.1873, 0.1696, 0.1440, 0.1276},
+	"J9s": {0.5514, 0.3988, 0.3159, 0.2635, 0.2179, 0.2035, 0.1741, 0.1564, 0.1472},
+	"J9o": {0.5278, 0.3633, 0.2820, 0.2259, 0.1843, 0.1584, 0.1400, 0.1246, 0.1062},
+	"J8s": {0.5427, 0.3726, 0.2941, 0.2371, 0.2073, 0.1801, 0.1579, 0.1444, 0.1354},
+	"J8o": {0.5151, 0.3367, 0.2557, 0.2058, 0.1698, 0.1396, 0.1248, 0.1037, 0.0958},
+	"J7s": {0.5268, 0.3567, 0.2705, 0.2218, 0.1917, 0.1653, 0.1467, 0.1320, 0.1178},
+	"J7o": {0.5020, 0.3215, 0.2370, 0.1821, 0.1470, 0.1217, 0.1069, 0.0942, 0.0821},
+	"J6s": {0.5011, 0.3357, 0.2548, 0.2039, 0.1677, 0.1517, 0.1341, 0.1192, 0.1133},
+	"J6o": {0.4833, 0.3017, 0.2088, 0.1615, 0.1299, 0.1108, 0.0907, 0.0801, 0.0699},
+	"J5s": {0.5007, 0.3256, 0.2481, 0.1994, 0.1697, 0.1481, 0.1259, 0.1154, 0.1067},
+	"J5o": {0.4728, 0.2861, 0.2027, 0.1598, 0.1264, 0.1057, 0.0896, 0.0745, 0.0686},
+	"J4s": {0.4911, 0.3224, 0.2413, 0.1938, 0.1622, 0.1400, 0.1277, 0.1110, 0.1077},
+	"J4o": {0.4618, 0.2794, 0.1978, 0.1504, 0.1225, 0.0999, 0.0905, 0.0761, 0.0674},
+	"J3s": {0.4841, 0.3151, 0.2356, 0.1878, 0.1573, 0.1404, 0.1287, 0.1155, 0.1036},
+	"J3o": {0.4548, 0.2717, 0.1927, 0.1496, 0.1147, 0.1008, 0.0824, 0.0692, 0.0645},
+	"J2s": {0.4685, 0.3020, 0.2268, 0.1814, 0.1592, 0.1364, 0.1252, 0.1121, 0.1073},
+	"J2o": {0.4350, 0.2618, 0.1870, 0.1408, 0.1164, 0.0934, 0.0845, 0.0696, 0.0633},
+	"TT":  {0.7493, 0.5772, 0.4497, 0.3567, 0.2993, 0.2513, 0.2174, 0.1963, 0.1714},
+	"T9s": {0.5417, 0.3852, 0.3161, 0.2603, 0.2164, 0.1946, 0.1761, 0.1589, 0.1526},
+	"T9o": {0.5183, 0.3607, 0.2793, 0.2227, 0.1887, 0.1618, 0.1370, 0.1259, 0.1131},
+	"T8s": {0.5211, 0.3676, 0.2895, 0.2432, 0.2046, 0.1859, 0.1617, 0.1474, 0.1365},
+	"T8o": {0.5000, 0.3285, 0.2503, 0.2042, 0.1665, 0.1438, 0.1234, 0.1081, 0.1001},
+	"T7s": {0.5068, 0.3447, 0.2665, 0.2248, 0.1878, 0.1696, 0.1478, 0.1299, 0.1235},
+	"T7o": {0.4798, 0.3132, 0.2315, 0.1878, 0.1521, 0.1288, 0.1080, 0.0950, 0.0862},
+	"T6s": {0.4884, 0.3239, 0.2471, 0.1990, 0.1756, 0.1495, 0.1359, 0.1262, 0.1097},
+	"T6o": {0.4613, 0.2846, 0.2091, 0.1675, 0.1308, 0.1162, 0.0928, 0.0822, 0.0754},
+	"T5s": {0.4714, 0.3086, 0.2327, 0.1894, 0.1609, 0.1363, 0.1195, 0.1099, 0.0998},
+	"T5o": {0.4371, 0.2702, 0.1911, 0.1463, 0.1224, 0.0995, 0.0821, 0.0730, 0.0627},
+	"T4s": {0.4664, 0.3040, 0.2227, 0.1880, 0.1574, 0.1388, 0.1203, 0.1081, 0.1027},
+	"T4o": {0.4344, 0.2719, 0.1839, 0.1444, 0.1114, 0.0927, 0.0811, 0.0701, 0.0576},
+	"T3s": {0.4588, 0.2949, 0.2219, 0.1795, 0.1559, 0.1322, 0.1178, 0.1051, 0.0936},
+	"T3o": {0.4259, 0.2579, 0.1805, 0.1420, 0.1124, 0.0875, 0.0775, 0.0663, 0.0584},
+	"T2s": {0.4456, 0.2858, 0.2213, 0.1759, 0.1468, 0.1281, 0.1148, 0.1081, 0.0996},
+	"T2o": {0.4180, 0.2422, 0.1698, 0.1331, 0.1047, 0.0888, 0.0743, 0.0672, 0.0567},
+	"99":  {0.7166, 0.5375, 0.4208, 0.3282, 0.2626, 0.2220, 0.1968, 0.1756, 0.1588},
+	"98s": {0.5086, 0.3583, 0.2831, 0.2390, 0.2066, 0.1808, 0.1572, 0.1427, 0.1360},
+	"98o": {0.4820, 0.3276, 0.2515, 0.2013, 0.1683, 0.1428, 0.1198, 0.1110, 0.0983},
+	"97s": {0.4915, 0.3383, 0.2677, 0.2216, 0.1854, 0.1639, 0.1504, 0.1367, 0.1244},
+	"97o": {0.4614, 0.3032, 0.2327, 0.1827, 0.1448, 0.1259, 0.1143, 0.0994, 0.0898},
+	"96s": {0.4674, 0.3249, 0.2437, 0.2005, 0.1732, 0.1481, 0.1326, 0.1219, 0.1127},
+	"96o": {0.4454, 0.2822, 0.2098, 0.1609, 0.1339, 0.1158, 0.0951, 0.0872, 0.0772},
+	"95s": {0.4577, 0.3009, 0.2289, 0.1854, 0.1586, 0.1438, 0.1270, 0.1123, 0.1012},
+	"95o": {0.4300, 0.2645, 0.1905, 0.1440, 0.1222, 0.0981, 0.0817, 0.0718, 0.0667},
+	"94s": {0.4391, 0.2791, 0.2123, 0.1710, 0.1511, 0.1247, 0.1138, 0.1014, 0.0959},
+	"94o": {0.4058, 0.2435, 0.1747, 0.1369, 0.1067, 0.0855, 0.0727, 0.0657, 0.0541},
+	"93s": {0.4353, 0.2786, 0.2104, 0.1673, 0.1431, 0.1255, 0.1131, 0.1014, 0.0893},
+	"93o": {0.3952, 0.2410, 0.1666, 0.1260, 0.1051, 0.0809, 0.0709, 0.0595, 0.0516},
+	"92s": {0.4241, 0.2692, 0.2018, 0.1660, 0.1366, 0.1210, 0.1103, 0.1006, 0.0898},
+	"92o": {0.3885, 0.2301, 0.1609, 0.1271, 0.0955, 0.0792, 0.0657, 0.0603, 0.0525},
+	"88":  {0.6916, 0.5040, 0.3751, 0.2961, 0.2422, 0.2053, 0.1752, 0.1584, 0.1464},
+	"87s": {0.4834, 0.3367, 0.2665, 0.2234, 0.1916, 0.1663, 0.1521, 0.1372, 0.1230},
+	"87o": {0.4502, 0.3082, 0.2294, 0.1849, 0.1546, 0.1331, 0.1137, 0.1047, 0.0960},
+	"86s": {0.4596, 0.3205, 0.2514, 0.2074, 0.1759, 0.1615, 0.1445, 0.1274, 0.1170},
+	"86o": {0.4266, 0.2855, 0.2097, 0.1632, 0.1356, 0.1147, 0.1019, 0.0938, 0.0846},
+	"85s": {0.4491, 0.2981, 0.2364, 0.1918, 0.1590, 0.1448, 0.1328, 0.1203, 0.1129},
+	"85o": {0.4182, 0.2656, 0.1975, 0.1494, 0.1214, 0.1063, 0.0946, 0.0848, 0.0722},
+	"84s": {0.4198, 0.2773, 0.2117, 0.1709, 0.1501, 0.1328, 0.1219, 0.1028, 0.0989},
+	"84o": {0.3923, 0.2476, 0.1770, 0.1324, 0.1085, 0.0879, 0.0793, 0.0677, 0.0613},
+	"83s": {0.4155, 0.2715, 0.2054, 0.1594, 0.1358, 0.1163, 0.1080, 0.0979, 0.0875},
+	"83o": {0.3775, 0.2259, 0.1561, 0.1167, 0.0938, 0.0798, 0.0662, 0.0578, 0.0509},
+	"82s": {0.4071, 0.2559, 0.1964, 0.1565, 0.1295, 0.1179, 0.1073, 0.0935, 0.0871},
+	"82o": {0.3692, 0.2207, 0.1552, 0.1192, 0.0908, 0.0754, 0.0629, 0.0572, 0.0482},
+	"77":  {0.6565, 0.4584, 0.3489, 0.2750, 0.2222, 0.1854, 0.1641, 0.1471, 0.1375},
+	"76s": {0.4534, 0.3188, 0.2525, 0.2056, 0.1790, 0.1593, 0.1434, 0.1347, 0.1232},
+	"76o": {0.4220, 0.2882, 0.2179, 0.1691, 0.1406, 0.1186, 0.1097, 0.0985, 0.0859},
+	"75s": {0.4392, 0.3038, 0.2343, 0.1916, 0.1619, 0.1455, 0.1313, 0.1248, 0.1141},
+	"75o": {0.4124, 0.2629, 0.1982, 0.1536, 0.1269, 0.1082, 0.0973, 0.0873, 0.0800},
+	"74s": {0.4165, 0.2807, 0.2249, 0.1793, 0.1528, 0.1327, 0.1220, 0.1137, 0.1056},
+	"74o": {0.3880, 0.2439, 0.1772, 0.1378, 0.1158, 0.0971, 0.0810, 0.0744, 0.0683},
+	"73s": {0.3969, 0.2685, 0.2025, 0.1648, 0.1402, 0.1237, 0.1116, 0.0996, 0.0979},
+	"73o": {0.3696, 0.2285, 0.1594, 0.1195, 0.0983, 0.0833, 0.0714, 0.0628, 0.0579},
+	"72s": {0.3844, 0.2454, 0.1846, 0.1514, 0.1311, 0.1107, 0.1010, 0.0929, 0.0879},
+	"72o": {0.3454, 0.2031, 0.1399, 0.1074, 0.0869, 0.0716, 0.0616, 0.0555, 0.0490},
+	"66":  {0.6201, 0.4245, 0.3130, 0.2415, 0.2023, 0.1728, 0.1554, 0.1447, 0.1300},
+	"65s": {0.4404, 0.3064, 0.2380, 0.1973, 0.1677, 0.1501, 0.1389, 0.1265, 0.1188},
+	"65o": {0.4042, 0.2640, 0.2065, 0.1583, 0.1335, 0.1148, 0.1001, 0.0952, 0.0826},
+	"64s": {0.4152, 0.2819, 0.2265, 0.1820, 0.1551, 0.1424, 0.1274, 0.1205, 0.1109},
+	"64o": {0.3773, 0.2489, 0.1820, 0.1456, 0.1202, 0.1036, 0.0954, 0.0816, 0.0760},
+	"63s": {0.3983, 0.2589, 0.2039, 0.1680, 0.1460, 0.1284, 0.1166, 0.1086, 0.1033},
+	"63o": {0.3590, 0.2260, 0.1667, 0.1252, 0.1021, 0.0925, 0.0825, 0.0725, 0.0611},
+	"62s": {0.3762, 0.2457, 0.1823, 0.1531, 0.1310, 0.1176, 0.1056, 0.0977, 0.0891},
+	"62o": {0.3450, 0.2097, 0.1453, 0.1121, 0.0909, 0.0788, 0.0666, 0.0596, 0.0533},
+	"55":  {0.6028, 0.3976, 0.2909, 0.2269, 0.1876, 0.1622, 0.1416, 0.1334, 0.1216},
+	"54s": {0.4141, 0.2883, 0.2310, 0.1920, 0.1632, 0.1472, 0.1335, 0.1217, 0.1177},
+	"54o": {0.3813, 0.2562, 0.1873, 0.1527, 0.1285, 0.1115, 0.1022, 0.0900, 0.0788},
+	"53s": {0.3988, 0.2719, 0.2137, 0.1755, 0.1511, 0.1391, 0.1232, 0.1218, 0.1065},
+	"53o": {0.3569, 0.2352, 0.1741, 0.1351, 0.1162, 0.1004, 0.0843, 0.0796, 0.0728},
+	"52s": {0.3807, 0.2513, 0.1892, 0.1602, 0.1436, 0.1270, 0.1155, 0.1104, 0.0986},
+	"52o": {0.3411, 0.2148, 0.1542, 0.1198, 0.1003, 0.0880, 0.0788, 0.0675, 0.0663},
+	"44":  {0.5645, 0.3692, 0.2655, 0.2071, 0.1714, 0.1531, 0.1398, 0.1261, 0.1200},
+	"43s": {0.3846, 0.2666, 0.2066, 0.1675, 0.1498, 0.1281, 0.1189, 0.1103, 0.1066},
+	"43o": {0.3457, 0.2220, 0.1639, 0.1308, 0.1112, 0.0918, 0.0855, 0.0741, 0.0693},
+	"42s": {0.3687, 0.2403, 0.1857, 0.1585, 0.1353, 0.1207, 0.1097, 0.1006, 0.0949},
+	"42o": {0.3304, 0.2107, 0.1499, 0.1116, 0.0917, 0.0847, 0.0743, 0.0655, 0.0573},
+	"33":  {0.5377, 0.3397, 0.2388, 0.1915, 0.1579, 0.1473, 0.1384, 0.1239, 0.1215},
+	"32s": {0.3556, 0.2409, 0.1822, 0.1505, 0.1321, 0.1160, 0.1063, 0.0982, 0.0918},
+	"32o": {0.3241, 0.1989, 0.1394, 0.1088, 0.0895, 0.0782, 0.0668, 0.0611, 0.0555},
+	"22":  {0.5073, 0.3049, 0.2197, 0.1778, 0.1560, 0.1444, 0.1276, 0.1264, 0.1190},
+}
@@ -0,0 +1,171 @@
+package models
+
+import "testing"
+
+func TestStartingHands(t *testing.T) {
+	hands := StartingHands()
+	if len(hands) != StartingHandsNum {
+		t.Fatalf("StartingHands() returned %d hands, want %d", len(hands), StartingHandsNum)
+	}
+
+	seen := make(map[string]bool, len(hands))
+	combos := 0
+
+	for _, h := range hands {
+		name := h.String()
+		if seen[name] {
+			t.Errorf("StartingHands() returned %s twice", name)
+		}
+
+		seen[name] = true
+		combos += len(h.Combos())
+
+		parsed, err := ParseStartingHand(name)
+		if err != nil || parsed != h {
+			t.Errorf("ParseStartingHand(%s) = %v, %v", name, parsed, err)
+		}
+	}
+
+	// 1326 сочетаний двух карт из 52
+	if combos != 1326 {
+		t.Errorf("starting hands have %d combos, want 1326", combos)
+	}
+
+	if hands[0].String() != "AA" || hands[len(hands)-1].String() != "22" {
+		t.Errorf("StartingHands() runs from %s to %s, want AA to 22", hands[0], hands[len(hands)-1])
+	}
+}
+
+func TestNewStartingHand(t *testing.T) {
+	tests := []struct {
+		pocket string
+		want   string
+	}{
+		{pocket: "As Ad", want: "AA"},
+		{pocket: "Kh Ah", want: "AKs"},
+		{pocket: "9c Td", want: "T9o"},
+	}
+
+	for _, tt := range tests {
+		h, err := NewStartingHand(MustParse(tt.pocket))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if h.String() != tt.want {
+			t.Errorf("NewStartingHand(%s) = %s, want %s", tt.pocket, h, tt.want)
+		}
+	}
+
+	if _, err := NewStartingHand(MustParse("As Ad Ac")); err == nil {
+		t.Error("NewStartingHand() accepted three cards")
+	}
+
+	for _, s := range []string{"A", "KA", "AAs", "AK", "AKx", "1K"} {
+		if _, err := ParseStartingHand(s); err == nil {
+			t.Errorf("ParseStartingHand(%s) accepted a wrong hand", s)
+		}
+	}
+}
+
+func TestSklanskyGroup(t *testing.T) {
+	tests := []struct {
+		hand string
+		want int
+	}{
+		{hand: "AA", want: 1},
+		{hand: "KK", want: 1},
+		{hand: "AKs", want: 1},
+		{hand: "AKo", want: 2},
+		{hand: "T9s", want: 4},
+		{hand: "22", want: 7},
+		{hand: "K9o", want: 8},
+		{hand: "72o", want: SklanskyUnranked},
+	}
+
+	for _, tt := range tests {
+		h, err := ParseStartingHand(tt.hand)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := h.SklanskyGroup(); got != tt.want {
+			t.Errorf("%s.SklanskyGroup() = %d, want %d", tt.hand, got, tt.want)
+		}
+	}
+}
+
+func TestChenScore(t *testing.T) {
+	tests := []struct {
+		pocket string
+		want   int
+	}{
+		{pocket: "As Ad", want: 20},
+		{pocket: "Ks Kd", want: 16},
+		{pocket: "5s 5d", want: 5},
+		{pocket: "2s 2d", want: 5},
+		{pocket: "As Ks", want: 12},
+		{pocket: "Ah Kd", want: 10},
+		// 5 + 2 за масть, +1 за связность
+		{pocket: "Ts 9s", want: 8},
+		// 3.5 - 4 за разрыв в два номинала
+		{pocket: "7h 2d", want: -1},
+	}
+
+	for _, tt := range tests {
+		got, err := ChenScore(MustParse(tt.pocket))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.want {
+			t.Errorf("ChenScore(%s) = %d, want %d", tt.pocket, got, tt.want)
+		}
+	}
+
+	if _, err := ChenScore(MustParse("As")); err == nil {
+		t.Error("ChenScore() accepted a single card")
+	}
+}
+
+func TestStartingHandEquity(t *testing.T) {
+	for _, h := range []string{"AA", "KK", "AKs", "T9s", "72o"} {
+		hand, err := ParseStartingHand(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		prev := 1.0
+
+		for opponents := 1; opponents <= MaxOpponents; opponents++ {
+			equity, err := hand.Equity(opponents)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if equity >= prev {
+				t.Errorf("%s equity against %d opponents = %.4f, not less than %.4f", h, opponents, equity, prev)
+			}
+
+			prev = equity
+		}
+	}
+
+	aces, _ := ParseStartingHand("AA")
+	if _, err := aces.Equity(0); err == nil {
+		t.Error("Equity(0) accepted no opponents")
+	}
+
+	if _, err := aces.Equity(MaxOpponents + 1); err == nil {
+		t.Errorf("Equity(%d) accepted too many opponents", MaxOpponents+1)
+	}
+
+	strength, err := StartingHandStrength(MustParse("Ah Ad"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strength.Hand.String() != "AA" || strength.ChenScore != 20 || strength.SklanskyGroup != 1 || strength.Equity < 0.8 {
+		t.Errorf("StartingHandStrength(AhAd) = %+v", strength)
+	}
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"hands/src/helpers"
+	"math/rand"
 	"sort"
 	"sync"
 )
@@ -16,8 +17,22 @@ const (
 
 const CardsOnFlopNumber = 3
 
+// BoardSize - количество общих карт на столе после ривера
+const BoardSize = 5
+
+// TableRules - правила стола, влияющие на принудительные ставки
 type TableRules struct {
-	// TODO
+	// Ante - размер анте. Если BigBlindAnte == false, анте вносит каждый игрок
+	Ante Chips
+	// BigBlindAnte - анте за весь стол вносит игрок на большом блайнде, в размере Ante
+	BigBlindAnte bool
+	// UTGStraddle - игрок на первой позиции после большого блайнда ставит стрэддл (2 BB)
+	UTGStraddle bool
+	// ButtonStraddle - дилер ставит стрэддл, вдвое превышающий текущую ставку
+	ButtonStraddle bool
+	// DeadBlinds - игроки, севшие за стол в середине круга, вносят мертвый малый блайнд
+	// и живой большой
+	DeadBlinds bool
 }
 
 type DealFunc func() (*Table, error)
@@ -33,20 +48,68 @@ type Table struct {
 	CurrentPlayersNum int
 	BigBlind          Chips
 	SmallBlind        Chips
-	Board             []*Card
-	Dealer            int
-	CurrentMove       int
+	// Board - общие карты в порядке выкладки: порядок нужен истории раздачи и вариантам доставки,
+	// поэтому доска хранится слайсом, а для учета мертвых карт есть BoardSet
+	Board []*Card
+	// Dealer - номер места баттона. Баттон может быть мертвым, т.е. находиться на пустом месте
+	Dealer int
+	// SmallBlindSeat - номер места малого блайнда. Если место пусто, малый блайнд мертвый
+	SmallBlindSeat int
+	// BigBlindSeat - номер места большого блайнда
+	BigBlindSeat int
+	CurrentMove  int
+	// CurrentBet - ставка, которую необходимо уравнять в текущем круге торговли
+	CurrentBet Chips
+	// Street - текущая улица раздачи
+	Street Street
+	// Variant - вариант игры текущей раздачи
+	Variant Variant
 
 	deck             *Deck
 	m                sync.RWMutex
 	dealFuncs        []DealFunc
 	isDealerInactive bool
+	// roundBets - живые ставки игроков в текущем круге торговли (без анте и мертвых блайндов)
+	roundBets map[string]Chips
+	// newcomers - игроки, севшие за стол после начала игры и еще не вносившие блайнды
+	newcomers map[string]struct{}
+	// handlers - подписчики на события стола
+	handlers []EventHandler
+	// startingStacks - стеки игроков перед началом текущей раздачи
+	startingStacks map[string]Chips
+	// handPositions - позиции игроков в текущей раздаче
+	handPositions map[string]Position
+	// actions - действия игроков в текущей раздаче
+	actions []Action
+	// acted - игроки, действовавшие в текущем круге торговли после последнего повышения
+	acted map[string]bool
+	// minRaise - минимальный размер повышения ставки
+	minRaise Chips
+	// random - генератор случайных чисел стола; если nil, используется генератор по умолчанию
+	random *rand.Rand
+	// upCards - открытые карты игроков в играх с открытыми картами
+	upCards map[string][]*Card
+	// bets - количество ставок и повышений на текущей улице в лимитной игре
+	bets int
+	// drawing - идет обмен карт; drawn - игроки, уже обменявшие карты
+	drawing bool
+	drawn   map[string]bool
+	// discardNum - количество карт, которые игроки сбрасывают без замены; pendingBoard - количество
+	// общих карт, выкладываемых после сброса
+	discardNum   int
+	pendingBoard int
+	// rotation - варианты смешанной игры, сменяющиеся после каждого круга баттона
+	rotation      []Variant
+	rotationIndex int
+	// orbitHands - количество раздач, сыгранных в текущем варианте ротации
+	orbitHands int
 }
 
 func NewTable(name, tag string, idMaker IdMaker, tableType TableType, maxPlayersNum, bb, sb int) *Table {
 	t := &Table{
 		ID:                idMaker.MakeID(),
 		Type:              tableType,
+		TableRules:        &TableRules{},
 		Name:              name,
 		Pot:               NewPot(tag),
 		Players:           make([]*Player, 0),
@@ -56,45 +119,54 @@ func NewTable(name, tag string, idMaker IdMaker, tableType TableType, maxPlayers
 		SmallBlind:        Chips(sb),
 		deck:              NewDeck(),
 		Dealer:            0,
+		SmallBlindSeat:    0,
+		BigBlindSeat:      0,
 		CurrentMove:       0,
 		m:                 sync.RWMutex{},
 		isDealerInactive:  true,
+		roundBets:         make(map[string]Chips),
+		newcomers:         make(map[string]struct{}),
+		startingStacks:    make(map[string]Chips),
+		handPositions:     make(map[string]Position),
+		acted:             make(map[string]bool),
+		Street:            PreFlopStreet,
+		Variant:           HoldemVariant,
+		upCards:           make(map[string][]*Card),
+		drawn:             make(map[string]bool),
 	}
 
-	t.dealFuncs = []DealFunc{
-		t.PreFlop,
-		t.Flop,
-		t.Turn,
-		t.River,
-	}
+	t.buildDealFuncs()
 
 	return t
 }
 
 func NewTableWithDefaultId(name, tag string, tableType TableType, maxPlayersNum, bb, sb int) *Table {
-	t := &Table{
-		ID:                helpers.NewDefaultIdGenerator().MakeID(),
-		Type:              tableType,
-		Name:              name,
-		Pot:               NewPot(tag),
-		Players:           make([]*Player, 0),
-		MaxPlayersNum:     maxPlayersNum,
-		CurrentPlayersNum: 0,
-		BigBlind:          Chips(bb),
-		SmallBlind:        Chips(sb),
-		deck:              NewDeck(),
-		Dealer:            0,
-		CurrentMove:       0,
-		m:                 sync.RWMutex{},
-		isDealerInactive:  true,
-	}
+	return NewTable(name, tag, helpers.NewDefaultIdGenerator(), tableType, maxPlayersNum, bb, sb)
+}
 
-	t.dealFuncs = []DealFunc{
-		t.PreFlop,
-		t.Flop,
-		t.Turn,
-		t.River,
-	}
+// WithRandom устанавливает генератор случайных чисел для тасования колоды и выбора баттона,
+// что делает игру воспроизводимой. rand.Rand не потокобезопасен, поэтому генератор
+// не должен использоваться одновременно другими столами. Возвращает t
+func (t *Table) WithRandom(r *rand.Rand) *Table {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.random = r
+
+	return t
+}
+
+// shuffleDeck тасует колоду варианта игры генератором стола
+func (t *Table) shuffleDeck() {
+	t.deck = t.deck.ShuffleCards(t.variant().Deck(), t.random)
+}
+
+// WithRules устанавливает правила стола. Возвращает t
+func (t *Table) WithRules(rules *TableRules) *Table {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.TableRules = rules
 
 	return t
 }
@@ -123,216 +195,115 @@ func (t *Table) Register(player *Player) error {
 		return fmt.Errorf("Players limit %d exceeded for table %s", t.MaxPlayersNum, t.Name)
 	}
 
-	t.Players = append(t.Players, player)
-
-	return t.Pot.Register(player.ID)
-}
-
-func (t *Table) GetDealFuncs() []DealFunc {
-	return t.dealFuncs
-}
-
-func (t *Table) setDealer() *Table {
-	d := helpers.GenerateRandomNumInRange(len(t.Players))
-
-	t.Dealer = d
-
-	return t
-}
-
-func (t *Table) NextDealer() int {
-	if t.isDealerInactive {
-		t.isDealerInactive = false
-
-		return t.setDealer().Dealer
-	}
-
-	if t.Dealer == len(t.Players)-1 {
-		t.Dealer = 0
+	t.seat(player)
 
-		return 0
+	// игра уже идет - новичок должен будет внести мертвые блайнды
+	if !t.isDealerInactive {
+		t.newcomers[player.ID] = struct{}{}
 	}
 
-	t.Dealer++
-
-	return t.Dealer
-}
-
-func (t *Table) GetDealer() *Player {
-	return t.Players[t.Dealer]
+	return t.Pot.Register(player.ID)
 }
 
-func (t *Table) GetFirstPosition() *Player {
-	if t.Dealer == len(t.Players)-1 {
-		return t.Players[0]
-	}
+// seat сажает игрока на первое свободное место. Слайс Players упорядочен по номерам мест
+func (t *Table) seat(player *Player) {
+	taken := make(map[int]struct{})
 
-	return t.Players[t.Dealer+1]
-}
-
-func (t *Table) GetSecondPosition() *Player {
-	if t.Dealer == len(t.Players)-2 {
-		return t.Players[0]
+	for _, p := range t.Players {
+		taken[p.Seat] = struct{}{}
 	}
 
-	return t.Players[t.Dealer+2]
-}
-
-func (t *Table) GetPlayersHand(pocket []*Card) (*Hand, error) {
-	return GetMaxHandWithBoard(NewStringSliceFromCards(t.Board), NewStringSliceFromCards(pocket))
-}
-
-// ResolveWinner возвращает слайс с id игроков - обладателей максимальных рук
-func (t *Table) ResolveWinner() ([]string, error) {
-	playersHandsMap := make(map[string]*Hand)
-	hands := make([]*Hand, 0)
+	for seat := 0; seat < t.MaxPlayersNum; seat++ {
+		if _, ok := taken[seat]; !ok {
+			player.Seat = seat
 
-	for _, player := range t.Players {
-		h, err := t.GetPlayersHand(player.GetPocketCards())
-		if err != nil {
-			return nil, err
+			break
 		}
-
-		hands = append(hands, h)
-		playersHandsMap[player.ID] = h
 	}
 
-	sort.Slice(hands, func(i, j int) bool {
-		return hands[i].Compare(hands[j]) < 0
-	})
-
-	similar := make([]*Hand, 0)
-	lastNum, preLastNum := len(hands)-1, len(hands)-2
-
-	// если есть только одна максимальная рука
-	if hands[lastNum].Compare(hands[preLastNum]) != 0 {
-		for id, hand := range playersHandsMap {
-			if hand.Same(hands[lastNum]) {
-				return []string{id}, nil
-			}
-		}
-	} else {
-		result := make([]string, 0)
-
-		// перебираем все руки, имеющие максимальное значение
-		for hands[lastNum].Compare(hands[preLastNum]) == 0 {
-			similar = append(similar, hands[lastNum], hands[preLastNum])
-			if preLastNum == 0 { // len(hands) == 2
-				break
-			}
-
-			lastNum--
-			preLastNum--
-		}
-
-		for id, hand := range playersHandsMap {
-			for _, h := range similar {
-				if hand.Same(h) {
-					result = append(result, id)
-				}
-			}
-		}
-
-		return result, nil
-	}
+	t.Players = append(t.Players, player)
+	t.CurrentPlayersNum = len(t.Players)
 
-	return nil, nil
+	sort.Slice(t.Players, func(i, j int) bool {
+		return t.Players[i].Seat < t.Players[j].Seat
+	})
 }
 
-func (t *Table) PreFlop() (*Table, error) {
+// Unregister убирает игрока из-за стола. Его место освобождается, а вклад в банк текущей раздачи остается
+// в банке мертвыми фишками. Игрок может снова сесть за стол
+func (t *Table) Unregister(playerID string) error {
 	t.m.Lock()
 	defer t.m.Unlock()
 
-	if err := t.deck.Discard(); err != nil {
-		return nil, err
-	}
+	for i, p := range t.Players {
+		if p.ID == playerID {
+			t.Players = append(t.Players[:i], t.Players[i+1:]...)
+			t.CurrentPlayersNum = len(t.Players)
 
-	for i := 0; i < PocketSize; i++ {
-		for _, player := range t.Players {
-			card, err := t.deck.Card()
-			if err != nil {
-				return nil, err
-			}
+			delete(t.newcomers, playerID)
+			t.Pot.Unregister(playerID)
 
-			if err := player.AddCard(card); err != nil {
-				return nil, err
-			}
+			return nil
 		}
 	}
 
-	return t, nil
+	return fmt.Errorf("Player with ID %s is not in this game", playerID)
 }
 
-func (t *Table) Flop() (*Table, error) {
-	t.m.Lock()
-	defer t.m.Unlock()
-
-	if err := t.deck.Discard(); err != nil {
-		return nil, err
-	}
-
-	for i := 0; i < CardsOnFlopNumber; i++ {
-		card, err := t.deck.Card()
-		if err != nil {
-			return nil, err
-		}
+func (t *Table) GetDealFuncs() []DealFunc {
+	return t.dealFuncs
+}
 
-		t.Board = append(t.Board, card)
-	}
+// BoardSet возвращает множество общих карт
+func (t *Table) BoardSet() CardSet {
+	t.m.RLock()
+	defer t.m.RUnlock()
 
-	return t, nil
+	return NewCardSet(t.Board...)
 }
 
-func (t *Table) openOne() (*Table, error) {
-	t.m.Lock()
-	defer t.m.Unlock()
-
-	if err := t.deck.Discard(); err != nil {
-		return nil, err
-	}
+func (t *Table) GetPlayersHand(pocket []*Card) (*Hand, error) {
+	return GetMaxHandWithBoard(NewStringSliceFromCards(t.Board), NewStringSliceFromCards(pocket))
+}
 
-	card, err := t.deck.Card()
+// ResolveWinner возвращает слайс с id игроков - обладателей максимальных рук (в играх хай-лоу - старших рук,
+// см. Showdown). Учитываются только игроки, не сбросившие карты. Если такой игрок один, он побеждает без вскрытия
+func (t *Table) ResolveWinner() ([]string, error) {
+	groups, err := t.showdownGroups()
 	if err != nil {
 		return nil, err
 	}
 
-	t.Board = append(t.Board, card)
+	return groups[0], nil
+}
 
-	return t, nil
+// PreFlop раздает первую улицу варианта игры: в холдеме - карманные карты
+func (t *Table) PreFlop() (*Table, error) {
+	return t.dealStreet(t.streetDealer(0))
+}
+
+// Flop раздает вторую улицу варианта игры
+func (t *Table) Flop() (*Table, error) {
+	return t.dealStreet(t.streetDealer(1))
 }
 
+// Turn раздает третью улицу варианта игры
 func (t *Table) Turn() (*Table, error) {
-	return t.openOne()
+	return t.dealStreet(t.streetDealer(2))
 }
 
+// River раздает четвертую улицу варианта игры
 func (t *Table) River() (*Table, error) {
-	return t.openOne()
+	return t.dealStreet(t.streetDealer(3))
 }
 
-func (t *Table) Blinds() (*Table, error) {
-	t.m.Lock()
-	defer t.m.Unlock()
-
-	small := t.GetFirstPosition()
-	big := t.GetSecondPosition()
-
-	smallBlind, err := small.Call(NewBet(t.SmallBlind, 0))
-	if err != nil {
+// dealStreet выполняет раздачу улицы и оповещает подписчиков о ее результатах
+func (t *Table) dealStreet(deal DealFunc) (*Table, error) {
+	if _, err := deal(); err != nil {
 		return nil, err
 	}
 
-	if _, err = t.Pot.AddPlayerBet(smallBlind, small.ID); err != nil {
-		return nil, err
-	}
-
-	bigBlind, err := big.Call(NewBet(t.BigBlind, 0))
-	if err != nil {
-		return nil, err
-	}
-
-	if _, err = t.Pot.AddPlayerBet(bigBlind, big.ID); err != nil {
-		return nil, err
-	}
+	t.notifyStreet()
 
 	return t, nil
 }
@@ -341,7 +312,7 @@ func (t *Table) ShuffleDeck() *Table {
 	t.m.Lock()
 	defer t.m.Unlock()
 
-	t.deck = t.deck.Shuffle()
+	t.shuffleDeck()
 
 	return t
 }
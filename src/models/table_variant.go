@@ -0,0 +1,516 @@
+package models
+
+import "fmt"
+
+// variant возвращает вариант игры стола
+func (t *Table) variant() Variant {
+	if t.Variant == nil {
+		return HoldemVariant
+	}
+
+	return t.Variant
+}
+
+// WithVariant устанавливает вариант игры, начиная со следующей раздачи, и отменяет ротацию. Возвращает t
+func (t *Table) WithVariant(v Variant) *Table {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.rotation = nil
+	t.setVariant(v)
+
+	return t
+}
+
+// WithRotation устанавливает ротацию смешанной игры (например, HORSE): вариант меняется
+// после каждого круга баттона, т.е. после стольких раздач, сколько игроков за столом. Возвращает t
+func (t *Table) WithRotation(variants []Variant) *Table {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.rotation = append(make([]Variant, 0, len(variants)), variants...)
+	t.rotationIndex, t.orbitHands = 0, 0
+
+	if len(t.rotation) > 0 {
+		t.setVariant(t.rotation[0])
+	}
+
+	return t
+}
+
+func (t *Table) setVariant(v Variant) {
+	t.Variant = v
+	t.buildDealFuncs()
+}
+
+// rotate переходит к следующему варианту ротации, если круг баттона в текущем варианте закончен
+func (t *Table) rotate() {
+	if len(t.rotation) == 0 {
+		return
+	}
+
+	if t.orbitHands >= len(t.seated()) {
+		t.rotationIndex = (t.rotationIndex + 1) % len(t.rotation)
+		t.orbitHands = 0
+		t.setVariant(t.rotation[t.rotationIndex])
+	}
+
+	t.orbitHands++
+}
+
+// buildDealFuncs строит функции раздачи улиц по описанию варианта игры
+func (t *Table) buildDealFuncs() {
+	streets := t.variant().Streets()
+	t.dealFuncs = make([]DealFunc, len(streets))
+
+	for i := range streets {
+		deal := t.streetDealer(i)
+
+		t.dealFuncs[i] = func() (*Table, error) {
+			return t.dealStreet(deal)
+		}
+	}
+}
+
+// streetDealer возвращает функцию раздачи улицы номер index варианта игры
+func (t *Table) streetDealer(index int) DealFunc {
+	return func() (*Table, error) {
+		t.m.Lock()
+		defer t.m.Unlock()
+
+		return t.dealVariantStreet(index)
+	}
+}
+
+// dealVariantStreet раздает улицу номер index: сжигает карту, раздает игрокам закрытые и открытые карты,
+// выкладывает общие карты и начинает круг торговли или обмен карт. Вызывается под блокировкой стола
+func (t *Table) dealVariantStreet(index int) (*Table, error) {
+	v := t.variant()
+	streets := v.Streets()
+
+	if index >= len(streets) {
+		return nil, fmt.Errorf("Variant %s has only %d streets", v.Name(), len(streets))
+	}
+
+	spec := streets[index]
+
+	if index == 0 {
+		t.Street = spec.Street
+	} else {
+		t.startBettingRound(spec.Street)
+	}
+
+	if spec.Down+spec.Up > 0 {
+		if err := t.deck.Discard(); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 0; i < spec.Down+spec.Up; i++ {
+		if err := t.dealEach(i >= spec.Down); err != nil {
+			return nil, err
+		}
+	}
+
+	// общие карты выкладываются, когда все игроки сбросят лишние карты
+	if spec.Discard > 0 {
+		t.startExchange(spec.Discard)
+		t.pendingBoard = spec.Board
+
+		return t, nil
+	}
+
+	if err := t.dealBoard(spec.Board); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case spec.Draw:
+		t.startExchange(0)
+	case v.BringIn() && index == 0:
+		return t, t.postBringIn()
+	case v.BringIn():
+		t.CurrentMove = t.playerIndex(t.bestVisible().ID)
+
+		if !canAct(t.Players[t.CurrentMove]) {
+			t.advance()
+		}
+	}
+
+	return t, nil
+}
+
+// dealBoard сжигает карту и выкладывает num общих карт
+func (t *Table) dealBoard(num int) error {
+	if num == 0 {
+		return nil
+	}
+
+	if err := t.deck.Discard(); err != nil {
+		return err
+	}
+
+	for i := 0; i < num; i++ {
+		card, err := t.deck.Card()
+		if err != nil {
+			return err
+		}
+
+		t.Board = append(t.Board, card)
+	}
+
+	return nil
+}
+
+// startExchange начинает обмен карт, а если discard больше нуля - сброс discard карт без замены
+func (t *Table) startExchange(discard int) {
+	t.drawing = true
+	t.discardNum = discard
+	t.drawn = make(map[string]bool)
+
+	if next := t.nextDrawer(); next != nil {
+		t.CurrentMove = t.playerIndex(next.ID)
+	}
+}
+
+// dealEach сдает по одной карте каждому игроку в раздаче. Если карт в колоде не хватает всем
+// (стад с восемью игроками), открывается одна общая карта
+func (t *Table) dealEach(up bool) error {
+	players := make([]*Player, 0, len(t.Players))
+
+	for _, p := range t.Players {
+		if p.Active {
+			players = append(players, p)
+		}
+	}
+
+	if t.deck.Len() < len(players) {
+		card, err := t.deck.Card()
+		if err != nil {
+			return err
+		}
+
+		t.Board = append(t.Board, card)
+
+		return nil
+	}
+
+	for _, p := range players {
+		card, err := t.deck.Card()
+		if err != nil {
+			return err
+		}
+
+		if err := p.AddCard(card); err != nil {
+			return err
+		}
+
+		if up {
+			t.upCards[p.ID] = append(t.upCards[p.ID], card)
+		}
+	}
+
+	return nil
+}
+
+// postBringIn собирает bring-in, равный малому блайнду, с игрока с наименьшей оценкой открытых карт.
+// Первым действует следующий игрок. Bring-in не считается действием: игрок сохраняет право хода
+func (t *Table) postBringIn() error {
+	var bringIn *Player
+
+	for _, p := range t.Players {
+		if !p.Active {
+			continue
+		}
+
+		if bringIn == nil || t.visibleStrength(p) < t.visibleStrength(bringIn) {
+			bringIn = p
+		}
+	}
+
+	if bringIn == nil {
+		return fmt.Errorf("No players to post bring-in at table %s", t.Name)
+	}
+
+	if _, err := t.post(bringIn, t.SmallBlind, true); err != nil {
+		return err
+	}
+
+	t.CurrentMove = t.playerIndex(bringIn.ID)
+	t.advance()
+
+	return nil
+}
+
+func (t *Table) visibleStrength(p *Player) int {
+	return t.variant().VisibleStrength(t.upCards[p.ID])
+}
+
+// bestVisible возвращает игрока с сильнейшими открытыми картами. При равных комбинациях оценка
+// варианта сравнивает масти, как и при выборе bring-in, поэтому такой игрок всегда один
+func (t *Table) bestVisible() *Player {
+	var best *Player
+
+	for _, p := range t.Players {
+		if p.Active && (best == nil || t.visibleStrength(p) > t.visibleStrength(best)) {
+			best = p
+		}
+	}
+
+	return best
+}
+
+// nextDrawer возвращает следующего после баттона игрока в раздаче, еще не менявшего карты, или nil
+func (t *Table) nextDrawer() *Player {
+	for _, p := range t.PostflopOrder() {
+		if p.Active && !t.drawn[p.ID] {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// streetSpec возвращает описание текущей улицы
+func (t *Table) streetSpec() StreetSpec {
+	for _, spec := range t.variant().Streets() {
+		if spec.Street == t.Street {
+			return spec
+		}
+	}
+
+	return StreetSpec{Street: t.Street}
+}
+
+// limitBet возвращает размер ставки на текущей улице лимитной игры: малая ставка равна большому блайнду
+func (t *Table) limitBet() Chips {
+	if t.streetSpec().BigBet {
+		return 2 * t.BigBlind
+	}
+
+	return t.BigBlind
+}
+
+// limitRaise возвращает размер повышения на текущей улице лимитной игры. Повышение после bring-in
+// или неполного блайнда дополняет ставку до размера ставки улицы
+func (t *Table) limitRaise() Chips {
+	if bet := t.limitBet(); t.CurrentBet < bet {
+		return bet - t.CurrentBet
+	}
+
+	return t.limitBet()
+}
+
+// IsDrawing проверяет, идет ли обмен карт
+func (t *Table) IsDrawing() bool {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	return t.drawing
+}
+
+// Draw меняет карты discards игрока playerID на карты из колоды. Игроки меняют карты по очереди,
+// начиная с первого после баттона. Когда колода заканчивается, в нее перетасовывается сброс; карты,
+// сбрасываемые игроком сейчас, в замену ему не попадают. Возвращает полученные карты
+func (t *Table) Draw(playerID string, discards []*Card) ([]*Card, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if t.discardNum > 0 {
+		return nil, fmt.Errorf("Player with ID %s must discard %d cards without drawing", playerID, t.discardNum)
+	}
+
+	return t.exchange(playerID, discards)
+}
+
+// Discard сбрасывает лишние карманные карты cards игрока playerID без замены (пайнэппл).
+// Игроки сбрасывают карты по очереди, начиная с первого после баттона. Когда все сбросили карты,
+// выкладываются общие карты улицы и начинается круг торговли
+func (t *Table) Discard(playerID string, cards []*Card) error {
+	t.m.Lock()
+
+	if t.discardNum == 0 {
+		t.m.Unlock()
+
+		return fmt.Errorf("There is no discard on %s street", t.Street)
+	}
+
+	if len(cards) != t.discardNum {
+		t.m.Unlock()
+
+		return fmt.Errorf("Player with ID %s must discard %d cards, got %d", playerID, t.discardNum, len(cards))
+	}
+
+	_, err := t.exchange(playerID, cards)
+	dealt := err == nil && !t.drawing
+
+	t.m.Unlock()
+
+	// общие карты выложены - оповещаем подписчиков
+	if dealt {
+		t.notifyStreet()
+	}
+
+	return err
+}
+
+// exchange меняет или сбрасывает карты игрока и передает очередь обмена следующему игроку.
+// Вызывается под блокировкой стола
+func (t *Table) exchange(playerID string, discards []*Card) ([]*Card, error) {
+	player := t.GetPlayerByID(playerID)
+	if player == nil {
+		return nil, fmt.Errorf("Player with ID %s is not in this game", playerID)
+	}
+
+	if !t.drawing || t.Players[t.CurrentMove] != player {
+		return nil, fmt.Errorf("It is not a draw turn of player with ID %s", playerID)
+	}
+
+	pocket := player.GetPocketCards()
+	mucked := make([]*Card, 0, len(discards))
+
+	for _, c := range pocket {
+		if inSlice(discards, c) {
+			mucked = append(mucked, c)
+		}
+	}
+
+	if len(mucked) != len(discards) {
+		return nil, fmt.Errorf("Player with ID %s can discard only own cards, without repeats", playerID)
+	}
+
+	drawn := make([]*Card, 0, len(mucked))
+
+	for i := 0; i < len(mucked) && t.discardNum == 0; i++ {
+		if t.deck.Len() == 0 {
+			if err := t.deck.Reshuffle(t.random); err != nil {
+				return nil, fmt.Errorf("Not enough cards to draw for player with ID %s", playerID)
+			}
+		}
+
+		card, err := t.deck.Card()
+		if err != nil {
+			return nil, err
+		}
+
+		drawn = append(drawn, card)
+	}
+
+	t.deck.Muck(mucked...)
+	player.replaceCards(mucked, drawn)
+
+	action := DrawAction
+
+	switch {
+	case t.discardNum > 0:
+		action = DiscardAction
+	case len(mucked) == 0:
+		action = StandPatAction
+	}
+
+	t.actions = append(t.actions, Action{
+		PlayerID: playerID,
+		Type:     action,
+		Street:   t.Street,
+	})
+
+	t.drawn[playerID] = true
+
+	if next := t.nextDrawer(); next != nil {
+		t.CurrentMove = t.playerIndex(next.ID)
+
+		return drawn, nil
+	}
+
+	// все обменяли карты - выкладываются отложенные общие карты и начинается торговля
+	t.drawing = false
+
+	if t.discardNum > 0 {
+		t.discardNum = 0
+
+		if err := t.dealBoard(t.pendingBoard); err != nil {
+			return nil, err
+		}
+
+		t.pendingBoard = 0
+	}
+
+	for _, p := range t.PostflopOrder() {
+		if canAct(p) {
+			t.CurrentMove = t.playerIndex(p.ID)
+
+			break
+		}
+	}
+
+	return drawn, nil
+}
+
+// GetUpCards возвращает открытые карты игрока playerID
+func (t *Table) GetUpCards(playerID string) []*Card {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	return append(make([]*Card, 0, len(t.upCards[playerID])), t.upCards[playerID]...)
+}
+
+// contenders возвращает ID игроков, претендующих на банк, т.е. не сбросивших карты, в порядке мест.
+// Если в раздаче остался один игрок, он претендует на банк без вскрытия
+func (t *Table) contenders() []string {
+	single := t.inHand() == 1
+	ids := make([]string, 0, len(t.Players))
+
+	for _, player := range t.Players {
+		if player.Active && (single || len(player.GetPocketCards()) > 0) {
+			ids = append(ids, player.ID)
+		}
+	}
+
+	return ids
+}
+
+// showdownGroups возвращает победителей частей банка по правилам варианта игры: одну группу,
+// или старшие и младшие руки в играх хай-лоу
+func (t *Table) showdownGroups() ([][]string, error) {
+	return t.groupsAmong(t.contenders())
+}
+
+// groupsAmong возвращает победителей среди игроков ids так же, как showdownGroups
+func (t *Table) groupsAmong(ids []string) ([][]string, error) {
+	if len(ids) < 2 {
+		return [][]string{append(make([]string, 0, len(ids)), ids...)}, nil
+	}
+
+	pockets := make([][]*Card, len(ids))
+
+	for i, id := range ids {
+		pockets[i] = t.GetPlayerByID(id).GetPocketCards()
+	}
+
+	groups := make([][]string, 0)
+
+	for _, indexes := range t.variant().Showdown(pockets, t.Board) {
+		winners := make([]string, len(indexes))
+
+		for i, idx := range indexes {
+			winners[i] = ids[idx]
+		}
+
+		groups = append(groups, winners)
+	}
+
+	return groups, nil
+}
+
+// Showdown определяет победителей по правилам варианта игры и делит между ними основной и побочные банки
+// (см. Pot.SidePots): каждую часть банка выигрывают сильнейшие руки среди претендующих на нее игроков.
+// В играх хай-лоу каждая часть делится пополам между старшими и младшими руками (нечетная фишка - старшим),
+// а если младшей руки нет, достается старшим. Результат передается в SplitRunouts
+func (t *Table) Showdown() ([]*Runout, error) {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	return t.potRunouts(t.Pot.SidePots(t.contenders()), func(amount Chips) Chips {
+		return amount
+	})
+}
@@ -0,0 +1,256 @@
+package models
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"hands/src/helpers"
+)
+
+func TestShowdownSidePots(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant Variant
+		board   string
+		seats   []testSeat
+		want    map[string]Chips
+	}{
+		{
+			name:    "holdem short all-in",
+			variant: HoldemVariant,
+			board:   "Ah Kd 7c 2s 3d",
+			seats: []testSeat{
+				{name: "short", pocket: "As Ad", chips: 50},
+				{name: "kings", pocket: "Ks Kc", chips: 200},
+				{name: "queens", pocket: "Qs Qc", chips: 200},
+			},
+			want: map[string]Chips{"short": 150, "kings": 300},
+		},
+		{
+			name:    "holdem everyone else folded",
+			variant: HoldemVariant,
+			board:   "Ah Kd 7c",
+			seats: []testSeat{
+				{name: "bettor", pocket: "2s 3c", chips: 40},
+				{name: "folded", pocket: "As Ad", chips: 100, folded: true},
+			},
+			want: map[string]Chips{"bettor": 140},
+		},
+		{
+			name:    "omaha hi-lo splits each pot",
+			variant: OmahaHiLoVariant,
+			board:   "2c 4d 7h Kc Ks",
+			seats: []testSeat{
+				// лучшее лоу 7-4-3-2-A, в хай только пара королей
+				{name: "short", pocket: "As 3s 8d 9d", chips: 50},
+				// сет королей без лоу
+				{name: "trips", pocket: "Kd Qd Jh Th", chips: 200},
+				// лоу 7-5-4-2-A
+				{name: "low", pocket: "Ah 5h Qc Qh", chips: 200},
+			},
+			// основной банк 150: 75 хай и 75 лоу, побочный 300: 150 хай и 150 лоу
+			want: map[string]Chips{"short": 75, "trips": 225, "low": 150},
+		},
+		{
+			name:    "stud short all-in",
+			variant: StudVariant,
+			seats: []testSeat{
+				{name: "aces", pocket: "As Ad Ac 2h 3d 4c 7s", chips: 50},
+				{name: "kings", pocket: "Ks Kd Kc 2s 3h 5c 8d", chips: 200},
+				{name: "queens", pocket: "Qs Qd 9c 8c 6h 4d 2d", chips: 200},
+			},
+			want: map[string]Chips{"aces": 150, "kings": 300},
+		},
+		{
+			name:    "deuce-to-seven triple draw short all-in",
+			variant: TripleDrawVariant,
+			seats: []testSeat{
+				{name: "seven", pocket: "2c 3d 4h 5s 7c", chips: 50},
+				{name: "eight", pocket: "2d 3c 4s 6h 8d", chips: 200},
+				{name: "pair", pocket: "Kc Kd Qs Js 9h", chips: 200},
+			},
+			want: map[string]Chips{"seven": 150, "eight": 300},
+		},
+		{
+			name:    "razz short all-in",
+			variant: RazzVariant,
+			seats: []testSeat{
+				{name: "short", pocket: "As 2s 3s 4s 5s Kd Kc", chips: 50},
+				{name: "six", pocket: "Ad 2d 3d 4d 6d Qh Qd", chips: 200},
+				{name: "jack", pocket: "7c 8c 9c Tc Jc Js Qs", chips: 200},
+			},
+			want: map[string]Chips{"short": 150, "six": 300},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, players := newTestTable(t, tt.variant, tt.board, tt.seats)
+
+			runouts, err := table.Showdown()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := payoutsByName(table.SplitRunouts(runouts), players)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("payouts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrawReshufflesDiscards(t *testing.T) {
+	table, players := newTestTable(t, TripleDrawVariant, "", []testSeat{
+		{name: "drawer", pocket: "2s 3s 4s 5s 7d"},
+		{name: "other", pocket: "8c 9c Kd Qd Jd"},
+	})
+
+	// в колоде две карты, в сбросе - две карты прошлого обмена
+	table.deck = newDeckFromCards(MustParse("Tc Jc"))
+	table.deck.Muck(MustParse("Qh Kh")...)
+
+	drawer := players["drawer"]
+	table.drawing = true
+	table.CurrentMove = table.playerIndex(drawer.ID)
+
+	discards := MustParse("2s 3s 4s")
+
+	drawn, err := table.Draw(drawer.ID, discards)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(drawn) != len(discards) {
+		t.Fatalf("drawn %v, want %d cards", drawn, len(discards))
+	}
+
+	pocket := NewCardSet(drawer.GetPocketCards()...)
+	if pocket.Len() != len(drawer.GetPocketCards()) || pocket.Len() != 5 {
+		t.Errorf("pocket after draw = %v", drawer.GetPocketCards())
+	}
+
+	if want := NewCardSet(MustParse("5s 7d Tc Jc")...); pocket.Intersect(want) != want {
+		t.Errorf("pocket after draw = %v, want the stub and one reshuffled card", drawer.GetPocketCards())
+	}
+
+	if pocket.Intersect(NewCardSet(discards...)) != 0 {
+		t.Errorf("player got own discards back: %v", drawer.GetPocketCards())
+	}
+
+	if next := table.Players[table.CurrentMove]; next != players["other"] {
+		t.Errorf("next drawer = %s, want other", next.Name)
+	}
+}
+
+// checkRound проводит круг торговли чеками и коллами
+func checkRound(t *testing.T, table *Table) {
+	t.Helper()
+
+	for p := table.NextToAct(); p != nil && !table.IsDrawing(); p = table.NextToAct() {
+		action := CheckAction
+		if table.CurrentBet > table.GetRoundBet(p.ID) {
+			action = CallAction
+		}
+
+		if _, err := table.Act(p.ID, action, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPineappleDiscard(t *testing.T) {
+	tests := []struct {
+		variant Variant
+		street  Street
+		// board, after - количество общих карт до и после сброса
+		board, after int
+	}{
+		{variant: PineappleVariant, street: FlopStreet, board: 0, after: CardsOnFlopNumber},
+		{variant: CrazyPineappleVariant, street: TurnStreet, board: CardsOnFlopNumber, after: CardsOnFlopNumber + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variant.Name(), func(t *testing.T) {
+			table := NewTable("test", "t", helpers.NewDefaultIdGenerator(), CasheTableType, 2, 10, 5).
+				WithRandom(rand.New(rand.NewSource(1))).
+				WithVariant(tt.variant)
+
+			for i := 0; i < 2; i++ {
+				if err := table.Register(NewPlayer("p", helpers.NewDefaultIdGenerator(), 1000)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := table.NewHand(); err != nil {
+				t.Fatal(err)
+			}
+
+			discarded := false
+
+			for _, deal := range table.GetDealFuncs() {
+				if _, err := deal(); err != nil {
+					t.Fatal(err)
+				}
+
+				if !table.IsDrawing() {
+					p := table.NextToAct()
+					if err := table.Discard(p.ID, p.GetPocketCards()[:1]); err == nil {
+						t.Errorf("Discard() accepted a discard on %s", table.Street)
+					}
+
+					checkRound(t, table)
+
+					continue
+				}
+
+				if table.Street != tt.street || len(table.Board) != tt.board {
+					t.Fatalf("discard started on %s with %d board cards, want %s with %d",
+						table.Street, len(table.Board), tt.street, tt.board)
+				}
+
+				p := table.NextToAct()
+				pocket := p.GetPocketCards()
+
+				if err := table.Discard(p.ID, pocket[:2]); err == nil {
+					t.Error("Discard() accepted two cards")
+				}
+
+				if err := table.Discard(p.ID, nil); err == nil {
+					t.Error("Discard() accepted no cards")
+				}
+
+				if _, err := table.Draw(p.ID, pocket[:1]); err == nil {
+					t.Error("Draw() accepted a draw instead of a discard")
+				}
+
+				for p := table.NextToAct(); table.IsDrawing(); p = table.NextToAct() {
+					if err := table.Discard(p.ID, p.GetPocketCards()[:1]); err != nil {
+						t.Fatal(err)
+					}
+
+					if len(p.GetPocketCards()) != PocketSize {
+						t.Errorf("pocket after discard = %v", p.GetPocketCards())
+					}
+				}
+
+				if len(table.Board) != tt.after {
+					t.Errorf("board after discards = %v, want %d cards", table.Board, tt.after)
+				}
+
+				discarded = true
+
+				checkRound(t, table)
+			}
+
+			if !discarded {
+				t.Errorf("no discard in %s", tt.variant.Name())
+			}
+
+			if len(table.Board) != BoardSize {
+				t.Errorf("board = %v, want %d cards", table.Board, BoardSize)
+			}
+		})
+	}
+}
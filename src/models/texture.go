@@ -0,0 +1,254 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Pairing - спаренность общих карт
+type Pairing string
+
+const (
+	UnpairedBoard  Pairing = "unpaired"
+	PairedBoard    Pairing = "paired"
+	TwoPairedBoard Pairing = "two-paired"
+	TripsBoard     Pairing = "trips"
+	FullHouseBoard Pairing = "full house"
+	QuadsBoard     Pairing = "quads"
+)
+
+// SuitTexture - распределение мастей общих карт
+type SuitTexture string
+
+const (
+	// MonotoneBoard - все карты одной масти
+	MonotoneBoard SuitTexture = "monotone"
+	// TwoToneBoard - есть хотя бы две карты одной масти, но не все
+	TwoToneBoard SuitTexture = "two-tone"
+	// RainbowBoard - все карты разных мастей
+	RainbowBoard SuitTexture = "rainbow"
+)
+
+// Connectedness - связанность общих карт: сколько их помещается в окно из пяти значений подряд
+type Connectedness string
+
+const (
+	// DryBoard - никакие две карты не помещаются в одно окно стрита
+	DryBoard Connectedness = "dry"
+	// SemiConnectedBoard - две карты помещаются в одно окно стрита: возможны стрит-дро
+	SemiConnectedBoard Connectedness = "semi-connected"
+	// ConnectedBoard - три и больше карт помещаются в одно окно стрита: возможен стрит
+	ConnectedBoard Connectedness = "connected"
+)
+
+// HighCardClass - класс старшей общей карты
+type HighCardClass string
+
+const (
+	AceHighBoard  HighCardClass = "ace-high"
+	BroadwayBoard HighCardClass = "broadway"
+	MiddleBoard   HighCardClass = "middle"
+	LowBoard      HighCardClass = "low"
+)
+
+// BoardTexture - текстура общих карт
+type BoardTexture struct {
+	Board   []*Card
+	Pairing Pairing
+	Suits   SuitTexture
+	// FlushPossible - соперник может собрать флэш
+	FlushPossible bool
+	// FlushDrawPossible - соперник может иметь флэш-дро (еще не все общие карты открыты)
+	FlushDrawPossible bool
+	Connectedness     Connectedness
+	// PossibleStraights - старшие карты стритов, которые можно собрать с двумя карманными картами
+	PossibleStraights []CardValue
+	HighCard          CardValue
+	HighCardClass     HighCardClass
+	// Nuts - сильнейшая возможная рука
+	Nuts HandRank
+	// NutPockets - карманные карты, дающие сильнейшую возможную руку
+	NutPockets [][]*Card
+
+	// ranks - все возможные при этих общих картах руки, по убыванию
+	ranks []HandRank
+}
+
+// AnalyzeBoard определяет текстуру общих карт board (от 3 до 5 карт)
+func AnalyzeBoard(board []*Card) (*BoardTexture, error) {
+	if len(board) < CardsOnFlopNumber || len(board) > BoardSize {
+		return nil, fmt.Errorf("Board texture requires %d to %d cards, got %d", CardsOnFlopNumber, BoardSize, len(board))
+	}
+
+	t := &BoardTexture{
+		Board:         append(make([]*Card, 0, BoardSize), board...),
+		Pairing:       boardPairing(board),
+		Connectedness: DryBoard,
+	}
+
+	maxSuite := 0
+	for _, suite := range Suites {
+		if n := suiteCount(board, suite); n > maxSuite {
+			maxSuite = n
+		}
+	}
+
+	switch {
+	case maxSuite == len(board):
+		t.Suits = MonotoneBoard
+	case maxSuite == 1:
+		t.Suits = RainbowBoard
+	default:
+		t.Suits = TwoToneBoard
+	}
+
+	t.FlushPossible = maxSuite >= HandSize-PocketSize
+	t.FlushDrawPossible = len(board) < BoardSize && maxSuite >= HandSize-PocketSize-1
+
+	mask := valuesMask(board)
+	if mask&(1<<Ace) != 0 {
+		mask |= 1 << 1
+	}
+
+	for high := Ace; high >= Five; high-- {
+		n := bitsCount(mask & (uint16(0x1f) << (high - 4)))
+
+		switch {
+		case n >= HandSize-PocketSize:
+			t.Connectedness = ConnectedBoard
+			t.PossibleStraights = append(t.PossibleStraights, high)
+		case n == HandSize-PocketSize-1 && t.Connectedness == DryBoard:
+			t.Connectedness = SemiConnectedBoard
+		}
+	}
+
+	for _, c := range board {
+		if c.Value > t.HighCard {
+			t.HighCard = c.Value
+		}
+	}
+
+	switch {
+	case t.HighCard == Ace:
+		t.HighCardClass = AceHighBoard
+	case t.HighCard >= Ten:
+		t.HighCardClass = BroadwayBoard
+	case t.HighCard >= Seven:
+		t.HighCardClass = MiddleBoard
+	default:
+		t.HighCardClass = LowBoard
+	}
+
+	t.rankHands()
+
+	return t, nil
+}
+
+// boardPairing определяет спаренность карт
+func boardPairing(board []*Card) Pairing {
+	counts := make(map[CardValue]int)
+	pairs, trips := 0, 0
+
+	for _, c := range board {
+		counts[c.Value]++
+	}
+
+	for _, n := range counts {
+		switch n {
+		case 4:
+			return QuadsBoard
+		case 3:
+			trips++
+		case 2:
+			pairs++
+		}
+	}
+
+	switch {
+	case trips > 0 && pairs > 0:
+		return FullHouseBoard
+	case trips > 0:
+		return TripsBoard
+	case pairs > 1:
+		return TwoPairedBoard
+	case pairs == 1:
+		return PairedBoard
+	}
+
+	return UnpairedBoard
+}
+
+// rankHands находит все возможные руки и натсы
+func (t *BoardTexture) rankHands() {
+	seen := make(map[HandRank]struct{})
+	cards := make([]*Card, 0, BoardSize+PocketSize)
+
+	for _, pocket := range UniformRange(t.Board) {
+		rank := RankCards(append(append(cards[:0], t.Board...), pocket...))
+
+		switch {
+		case rank > t.Nuts:
+			t.Nuts = rank
+			t.NutPockets = [][]*Card{pocket}
+		case rank == t.Nuts:
+			t.NutPockets = append(t.NutPockets, pocket)
+		}
+
+		if _, ok := seen[rank]; !ok {
+			seen[rank] = struct{}{}
+			t.ranks = append(t.ranks, rank)
+		}
+	}
+
+	sort.Slice(t.ranks, func(i, j int) bool {
+		return t.ranks[i] > t.ranks[j]
+	})
+}
+
+// NutRank возвращает место руки pocket среди всех возможных при этих общих картах рук:
+// 1 - натсы, 2 - вторые натсы и т.д. Руки, различающиеся только мастями, считаются одинаковыми
+func (t *BoardTexture) NutRank(pocket []*Card) (int, error) {
+	if len(pocket) != PocketSize {
+		return 0, fmt.Errorf("Nut rank requires %d pocket cards, got %d", PocketSize, len(pocket))
+	}
+
+	board := NewCardSet(t.Board...)
+
+	for _, c := range pocket {
+		if board.Contains(c) {
+			return 0, fmt.Errorf("Card %s is on the board already", c)
+		}
+	}
+
+	rank := RankCards(append(append(make([]*Card, 0, BoardSize+PocketSize), t.Board...), pocket...))
+
+	return sort.Search(len(t.ranks), func(i int) bool {
+		return t.ranks[i] <= rank
+	}) + 1, nil
+}
+
+// IsNuts проверяет, составляют ли карманные карты pocket сильнейшую возможную руку
+func (t *BoardTexture) IsNuts(pocket []*Card) bool {
+	rank, err := t.NutRank(pocket)
+
+	return err == nil && rank == 1
+}
+
+// String описывает текстуру: общие карты, их признаки и натсы
+func (t *BoardTexture) String() string {
+	features := []string{string(t.Pairing), string(t.Suits), string(t.Connectedness), string(t.HighCardClass)}
+
+	if t.FlushPossible {
+		features = append(features, "flush possible")
+	} else if t.FlushDrawPossible {
+		features = append(features, "flush draw possible")
+	}
+
+	if len(t.PossibleStraights) > 0 {
+		features = append(features, "straight possible")
+	}
+
+	return fmt.Sprintf("%s: %s; nuts: %v", strings.Join(NewStringSliceFromCards(t.Board), " "),
+		strings.Join(features, ", "), t.Nuts)
+}
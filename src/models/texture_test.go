@@ -0,0 +1,164 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeBoard(t *testing.T) {
+	tests := []struct {
+		board         string
+		pairing       Pairing
+		suits         SuitTexture
+		flush         bool
+		flushDraw     bool
+		connectedness Connectedness
+		straights     []CardValue
+		high          HighCardClass
+	}{
+		{
+			board: "2c 7h Kd", pairing: UnpairedBoard, suits: RainbowBoard,
+			connectedness: DryBoard, high: BroadwayBoard,
+		},
+		{
+			board: "Ah 7h 2h", pairing: UnpairedBoard, suits: MonotoneBoard, flush: true, flushDraw: true,
+			connectedness: SemiConnectedBoard, high: AceHighBoard,
+		},
+		{
+			board: "9h 8h 7c", pairing: UnpairedBoard, suits: TwoToneBoard, flushDraw: true,
+			connectedness: ConnectedBoard, straights: []CardValue{Jack, Ten, Nine}, high: MiddleBoard,
+		},
+		{
+			board: "Ks Kd 7d", pairing: PairedBoard, suits: TwoToneBoard, flushDraw: true,
+			connectedness: DryBoard, high: BroadwayBoard,
+		},
+		{
+			board: "7s 7d 7h", pairing: TripsBoard, suits: RainbowBoard,
+			connectedness: DryBoard, high: MiddleBoard,
+		},
+		{
+			board: "7s 7d 4d 4c", pairing: TwoPairedBoard, suits: TwoToneBoard, flushDraw: true,
+			connectedness: SemiConnectedBoard, high: MiddleBoard,
+		},
+		// на ривере флэш-дро уже невозможно, а колесо и стрит до шестерки собираются с двумя картами
+		{
+			board: "Ac 2d 3h 5h 5s", pairing: PairedBoard, suits: TwoToneBoard,
+			connectedness: ConnectedBoard, straights: []CardValue{Six, Five}, high: AceHighBoard,
+		},
+	}
+
+	for _, tt := range tests {
+		texture, err := AnalyzeBoard(MustParse(tt.board))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := []interface{}{texture.Pairing, texture.Suits, texture.FlushPossible, texture.FlushDrawPossible,
+			texture.Connectedness, texture.HighCardClass}
+		want := []interface{}{tt.pairing, tt.suits, tt.flush, tt.flushDraw, tt.connectedness, tt.high}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("AnalyzeBoard(%s) = %v, want %v", tt.board, got, want)
+		}
+
+		if !reflect.DeepEqual(texture.PossibleStraights, tt.straights) {
+			t.Errorf("AnalyzeBoard(%s) straights = %v, want %v", tt.board, texture.PossibleStraights, tt.straights)
+		}
+	}
+
+	for _, board := range []string{"2c 7h", "2c 7h Kd 9s 4h 5c"} {
+		if _, err := AnalyzeBoard(MustParse(board)); err == nil {
+			t.Errorf("AnalyzeBoard(%s) accepted a wrong board", board)
+		}
+	}
+}
+
+func TestNuts(t *testing.T) {
+	tests := []struct {
+		board string
+		nuts  HandValue
+		// pockets - количество сочетаний карманных карт, дающих натсы
+		pockets int
+		// example - одно из сочетаний, дающих натсы
+		example string
+	}{
+		// три оставшихся короля дают три сочетания сета
+		{board: "2c 7h Kd", nuts: ThreeHand, pockets: 3, example: "Ks Kh"},
+		{board: "Ah 7h 2h", nuts: FlushHand, pockets: 1, example: "Kh Qh"},
+		{board: "9h 8h 7h", nuts: StraightFlushHand, pockets: 1, example: "Jh Th"},
+		{board: "9h 8h 7c", nuts: StraightHand, pockets: 16, example: "Js Td"},
+		{board: "Ks Kd 7h", nuts: FourHand, pockets: 1, example: "Kh Kc"},
+		// каре семерок с тузом в кикере
+		{board: "7s 7d 7h", nuts: FourHand, pockets: 4, example: "7c As"},
+	}
+
+	for _, tt := range tests {
+		texture, err := AnalyzeBoard(MustParse(tt.board))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if texture.Nuts.Value() != tt.nuts || len(texture.NutPockets) != tt.pockets {
+			t.Errorf("AnalyzeBoard(%s) nuts = %v with %d pockets, want %v with %d",
+				tt.board, texture.Nuts.Value(), len(texture.NutPockets), tt.nuts, tt.pockets)
+		}
+
+		if !texture.IsNuts(MustParse(tt.example)) {
+			t.Errorf("%s is not the nuts on %s", tt.example, tt.board)
+		}
+	}
+}
+
+func TestNutRank(t *testing.T) {
+	tests := []struct {
+		board  string
+		pocket string
+		want   int
+	}{
+		{board: "2c 7h Kd", pocket: "Ks Kh", want: 1},
+		{board: "2c 7h Kd", pocket: "7s 7d", want: 2},
+		{board: "2c 7h Kd", pocket: "2s 2d", want: 3},
+		{board: "2c 7h Kd", pocket: "Ks 7d", want: 4},
+		{board: "2c 7h Kd", pocket: "Ks 2d", want: 5},
+		{board: "2c 7h Kd", pocket: "7s 2d", want: 6},
+		// масти не различаются: пара тузов с любыми мастями - седьмая рука
+		{board: "2c 7h Kd", pocket: "As Ad", want: 7},
+		{board: "2c 7h Kd", pocket: "Ah Ac", want: 7},
+		{board: "9h 8h 7c", pocket: "Js Td", want: 1},
+		{board: "9h 8h 7c", pocket: "Ts 6d", want: 2},
+		{board: "9h 8h 7c", pocket: "6s 5d", want: 3},
+	}
+
+	for _, tt := range tests {
+		texture, err := AnalyzeBoard(MustParse(tt.board))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := texture.NutRank(MustParse(tt.pocket))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.want {
+			t.Errorf("NutRank(%s) on %s = %d, want %d", tt.pocket, tt.board, got, tt.want)
+		}
+	}
+
+	texture, err := AnalyzeBoard(MustParse("2c 7h Kd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := texture.NutRank(MustParse("As Ad Ac")); err == nil {
+		t.Error("NutRank() accepted three pocket cards")
+	}
+
+	if _, err := texture.NutRank(MustParse("Kd As")); err == nil {
+		t.Error("NutRank() accepted a card from the board")
+	}
+
+	if texture.IsNuts(MustParse("Kd As")) {
+		t.Error("IsNuts() accepted a card from the board")
+	}
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ThreeCardSize - количество карт в руке трехкарточного покера и в верхнем ряду китайского покера
+const ThreeCardSize = 3
+
+// ThreeCardValue - величина руки из трех карт в Three Card Poker. Стрит из трех карт собрать труднее,
+// чем флэш, поэтому он старше флэша, а тройка старше стрита
+type ThreeCardValue int
+
+const (
+	ThreeCardHighCard      ThreeCardValue = iota // Старшая карта
+	ThreeCardPair                                // Пара
+	ThreeCardFlush                               // Флэш
+	ThreeCardStraight                            // Стрит
+	ThreeCardTrips                               // Тройка
+	ThreeCardStraightFlush                       // Стрит-флэш
+)
+
+var threeCardValueNames = []string{"High Card", "Pair", "Flush", "Straight", "Three of a Kind", "Straight Flush"}
+
+func (v ThreeCardValue) String() string {
+	if v < ThreeCardHighCard || int(v) >= len(threeCardValueNames) {
+		return fmt.Sprintf("ThreeCardValue(%d)", int(v))
+	}
+
+	return threeCardValueNames[v]
+}
+
+// ThreeCardRank - числовая оценка руки Three Card Poker: чем больше, тем сильнее рука.
+// Устроена как HandRank: старшие биты содержат ThreeCardValue, младшие - значения трех карт
+type ThreeCardRank int32
+
+// Value возвращает величину руки
+func (r ThreeCardRank) Value() ThreeCardValue {
+	return ThreeCardValue(r >> (rankValueBits * ThreeCardSize))
+}
+
+// Compare сравнивает руки так же, как Hand.Compare: положительное значение, если r сильнее other,
+// отрицательное, если слабее, и ноль при равенстве
+func (r ThreeCardRank) Compare(other ThreeCardRank) int {
+	return int(r) - int(other)
+}
+
+// values возвращает значения карт, определяющие старшинство руки
+func (r ThreeCardRank) values() []CardValue {
+	values := make([]CardValue, 0, ThreeCardSize)
+
+	for i := ThreeCardSize - 1; i >= 0; i-- {
+		if v := CardValue(r >> (rankValueBits * i) & (1<<rankValueBits - 1)); v != 0 {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// High возвращает значение карты, определяющей комбинацию: пары, тройки или старшей карты
+func (r ThreeCardRank) High() CardValue {
+	if values := r.values(); len(values) > 0 {
+		return values[0]
+	}
+
+	return 0
+}
+
+// String возвращает величину руки и значения карт, например "Straight 3-2-A" или "Pair Q-7"
+func (r ThreeCardRank) String() string {
+	values := r.values()
+	names := make([]string, len(values))
+
+	for i, v := range values {
+		if v == lowAce {
+			v = Ace
+		}
+
+		names[i] = string(startingHandValues[v-Two])
+	}
+
+	return r.Value().String() + " " + strings.Join(names, "-")
+}
+
+func newThreeCardRank(value ThreeCardValue, values ...CardValue) ThreeCardRank {
+	r := ThreeCardRank(value)
+
+	for i := 0; i < ThreeCardSize; i++ {
+		r <<= rankValueBits
+
+		if i < len(values) {
+			r |= ThreeCardRank(values[i])
+		}
+	}
+
+	return r
+}
+
+// ValidateThreeCardHand проверяет, что hand - рука из трех разных карт
+func ValidateThreeCardHand(hand []*Card) error {
+	if len(hand) != ThreeCardSize {
+		return errors.New("Hand must contains 3 cards only")
+	}
+
+	if countDuplicates(hand) > 0 {
+		return errors.New("Hand must not contains duplicates")
+	}
+
+	return nil
+}
+
+// RankThreeCards оценивает руку из трех карт без стритов и флэшей, как верхний ряд китайского покера.
+// Оценка в шкале HandRank сравнима с оценками рук из пяти карт RankCards: тройка старше двух пар,
+// а пара дам из трех карт слабее пары дам из пяти карт с теми же старшими кикерами
+func RankThreeCards(cards []*Card) (HandRank, error) {
+	if err := ValidateThreeCardHand(cards); err != nil {
+		return 0, err
+	}
+
+	values := make([]CardValue, ThreeCardSize)
+
+	for i, c := range cards {
+		values[i] = c.Value
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] > values[j]
+	})
+
+	switch {
+	case values[0] == values[2]:
+		return newHandRank(ThreeHand, values[0]), nil
+	case values[0] == values[1]:
+		return newHandRank(PairHand, values[0], values[2]), nil
+	case values[1] == values[2]:
+		return newHandRank(PairHand, values[1], values[0]), nil
+	}
+
+	return newHandRank(HighCardHand, values...), nil
+}
+
+// RankThreeCardPoker оценивает руку из трех карт по правилам Three Card Poker: стриты и флэши
+// из трех карт учитываются, A-2-3 - младший стрит
+func RankThreeCardPoker(cards []*Card) (ThreeCardRank, error) {
+	if err := ValidateThreeCardHand(cards); err != nil {
+		return 0, err
+	}
+
+	values := make([]CardValue, ThreeCardSize)
+	flush := true
+
+	for i, c := range cards {
+		values[i] = c.Value
+		flush = flush && c.Suite.Suite == cards[0].Suite.Suite
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] > values[j]
+	})
+
+	straight := values[0] == values[1]+1 && values[1] == values[2]+1
+
+	// A-2-3: туз играет как младшая карта
+	if values[0] == Ace && values[1] == Three && values[2] == Two {
+		straight, values = true, []CardValue{Three, Two, lowAce}
+	}
+
+	switch {
+	case straight && flush:
+		return newThreeCardRank(ThreeCardStraightFlush, values...), nil
+	case values[0] == values[2]:
+		return newThreeCardRank(ThreeCardTrips, values[0]), nil
+	case straight:
+		return newThreeCardRank(ThreeCardStraight, values...), nil
+	case flush:
+		return newThreeCardRank(ThreeCardFlush, values...), nil
+	case values[0] == values[1]:
+		return newThreeCardRank(ThreeCardPair, values[0], values[2]), nil
+	case values[1] == values[2]:
+		return newThreeCardRank(ThreeCardPair, values[1], values[0]), nil
+	}
+
+	return newThreeCardRank(ThreeCardHighCard, values...), nil
+}
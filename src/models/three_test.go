@@ -0,0 +1,130 @@
+package models
+
+import "testing"
+
+func TestRankThreeCards(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards string
+		value HandValue
+		high  CardValue
+	}{
+		{name: "trips", cards: "5h 5d 5c", value: ThreeHand, high: Five},
+		{name: "pair on top", cards: "Qh Qd 7c", value: PairHand, high: Queen},
+		{name: "pair below kicker", cards: "Ah 6d 6c", value: PairHand, high: Six},
+		{name: "no straights", cards: "Qh Jh Th", value: HighCardHand, high: Queen},
+		{name: "high card", cards: "Kh 9d 2c", value: HighCardHand, high: King},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, err := RankThreeCards(MustParse(tt.cards))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rank.Value() != tt.value || rank.High() != tt.high {
+				t.Errorf("RankThreeCards(%s) = %v %v, want %v %v", tt.cards, rank.Value(), rank.High(), tt.value, tt.high)
+			}
+		})
+	}
+}
+
+func TestRankThreeCardsOrder(t *testing.T) {
+	// каждая рука сильнее следующей; руки из пяти карт оцениваются RankCards
+	hands := []string{
+		"Kh Kd Kc",
+		"Ah Ad 3c 3s 2h",
+		"Qh Qd 7c 5s 4h",
+		"Qh Qd 7c",
+		"Qs Qc 6c",
+		"Ah 6d 6c",
+		"Ah Kd Qc",
+		"Ah Kd Jc",
+	}
+
+	ranks := make([]HandRank, len(hands))
+
+	for i, h := range hands {
+		cards := MustParse(h)
+
+		if len(cards) == ThreeCardSize {
+			rank, err := RankThreeCards(cards)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ranks[i] = rank
+		} else {
+			ranks[i] = RankCards(cards)
+		}
+	}
+
+	for i := 1; i < len(ranks); i++ {
+		if ranks[i-1] <= ranks[i] {
+			t.Errorf("%s is not stronger than %s", hands[i-1], hands[i])
+		}
+	}
+}
+
+func TestRankThreeCardsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards []*Card
+	}{
+		{name: "two cards", cards: MustParse("Ah Kd")},
+		{name: "four cards", cards: MustParse("Ah Kd Qc Js")},
+		{name: "duplicate", cards: append(MustParse("Ah Kd"), MustParse("Ah")...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RankThreeCards(tt.cards); err == nil {
+				t.Error("RankThreeCards accepted the hand")
+			}
+
+			if _, err := RankThreeCardPoker(tt.cards); err == nil {
+				t.Error("RankThreeCardPoker accepted the hand")
+			}
+		})
+	}
+}
+
+func TestRankThreeCardPoker(t *testing.T) {
+	// руки по убыванию силы
+	tests := []struct {
+		cards string
+		want  string
+	}{
+		{cards: "Qh Kh Ah", want: "Straight Flush A-K-Q"},
+		{cards: "2s 3s As", want: "Straight Flush 3-2-A"},
+		{cards: "7h 7d 7c", want: "Three of a Kind 7"},
+		{cards: "Qh Kd Ac", want: "Straight A-K-Q"},
+		{cards: "2h 3d Ac", want: "Straight 3-2-A"},
+		{cards: "Ah 9h 2h", want: "Flush A-9-2"},
+		{cards: "Kd 8d 3d", want: "Flush K-8-3"},
+		{cards: "Ah Ad 2c", want: "Pair A-2"},
+		{cards: "Kh 4d 4c", want: "Pair 4-K"},
+		{cards: "Ah Kd Jc", want: "High Card A-K-J"},
+		{cards: "Qh 9d 2c", want: "High Card Q-9-2"},
+	}
+
+	var prev ThreeCardRank
+
+	for i, tt := range tests {
+		rank, err := RankThreeCardPoker(MustParse(tt.cards))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rank.String() != tt.want {
+			t.Errorf("RankThreeCardPoker(%s) = %s, want %s", tt.cards, rank, tt.want)
+		}
+
+		if i > 0 && prev.Compare(rank) <= 0 {
+			t.Errorf("%s is not stronger than %s", tests[i-1].cards, tt.cards)
+		}
+
+		prev = rank
+	}
+}
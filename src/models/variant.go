@@ -0,0 +1,467 @@
+package models
+
+import "fmt"
+
+// BettingStructure - структура ставок
+type BettingStructure string
+
+const (
+	NoLimit  BettingStructure = "no-limit"
+	PotLimit BettingStructure = "pot-limit"
+	// FixedLimit - лимит: малая ставка равна большому блайнду, большая - двум большим блайндам
+	FixedLimit BettingStructure = "fixed-limit"
+)
+
+// MaxLimitBets - максимальное количество ставок и повышений на улице в лимитной игре
+const MaxLimitBets = 4
+
+// Улицы стада
+const (
+	ThirdStreet   Street = "third"
+	FourthStreet  Street = "fourth"
+	FifthStreet   Street = "fifth"
+	SixthStreet   Street = "sixth"
+	SeventhStreet Street = "seventh"
+)
+
+// Круги торговли дро-покера: до обменов и после каждого обмена
+const (
+	PreDrawStreet    Street = "predraw"
+	FirstDrawStreet  Street = "draw1"
+	SecondDrawStreet Street = "draw2"
+	ThirdDrawStreet  Street = "draw3"
+)
+
+// StreetSpec - описание улицы варианта игры
+type StreetSpec struct {
+	Street Street
+	// Board - количество общих карт, выкладываемых на улице
+	Board int
+	// Down, Up - количество закрытых и открытых карт, раздаваемых каждому игроку
+	Down int
+	Up   int
+	// Draw - перед кругом торговли игроки меняют карты
+	Draw bool
+	// Discard - перед выкладкой общих карт улицы каждый игрок сбрасывает столько карманных карт
+	Discard int
+	// BigBet - в лимитной игре ставка на улице равна большой ставке
+	BigBet bool
+}
+
+// Variant - вариант покера: состав колоды, количество карт у игрока, улицы, структура ставок и определение
+// победителей. Стол раздает карты и ведет торговлю по описанию варианта
+type Variant interface {
+	Name() string
+	// Deck возвращает карты колоды варианта
+	Deck() []*Card
+	// PocketSize возвращает наибольшее количество карт у игрока за раздачу
+	PocketSize() int
+	Streets() []StreetSpec
+	Betting() BettingStructure
+	// BringIn определяет, что вместо блайндов игрок с младшей открытой картой вносит bring-in
+	BringIn() bool
+	// VisibleStrength оценивает открытые карты игрока: на первой улице bring-in вносит игрок с наименьшей
+	// оценкой, на следующих первым действует игрок с наибольшей
+	VisibleStrength(up []*Card) int
+	// Showdown возвращает победителей частей банка, индексы в pockets: одну группу, или две - хай и лоу.
+	// Банк делится между непустыми группами поровну
+	Showdown(pockets [][]*Card, board []*Card) [][]int
+	// ShowdownHand возвращает руку игрока, по которой он претендует на банк (в хай-лоу - старшую),
+	// для описания и записи раздачи. В лоуболе это лучшая низкая рука. Ноль, если руку не собрать
+	ShowdownHand(pocket, board []*Card) HandRank
+}
+
+// gameVariant - вариант игры, заданный описанием улиц и функциями оценки рук
+type gameVariant struct {
+	name       string
+	pocketSize int
+	streets    []StreetSpec
+	betting    BettingStructure
+	bringIn    bool
+	// high - оценка старшей руки, чем больше, тем сильнее
+	high func(pocket, board []*Card) int
+	// low - оценка младшей руки для игр хай-лоу; false, если рука не проходит в лоу
+	low     func(pocket, board []*Card) (int, bool)
+	visible func(up []*Card) int
+	// hand - рука для описания, если high оценивает ее не как HandRank (лоубол)
+	hand func(pocket, board []*Card) HandRank
+}
+
+func (v *gameVariant) Name() string {
+	return v.name
+}
+
+func (v *gameVariant) Deck() []*Card {
+	return makeOrderedCards()
+}
+
+func (v *gameVariant) PocketSize() int {
+	return v.pocketSize
+}
+
+func (v *gameVariant) Streets() []StreetSpec {
+	return append(make([]StreetSpec, 0, len(v.streets)), v.streets...)
+}
+
+func (v *gameVariant) Betting() BettingStructure {
+	return v.betting
+}
+
+func (v *gameVariant) BringIn() bool {
+	return v.bringIn
+}
+
+func (v *gameVariant) VisibleStrength(up []*Card) int {
+	if v.visible == nil {
+		return 0
+	}
+
+	return v.visible(up)
+}
+
+func (v *gameVariant) Showdown(pockets [][]*Card, board []*Card) [][]int {
+	high := make([]int, 0)
+	best := 0
+
+	for i, pocket := range pockets {
+		strength := v.high(pocket, board)
+
+		switch {
+		case len(high) == 0 || strength > best:
+			best, high = strength, []int{i}
+		case strength == best:
+			high = append(high, i)
+		}
+	}
+
+	if v.low == nil {
+		return [][]int{high}
+	}
+
+	low := make([]int, 0)
+
+	for i, pocket := range pockets {
+		strength, ok := v.low(pocket, board)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case len(low) == 0 || strength > best:
+			best, low = strength, []int{i}
+		case strength == best:
+			low = append(low, i)
+		}
+	}
+
+	return [][]int{high, low}
+}
+
+func (v *gameVariant) ShowdownHand(pocket, board []*Card) HandRank {
+	if v.hand != nil {
+		return v.hand(pocket, board)
+	}
+
+	return HandRank(v.high(pocket, board))
+}
+
+func (v *gameVariant) String() string {
+	return v.name
+}
+
+var holdemStreets = []StreetSpec{
+	{Street: PreFlopStreet, Down: PocketSize},
+	{Street: FlopStreet, Board: CardsOnFlopNumber},
+	{Street: TurnStreet, Board: 1, BigBet: true},
+	{Street: RiverStreet, Board: 1, BigBet: true},
+}
+
+// OmahaPocketSize - количество карманных карт в омахе
+const OmahaPocketSize = 4
+
+var omahaStreets = []StreetSpec{
+	{Street: PreFlopStreet, Down: OmahaPocketSize},
+	{Street: FlopStreet, Board: CardsOnFlopNumber},
+	{Street: TurnStreet, Board: 1, BigBet: true},
+	{Street: RiverStreet, Board: 1, BigBet: true},
+}
+
+// PineapplePocketSize - количество карманных карт, раздаваемых в пайнэппле
+const PineapplePocketSize = 3
+
+// pineappleStreets возвращает улицы пайнэппла, в котором лишняя карманная карта сбрасывается
+// перед выкладкой улицы discardStreet
+func pineappleStreets(discardStreet Street) []StreetSpec {
+	streets := []StreetSpec{
+		{Street: PreFlopStreet, Down: PineapplePocketSize},
+		{Street: FlopStreet, Board: CardsOnFlopNumber},
+		{Street: TurnStreet, Board: 1, BigBet: true},
+		{Street: RiverStreet, Board: 1, BigBet: true},
+	}
+
+	for i := range streets {
+		if streets[i].Street == discardStreet {
+			streets[i].Discard = PineapplePocketSize - PocketSize
+		}
+	}
+
+	return streets
+}
+
+// StudCardsNum - количество карт у игрока в стаде
+const StudCardsNum = 7
+
+var studStreets = []StreetSpec{
+	{Street: ThirdStreet, Down: 2, Up: 1},
+	{Street: FourthStreet, Up: 1},
+	{Street: FifthStreet, Up: 1, BigBet: true},
+	{Street: SixthStreet, Up: 1, BigBet: true},
+	{Street: SeventhStreet, Down: 1, BigBet: true},
+}
+
+func drawStreets(draws int) []StreetSpec {
+	streets := []StreetSpec{{Street: PreDrawStreet, Down: HandSize}}
+
+	for i, street := range []Street{FirstDrawStreet, SecondDrawStreet, ThirdDrawStreet}[:draws] {
+		streets = append(streets, StreetSpec{Street: street, Draw: true, BigBet: i+1 >= (draws+1)/2})
+	}
+
+	return streets
+}
+
+var (
+	HoldemVariant Variant = &gameVariant{
+		name: "NLHE", pocketSize: PocketSize, streets: holdemStreets, betting: NoLimit, high: holdemHigh,
+	}
+	LimitHoldemVariant Variant = &gameVariant{
+		name: "LHE", pocketSize: PocketSize, streets: holdemStreets, betting: FixedLimit, high: holdemHigh,
+	}
+	OmahaVariant Variant = &gameVariant{
+		name: "PLO", pocketSize: OmahaPocketSize, streets: omahaStreets, betting: PotLimit, high: omahaHigh,
+	}
+	OmahaHiLoVariant Variant = &gameVariant{
+		name: "O8", pocketSize: OmahaPocketSize, streets: omahaStreets, betting: FixedLimit,
+		high: omahaHigh, low: omahaLow,
+	}
+	// PineappleVariant - холдем с тремя карманными картами, одна сбрасывается перед флопом
+	PineappleVariant Variant = &gameVariant{
+		name: "Pineapple", pocketSize: PineapplePocketSize, streets: pineappleStreets(FlopStreet), betting: NoLimit,
+		high: pineappleHigh,
+	}
+	// CrazyPineappleVariant - пайнэппл, в котором лишняя карта сбрасывается после флопа, перед терном
+	CrazyPineappleVariant Variant = &gameVariant{
+		name: "Crazy Pineapple", pocketSize: PineapplePocketSize, streets: pineappleStreets(TurnStreet),
+		betting: NoLimit, high: pineappleHigh,
+	}
+	StudVariant Variant = &gameVariant{
+		name: "Stud", pocketSize: StudCardsNum, streets: studStreets, betting: FixedLimit, bringIn: true,
+		high: holdemHigh, visible: studVisible,
+	}
+	StudHiLoVariant Variant = &gameVariant{
+		name: "Stud8", pocketSize: StudCardsNum, streets: studStreets, betting: FixedLimit, bringIn: true,
+		high: holdemHigh, low: eightLow, visible: studVisible,
+	}
+	RazzVariant Variant = &gameVariant{
+		name: "Razz", pocketSize: StudCardsNum, streets: studStreets, betting: FixedLimit, bringIn: true,
+		high: razzHigh, visible: razzVisible, hand: razzHand,
+	}
+	TripleDrawVariant Variant = &gameVariant{
+		name: "2-7 TD", pocketSize: HandSize, streets: drawStreets(3), betting: FixedLimit, high: deuceToSevenHigh,
+		hand: deuceToSevenHand,
+	}
+	FiveCardDrawVariant Variant = &gameVariant{
+		name: "5CD", pocketSize: HandSize, streets: drawStreets(1), betting: FixedLimit, high: holdemHigh,
+	}
+)
+
+// Variants - встроенные варианты игры
+var Variants = []Variant{
+	HoldemVariant, LimitHoldemVariant, OmahaVariant, OmahaHiLoVariant, PineappleVariant, CrazyPineappleVariant,
+	StudVariant, StudHiLoVariant, RazzVariant, TripleDrawVariant, FiveCardDrawVariant,
+}
+
+// hasBoard проверяет, выкладываются ли в варианте v общие карты
+func hasBoard(v Variant) bool {
+	for _, s := range v.Streets() {
+		if s.Board > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VariantByName возвращает встроенный вариант игры по названию
+func VariantByName(name string) (Variant, error) {
+	for _, v := range Variants {
+		if v.Name() == name {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Unknown game variant %s", name)
+}
+
+// Ротации смешанных игр: вариант меняется после каждого круга баттона
+var (
+	HORSE     = []Variant{LimitHoldemVariant, OmahaHiLoVariant, RazzVariant, StudVariant, StudHiLoVariant}
+	EightGame = []Variant{
+		TripleDrawVariant, LimitHoldemVariant, OmahaHiLoVariant, RazzVariant, StudVariant, StudHiLoVariant,
+		HoldemVariant, OmahaVariant,
+	}
+)
+
+func holdemHigh(pocket, board []*Card) int {
+	return int(RankCards(append(append(make([]*Card, 0, len(pocket)+len(board)), pocket...), board...)))
+}
+
+// pineappleHigh оценивает руку из двух оставшихся после сброса карманных карт и общих карт
+func pineappleHigh(pocket, board []*Card) int {
+	hand, err := GetMaxHandWithBoard(NewStringSliceFromCards(board), NewStringSliceFromCards(pocket))
+	if err != nil {
+		return holdemHigh(pocket, board)
+	}
+
+	return int(hand.Rank())
+}
+
+func razzHigh(pocket, board []*Card) int {
+	return int(RazzRank(append(append(make([]*Card, 0, len(pocket)+len(board)), pocket...), board...)))
+}
+
+func deuceToSevenHigh(pocket, board []*Card) int {
+	return int(DeuceToSevenRank(pocket))
+}
+
+func razzHand(pocket, board []*Card) HandRank {
+	return LowRank(razzHigh(pocket, board)).HandRank()
+}
+
+func deuceToSevenHand(pocket, board []*Card) HandRank {
+	return DeuceToSevenRank(pocket).HandRank()
+}
+
+// omahaHands вызывает f для каждой руки из двух карманных карт и трех общих
+func omahaHands(pocket, board []*Card, f func(hand []*Card)) {
+	hand := make([]*Card, HandSize)
+
+	for i := 0; i < len(pocket); i++ {
+		for j := i + 1; j < len(pocket); j++ {
+			for a := 0; a < len(board); a++ {
+				for b := a + 1; b < len(board); b++ {
+					for c := b + 1; c < len(board); c++ {
+						hand[0], hand[1], hand[2], hand[3], hand[4] = pocket[i], pocket[j], board[a], board[b], board[c]
+
+						f(hand)
+					}
+				}
+			}
+		}
+	}
+}
+
+// omahaHigh оценивает лучшую руку омахи: ровно две карманные карты и три общие
+func omahaHigh(pocket, board []*Card) int {
+	best := HandRank(0)
+
+	omahaHands(pocket, board, func(hand []*Card) {
+		if r := RankCards(hand); r > best {
+			best = r
+		}
+	})
+
+	return int(best)
+}
+
+// omahaLow оценивает лучшую младшую руку омахи хай-лоу из двух карманных и трех общих карт
+func omahaLow(pocket, board []*Card) (int, bool) {
+	best, found := 0, false
+
+	omahaHands(pocket, board, func(hand []*Card) {
+		if r, ok := eightLow(hand, nil); ok && (!found || r > best) {
+			best, found = r, true
+		}
+	})
+
+	return best, found
+}
+
+// LowQualifier - старшая допустимая карта младшей руки в играх хай-лоу
+const LowQualifier = Eight
+
+// eightLow оценивает лучшую младшую руку из пяти разных по значению карт не старше восьмерки
+// (туз младший). Возвращает false, если такой руки нет
+func eightLow(pocket, board []*Card) (int, bool) {
+	var (
+		seen  [LowQualifier + 1]bool
+		cards = make([]*Card, 0, HandSize)
+	)
+
+	for _, c := range append(append(make([]*Card, 0, len(pocket)+len(board)), pocket...), board...) {
+		v := c.Value
+		if v == Ace {
+			v = lowAce
+		}
+
+		if v <= LowQualifier && !seen[v] {
+			seen[v] = true
+		}
+	}
+
+	// младшие значения по возрастанию, по одной карте каждого
+	for v := lowAce; v <= LowQualifier && len(cards) < HandSize; v++ {
+		if seen[v] {
+			value := v
+			if v == lowAce {
+				value = Ace
+			}
+
+			cards = append(cards, &Card{Value: value, Suite: &CardSuite{}})
+		}
+	}
+
+	if len(cards) < HandSize {
+		return 0, false
+	}
+
+	return int(LowRank(-razzHighRank(cards))), true
+}
+
+// suitOrder - старшинство мастей при определении bring-in: трефы, бубны, червы, пики
+var suitOrder = map[Suite]int{
+	Crosses:  0,
+	Diamonds: 1,
+	Hearts:   2,
+	Spades:   3,
+}
+
+// topSuit возвращает старшинство масти старшей из карт up, а из карт одного значения - старшую масть.
+// Если aceLow истинно, туз считается младшей картой
+func topSuit(up []*Card, aceLow bool) int {
+	top, suit := CardValue(0), -1
+
+	for _, c := range up {
+		v := c.Value
+		if aceLow && v == Ace {
+			v = lowAce
+		}
+
+		if order := suitOrder[c.Suite.Suite]; v > top || v == top && order > suit {
+			top, suit = v, order
+		}
+	}
+
+	return suit
+}
+
+// studVisible оценивает открытые карты в стаде: старшая комбинация сильнее, а при равных комбинациях
+// сильнее старшая масть старшей карты. Так сравниваются и карты для bring-in, и руки на следующих улицах
+func studVisible(up []*Card) int {
+	return int(RankCards(up))*SuitesNum + topSuit(up, false)
+}
+
+// razzVisible оценивает открытые карты в раззе: младшая рука сильнее, а при равных руках сильнее младшая
+// масть старшей карты. Поэтому bring-in вносит старшая карта, а при равенстве значений - старшая масть
+func razzVisible(up []*Card) int {
+	return int(RazzRank(up))*SuitesNum + SuitesNum - 1 - topSuit(up, true)
+}
@@ -0,0 +1,37 @@
+package models
+
+import "testing"
+
+func TestVisibleStrength(t *testing.T) {
+	tests := []struct {
+		name     string
+		variant  Variant
+		stronger string
+		weaker   string
+	}{
+		{"stud higher card", StudVariant, "Kc", "Qs"},
+		{"stud bring-in suit order", StudVariant, "2d", "2c"},
+		{"stud spades over hearts", StudVariant, "2s", "2h"},
+		{"stud pair over high cards", StudVariant, "3c 3d", "As Kd"},
+		{"stud kicker", StudVariant, "Kc Kd 4c", "Ks Kh 3d"},
+		{"stud equal pairs by suit of the top card", StudVariant, "Ks Kd 4c", "Kh Kc 4d"},
+		{"stud equal high cards by suit", StudVariant, "Ah 9c 5d 2c", "Ad 9s 5s 2s"},
+		{"razz lower card", RazzVariant, "2c", "3s"},
+		{"razz ace is low", RazzVariant, "As", "2c"},
+		{"razz equal cards, lower suit is stronger", RazzVariant, "Kc", "Ks"},
+		{"razz equal hands by suit of the top card", RazzVariant, "8c 4d 2s", "8d 4c 2h"},
+		{"razz pair is worse", RazzVariant, "Kc Qd", "3c 3d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stronger := tt.variant.VisibleStrength(MustParse(tt.stronger))
+			weaker := tt.variant.VisibleStrength(MustParse(tt.weaker))
+
+			if stronger <= weaker {
+				t.Errorf("VisibleStrength(%s) = %d, want more than VisibleStrength(%s) = %d",
+					tt.stronger, stronger, tt.weaker, weaker)
+			}
+		})
+	}
+}
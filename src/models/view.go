@@ -0,0 +1,163 @@
+package models
+
+import "fmt"
+
+// PlayerView - общедоступная информация об игроке за столом
+type PlayerView struct {
+	ID       string
+	Name     string
+	Seat     int
+	Chips    Chips
+	Active   bool
+	RoundBet Chips
+	Position Position
+	// Up - открытые карты игрока в играх с открытыми картами
+	Up []*Card
+}
+
+// TableView - стол глазами игрока: карты соперников скрыты, колода недоступна
+type TableView struct {
+	TableID  string
+	PlayerID string
+	Pocket   []*Card
+	Board    []*Card
+	Street   Street
+	Pot      Chips
+	BigBlind Chips
+	// Chips - стек игрока
+	Chips      Chips
+	CurrentBet Chips
+	// ToCall - сколько игроку нужно доставить, чтобы уравнять ставку
+	ToCall Chips
+	// MinRaise - минимальное повышение сверх текущей ставки
+	MinRaise Chips
+	// RaiseLimit - максимальное повышение по структуре ставок (банк в пот-лимите), 0 - без ограничения
+	RaiseLimit Chips
+	Variant    string
+	Betting    BettingStructure
+	// Drawing - игрок должен обменять карты, а не сделать ставку
+	Drawing bool
+	// Discard - сколько карт игрок должен сбросить без замены (пайнэппл), 0 - обычный обмен
+	Discard int
+	Players []PlayerView
+	Actions []Action
+	Legal   []ActionType
+}
+
+// CanAct проверяет, допустимо ли действие action
+func (v *TableView) CanAct(action ActionType) bool {
+	for _, a := range v.Legal {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MaxRaise возвращает максимальное повышение сверх текущей ставки: олл-ин или ограничение структуры ставок
+func (v *TableView) MaxRaise() Chips {
+	if v.Chips <= v.ToCall {
+		return 0
+	}
+
+	if v.RaiseLimit > 0 && v.RaiseLimit < v.Chips-v.ToCall {
+		return v.RaiseLimit
+	}
+
+	return v.Chips - v.ToCall
+}
+
+// Opponents возвращает количество соперников, не сбросивших карты
+func (v *TableView) Opponents() int {
+	n := 0
+
+	for _, p := range v.Players {
+		if p.Active && p.ID != v.PlayerID {
+			n++
+		}
+	}
+
+	return n
+}
+
+// ViewFor возвращает стол глазами игрока playerID
+func (t *Table) ViewFor(playerID string) (*TableView, error) {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	player := t.GetPlayerByID(playerID)
+	if player == nil {
+		return nil, fmt.Errorf("Player with ID %s is not in this game", playerID)
+	}
+
+	v := &TableView{
+		TableID:    t.ID,
+		PlayerID:   playerID,
+		Pocket:     append(make([]*Card, 0, t.variant().PocketSize()), player.GetPocketCards()...),
+		Board:      append(make([]*Card, 0, BoardSize), t.Board...),
+		Street:     t.Street,
+		Pot:        t.Pot.TotalChipsNum,
+		BigBlind:   t.BigBlind,
+		Chips:      player.GetCurrentChipsAmount(),
+		CurrentBet: t.CurrentBet,
+		ToCall:     t.CurrentBet - t.roundBets[playerID],
+		MinRaise:   t.minRaise,
+		Players:    make([]PlayerView, len(t.Players)),
+		Actions:    append(make([]Action, 0, len(t.actions)), t.actions...),
+		Legal:      []ActionType{FallAction},
+		Variant:    t.variant().Name(),
+		Betting:    t.variant().Betting(),
+		Drawing:    t.drawing,
+		Discard:    t.discardNum,
+	}
+
+	switch v.Betting {
+	case PotLimit:
+		v.RaiseLimit = t.Pot.TotalChipsNum + v.ToCall
+	case FixedLimit:
+		v.MinRaise = t.limitRaise()
+		v.RaiseLimit = v.MinRaise
+	}
+
+	if v.ToCall > v.Chips {
+		v.ToCall = v.Chips
+	}
+
+	for i, p := range t.Players {
+		v.Players[i] = PlayerView{
+			ID:       p.ID,
+			Name:     p.Name,
+			Seat:     p.Seat,
+			Chips:    p.GetCurrentChipsAmount(),
+			Active:   p.Active,
+			RoundBet: t.roundBets[p.ID],
+			Position: t.handPositions[p.ID],
+			Up:       append(make([]*Card, 0, len(t.upCards[p.ID])), t.upCards[p.ID]...),
+		}
+	}
+
+	if v.Drawing && v.Discard > 0 {
+		v.Legal = []ActionType{DiscardAction}
+
+		return v, nil
+	}
+
+	if v.Drawing {
+		v.Legal = []ActionType{DrawAction, StandPatAction}
+
+		return v, nil
+	}
+
+	if v.ToCall == 0 {
+		v.Legal = append(v.Legal, CheckAction)
+	} else {
+		v.Legal = append(v.Legal, CallAction)
+	}
+
+	if v.Chips > v.ToCall && (v.Betting != FixedLimit || t.bets < MaxLimitBets) {
+		v.Legal = append(v.Legal, RaiseAction)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,419 @@
+package ofc
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"hands/src/models"
+)
+
+const (
+	// MaxPlayersNum - максимальное количество игроков: карты пайнэппла и фантазии помещаются в колоду
+	MaxPlayersNum = 3
+	// InitialCards - количество карт первого круга раскладки
+	InitialCards = 5
+	// PineappleCards - количество карт в следующих кругах пайнэппла: две раскладываются, одна сбрасывается
+	PineappleCards = 3
+)
+
+// Rules - правила игры
+type Rules struct {
+	// Pineapple - в каждом круге после первого игрок получает три карты и одну сбрасывает
+	Pineapple bool
+	// PointValue - стоимость одного очка в фишках
+	PointValue models.Chips
+}
+
+// Placement - карта и ряд, в который ее кладет игрок
+type Placement struct {
+	Card *models.Card
+	Row  Row
+}
+
+// Seat - игрок за столом, его раскладка и еще не разложенные карты
+type Seat struct {
+	Player *models.Player
+	Board  *Board
+	// Hand - карты текущего круга, которые игрок должен разложить или сбросить
+	Hand []*models.Card
+	// Discards - сброшенные игроком карты, соперникам не видны
+	Discards []*models.Card
+	// Fantasyland - количество карт, полученных в фантазии, 0 - игрок не в фантазии
+	Fantasyland int
+}
+
+// Game - стол китайского покера
+type Game struct {
+	ID    string
+	Rules Rules
+	// Seats - игроки в порядке мест, по часовой стрелке
+	Seats []*Seat
+	// Dealer - индекс в Seats баттона; -1 до первой раздачи
+	Dealer int
+	// Round - номер круга раскладки, начиная с 1
+	Round int
+	// CurrentMove - индекс в Seats игрока, чей сейчас ход, или -1, если раскладывают только игроки в фантазии
+	CurrentMove int
+
+	deck   *models.Deck
+	m      sync.RWMutex
+	random *rand.Rand
+}
+
+// NewGame создает стол китайского покера с правилами rules
+func NewGame(idMaker models.IdMaker, rules Rules) *Game {
+	return &Game{
+		ID:          idMaker.MakeID(),
+		Rules:       rules,
+		Seats:       make([]*Seat, 0, MaxPlayersNum),
+		Dealer:      -1,
+		CurrentMove: -1,
+		deck:        models.NewDeck(),
+	}
+}
+
+// WithRandom устанавливает генератор случайных чисел для тасования колоды. Возвращает g
+func (g *Game) WithRandom(r *rand.Rand) *Game {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	g.random = r
+
+	return g
+}
+
+// Register сажает игрока за стол
+func (g *Game) Register(player *models.Player) error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if g.seat(player.ID) != nil {
+		return fmt.Errorf("Player with ID %s is in this game already", player.ID)
+	}
+
+	if len(g.Seats) == MaxPlayersNum {
+		return fmt.Errorf("Players limit %d exceeded for game %s", MaxPlayersNum, g.ID)
+	}
+
+	g.Seats = append(g.Seats, &Seat{Player: player, Board: NewBoard()})
+
+	return nil
+}
+
+func (g *Game) seat(playerID string) *Seat {
+	for _, s := range g.Seats {
+		if s.Player.ID == playerID {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// GetSeat возвращает место игрока playerID, или nil
+func (g *Game) GetSeat(playerID string) *Seat {
+	g.m.RLock()
+	defer g.m.RUnlock()
+
+	return g.seat(playerID)
+}
+
+// order возвращает индексы игроков в раздаче, начиная с первого после баттона
+func (g *Game) order() []int {
+	order := make([]int, 0, len(g.Seats))
+
+	for i := 1; i <= len(g.Seats); i++ {
+		n := (g.Dealer + i) % len(g.Seats)
+
+		if g.Seats[n].Player.Active {
+			order = append(order, n)
+		}
+	}
+
+	return order
+}
+
+// NewHand начинает раздачу: передает баттон, тасует колоду и раздает первый круг - по пять карт,
+// а игрокам в фантазии - все карты сразу
+func (g *Game) NewHand() error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	players := 0
+
+	for _, s := range g.Seats {
+		s.Board, s.Hand, s.Discards = NewBoard(), nil, nil
+		if s.Player.DealIn() {
+			players++
+		} else {
+			s.Fantasyland = 0
+		}
+	}
+
+	if players < models.HeadsUpPlayersNum {
+		return fmt.Errorf("Not enough players to start a hand in game %s", g.ID)
+	}
+
+	g.Dealer = (g.Dealer + 1) % len(g.Seats)
+
+	if g.random != nil {
+		g.deck.ShuffleWithRandom(g.random)
+	} else {
+		g.deck.Shuffle()
+	}
+
+	for _, i := range g.order() {
+		s := g.Seats[i]
+
+		n := InitialCards
+		if s.Fantasyland > 0 {
+			n = s.Fantasyland
+		}
+
+		if err := g.deal(s, n); err != nil {
+			return err
+		}
+	}
+
+	g.Round = 1
+	g.CurrentMove = g.nextToPlace(g.Dealer)
+
+	return nil
+}
+
+// deal сдает игроку n карт на руку
+func (g *Game) deal(s *Seat, n int) error {
+	for i := 0; i < n; i++ {
+		card, err := g.deck.Card()
+		if err != nil {
+			return err
+		}
+
+		s.Hand = append(s.Hand, card)
+	}
+
+	return nil
+}
+
+// nextToPlace возвращает индекс следующего после from игрока не в фантазии, у которого есть карты на руке,
+// или -1
+func (g *Game) nextToPlace(from int) int {
+	for i := 1; i <= len(g.Seats); i++ {
+		n := (from + i) % len(g.Seats)
+		s := g.Seats[n]
+
+		if s.Player.Active && s.Fantasyland == 0 && len(s.Hand) > 0 {
+			return n
+		}
+	}
+
+	return -1
+}
+
+// discardsNum возвращает, сколько карт игрок должен сбросить в текущем круге
+func (g *Game) discardsNum(s *Seat) int {
+	switch {
+	case s.Fantasyland > 0:
+		return len(s.Hand) - CardsNum
+	case g.Rules.Pineapple && g.Round > 1:
+		return 1
+	}
+
+	return 0
+}
+
+// Set раскладывает карты текущего круга игрока playerID по рядам placements и сбрасывает discards.
+// Игроки раскладывают карты по очереди, начиная с первого после баттона; игроки в фантазии раскладывают
+// все 13 карт сразу и в любой момент. Когда все игроки разложили карты круга, раздается следующий круг:
+// по одной карте, а в пайнэппле - по три
+func (g *Game) Set(playerID string, placements []Placement, discards []*models.Card) error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	s := g.seat(playerID)
+	if s == nil || !s.Player.Active {
+		return fmt.Errorf("Player with ID %s is not in this hand", playerID)
+	}
+
+	if len(s.Hand) == 0 {
+		return fmt.Errorf("Player with ID %s has no cards to place", playerID)
+	}
+
+	if s.Fantasyland == 0 && (g.CurrentMove < 0 || g.Seats[g.CurrentMove] != s) {
+		return fmt.Errorf("It is not a turn of player with ID %s", playerID)
+	}
+
+	if n := g.discardsNum(s); len(discards) != n || len(placements) != len(s.Hand)-n {
+		return fmt.Errorf("Player with ID %s must place %d cards and discard %d", playerID, len(s.Hand)-n, n)
+	}
+
+	used := make(map[*models.Card]bool, len(s.Hand))
+
+	for _, c := range s.Hand {
+		used[c] = false
+	}
+
+	board := *s.Board
+
+	for i := range board.Rows {
+		board.Rows[i] = append([]*models.Card(nil), s.Board.Rows[i]...)
+	}
+
+	for _, p := range placements {
+		if err := g.use(used, p.Card, playerID); err != nil {
+			return err
+		}
+
+		if err := board.Place(p.Row, p.Card); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range discards {
+		if err := g.use(used, c, playerID); err != nil {
+			return err
+		}
+	}
+
+	*s.Board = board
+	s.Discards = append(s.Discards, discards...)
+	s.Hand = nil
+
+	if s.Fantasyland > 0 {
+		return nil
+	}
+
+	if g.CurrentMove = g.nextToPlace(g.CurrentMove); g.CurrentMove >= 0 || g.boardsComplete() {
+		return nil
+	}
+
+	return g.nextRound()
+}
+
+// use отмечает карту card руки игрока как разложенную или сброшенную
+func (g *Game) use(used map[*models.Card]bool, card *models.Card, playerID string) error {
+	if done, ok := used[card]; !ok || done {
+		return fmt.Errorf("Player with ID %s can place only own cards, without repeats", playerID)
+	}
+
+	used[card] = true
+
+	return nil
+}
+
+// boardsComplete проверяет, закончена ли раскладка у всех игроков не в фантазии
+func (g *Game) boardsComplete() bool {
+	for _, i := range g.order() {
+		if s := g.Seats[i]; s.Fantasyland == 0 && !s.Board.IsComplete() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nextRound раздает следующий круг игрокам не в фантазии
+func (g *Game) nextRound() error {
+	n := 1
+	if g.Rules.Pineapple {
+		n = PineappleCards
+	}
+
+	for _, i := range g.order() {
+		if s := g.Seats[i]; s.Fantasyland == 0 {
+			if err := g.deal(s, n); err != nil {
+				return err
+			}
+		}
+	}
+
+	g.Round++
+	g.CurrentMove = g.nextToPlace(g.Dealer)
+
+	return nil
+}
+
+// IsHandOver проверяет, разложили ли все игроки в раздаче свои 13 карт
+func (g *Game) IsHandOver() bool {
+	g.m.RLock()
+	defer g.m.RUnlock()
+
+	return g.isHandOver()
+}
+
+func (g *Game) isHandOver() bool {
+	for _, i := range g.order() {
+		if !g.Seats[i].Board.IsComplete() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Scores возвращает итоговые очки игроков раздачи по их ID: сумму очков Score против каждого соперника
+func (g *Game) Scores() (map[string]int, error) {
+	g.m.RLock()
+	defer g.m.RUnlock()
+
+	if !g.isHandOver() {
+		return nil, fmt.Errorf("Hand in game %s is not over", g.ID)
+	}
+
+	scores := make(map[string]int)
+	order := g.order()
+
+	for i, a := range order {
+		for _, b := range order[i+1:] {
+			one, other := g.Seats[a], g.Seats[b]
+			score := Score(one.Board, other.Board)
+
+			scores[one.Player.ID] += score
+			scores[other.Player.ID] -= score
+		}
+	}
+
+	return scores, nil
+}
+
+// Award рассчитывает игроков попарно по Rules.PointValue фишек за очко (проигравший платит не больше
+// своего стека) и определяет, кто из игроков сыграет следующую раздачу в фантазии
+func (g *Game) Award() error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if !g.isHandOver() {
+		return fmt.Errorf("Hand in game %s is not over", g.ID)
+	}
+
+	order := g.order()
+
+	for i, a := range order {
+		for _, b := range order[i+1:] {
+			winner, loser := g.Seats[a], g.Seats[b]
+
+			score := Score(winner.Board, loser.Board)
+			if score < 0 {
+				winner, loser, score = loser, winner, -score
+			}
+
+			winner.Player.AddChips(loser.Player.Post(models.Chips(score) * g.Rules.PointValue))
+		}
+	}
+
+	for _, i := range order {
+		s := g.Seats[i]
+
+		switch {
+		case s.Fantasyland > 0 && StaysInFantasyland(s.Board):
+			s.Fantasyland = FantasylandCardsNum
+		case s.Fantasyland > 0:
+			s.Fantasyland = 0
+		default:
+			s.Fantasyland = FantasylandCards(s.Board, g.Rules.Pineapple)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package ofc
+
+import (
+	"math/rand"
+	"testing"
+
+	"hands/src/helpers"
+	"hands/src/models"
+)
+
+// newTestGame сажает за стол num игроков со стеком 1000
+func newTestGame(t *testing.T, rules Rules, num int) *Game {
+	t.Helper()
+
+	g := NewGame(helpers.NewDefaultIdGenerator(), rules).WithRandom(rand.New(rand.NewSource(1)))
+
+	for i := 0; i < num; i++ {
+		if err := g.Register(models.NewPlayer("p", helpers.NewDefaultIdGenerator(), 1000)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return g
+}
+
+// placeAll раскладывает карты руки игрока снизу вверх, сбрасывая последние карты, если нужно
+func placeAll(s *Seat, discards int) ([]Placement, []*models.Card) {
+	board := *s.Board
+	for i := range board.Rows {
+		board.Rows[i] = append([]*models.Card(nil), s.Board.Rows[i]...)
+	}
+
+	keep := s.Hand[:len(s.Hand)-discards]
+	placements := make([]Placement, 0, len(keep))
+
+	for _, c := range keep {
+		for _, row := range []Row{Bottom, Middle, Top} {
+			if board.Free(row) > 0 {
+				board.Rows[row] = append(board.Rows[row], c)
+				placements = append(placements, Placement{Card: c, Row: row})
+
+				break
+			}
+		}
+	}
+
+	return placements, s.Hand[len(keep):]
+}
+
+func TestPlayHand(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules Rules
+		// rounds - количество кругов раскладки
+		rounds int
+	}{
+		{name: "classic", rules: Rules{PointValue: 1}, rounds: 1 + CardsNum - InitialCards},
+		{name: "pineapple", rules: Rules{Pineapple: true, PointValue: 1}, rounds: 1 + (CardsNum-InitialCards)/2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, tt.rules, 3)
+
+			if err := g.NewHand(); err != nil {
+				t.Fatal(err)
+			}
+
+			for !g.IsHandOver() {
+				s := g.Seats[g.CurrentMove]
+
+				discards := 0
+				if tt.rules.Pineapple && g.Round > 1 {
+					discards = 1
+				}
+
+				placements, mucked := placeAll(s, discards)
+				if err := g.Set(s.Player.ID, placements, mucked); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if g.Round != tt.rounds {
+				t.Errorf("hand took %d rounds, want %d", g.Round, tt.rounds)
+			}
+
+			scores, err := g.Scores()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sum, chips := 0, models.Chips(0)
+			for _, score := range scores {
+				sum += score
+			}
+
+			if sum != 0 {
+				t.Errorf("scores %v sum to %d", scores, sum)
+			}
+
+			if err := g.Award(); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, s := range g.Seats {
+				chips += s.Player.GetCurrentChipsAmount()
+			}
+
+			if chips != 3000 {
+				t.Errorf("players have %d chips after award, want 3000", chips)
+			}
+		})
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	g := newTestGame(t, Rules{PointValue: 1}, 2)
+
+	if err := g.NewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	current := g.Seats[g.CurrentMove]
+	waiting := g.Seats[1-g.CurrentMove]
+	placements, _ := placeAll(current, 0)
+
+	tests := []struct {
+		name       string
+		playerID   string
+		placements []Placement
+	}{
+		{name: "not player's turn", playerID: waiting.Player.ID, placements: placements},
+		{name: "not all cards", playerID: current.Player.ID, placements: placements[1:]},
+		{
+			name:       "foreign card",
+			playerID:   current.Player.ID,
+			placements: append([]Placement{{Card: waiting.Hand[0], Row: Top}}, placements[1:]...),
+		},
+		{
+			name:       "repeated card",
+			playerID:   current.Player.ID,
+			placements: append([]Placement{{Card: placements[1].Card, Row: Top}}, placements[1:]...),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := g.Set(tt.playerID, tt.placements, nil); err == nil {
+				t.Error("Set() accepted")
+			}
+
+			if current.Board.Len() != 0 {
+				t.Errorf("board changed after failed Set: %d cards", current.Board.Len())
+			}
+		})
+	}
+
+	if _, err := g.Scores(); err == nil {
+		t.Error("scores of unfinished hand")
+	}
+}
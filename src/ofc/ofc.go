@@ -0,0 +1,259 @@
+// Package ofc реализует китайский покер открытой раскладки (Open-Face Chinese): каждый игрок раскладывает
+// 13 карт в три ряда - верхний из трех карт, средний и нижний из пяти. Нижний ряд должен быть не слабее
+// среднего, а средний - не слабее верхнего, иначе рука мертвая (фол)
+package ofc
+
+import (
+	"fmt"
+
+	"hands/src/models"
+)
+
+// Row - ряд раскладки
+type Row int
+
+const (
+	Top Row = iota
+	Middle
+	Bottom
+)
+
+// RowsNum - количество рядов раскладки
+const RowsNum = 3
+
+// Rows - ряды раскладки сверху вниз
+var Rows = []Row{Top, Middle, Bottom}
+
+const (
+	// TopSize - количество карт в верхнем ряду
+	TopSize = 3
+	// CardsNum - количество карт в полной раскладке
+	CardsNum = TopSize + 2*models.HandSize
+	// ScoopBonus - бонус за выигрыш всех трех рядов у соперника
+	ScoopBonus = 3
+	// FoulPenalty - очки, которые игрок с мертвой рукой платит сопернику, не считая бонусов соперника
+	FoulPenalty = RowsNum + ScoopBonus
+	// FantasylandCardsNum - количество карт, раздаваемых в фантазии (в пайнэппле - за пару дам)
+	FantasylandCardsNum = CardsNum + 1
+)
+
+// middleRoyalties и bottomRoyalties - бонусы за комбинации в среднем и нижнем рядах
+var (
+	middleRoyalties = map[models.HandValue]int{
+		models.ThreeHand:         2,
+		models.StraightHand:      4,
+		models.FlushHand:         8,
+		models.FullHouseHand:     12,
+		models.FourHand:          20,
+		models.StraightFlushHand: 30,
+		models.RoyalFlushHand:    50,
+	}
+	bottomRoyalties = map[models.HandValue]int{
+		models.StraightHand:      2,
+		models.FlushHand:         4,
+		models.FullHouseHand:     6,
+		models.FourHand:          10,
+		models.StraightFlushHand: 15,
+		models.RoyalFlushHand:    25,
+	}
+)
+
+// Size возвращает количество карт в ряду
+func (r Row) Size() int {
+	if r == Top {
+		return TopSize
+	}
+
+	return models.HandSize
+}
+
+func (r Row) String() string {
+	switch r {
+	case Top:
+		return "top"
+	case Middle:
+		return "middle"
+	case Bottom:
+		return "bottom"
+	}
+
+	return fmt.Sprintf("row %d", int(r))
+}
+
+// Board - раскладка игрока
+type Board struct {
+	Rows [RowsNum][]*models.Card
+}
+
+// NewBoard создает пустую раскладку
+func NewBoard() *Board {
+	return &Board{}
+}
+
+// Place кладет карту card в ряд row
+func (b *Board) Place(row Row, card *models.Card) error {
+	if row < Top || row > Bottom {
+		return fmt.Errorf("Unknown row %d", int(row))
+	}
+
+	if len(b.Rows[row]) == row.Size() {
+		return fmt.Errorf("Row %s is full", row)
+	}
+
+	b.Rows[row] = append(b.Rows[row], card)
+
+	return nil
+}
+
+// Free возвращает количество свободных мест в ряду row
+func (b *Board) Free(row Row) int {
+	return row.Size() - len(b.Rows[row])
+}
+
+// Len возвращает количество разложенных карт
+func (b *Board) Len() int {
+	n := 0
+
+	for _, cards := range b.Rows {
+		n += len(cards)
+	}
+
+	return n
+}
+
+// IsComplete проверяет, разложены ли все 13 карт
+func (b *Board) IsComplete() bool {
+	return b.Len() == CardsNum
+}
+
+// Rank возвращает оценку ряда row. Верхний ряд из трех карт оценивается без стритов и флэшей,
+// в одной шкале с рядами из пяти карт
+func (b *Board) Rank(row Row) models.HandRank {
+	return rowRank(row, b.Rows[row])
+}
+
+// rowRank оценивает карты ряда row. Незаполненный верхний ряд оценивается по уже разложенным картам
+func rowRank(row Row, cards []*models.Card) models.HandRank {
+	if row == Top {
+		if rank, err := models.RankThreeCards(cards); err == nil {
+			return rank
+		}
+	}
+
+	return models.RankCards(cards)
+}
+
+// IsFoul проверяет, мертвая ли полная раскладка: нижний ряд слабее среднего или средний слабее верхнего
+func (b *Board) IsFoul() bool {
+	if !b.IsComplete() {
+		return false
+	}
+
+	return b.Rank(Bottom) < b.Rank(Middle) || b.Rank(Middle) < b.Rank(Top)
+}
+
+// Royalties возвращает сумму бонусов за комбинации во всех рядах. У мертвой раскладки бонусов нет
+func (b *Board) Royalties() int {
+	if b.IsFoul() {
+		return 0
+	}
+
+	royalties := 0
+
+	for _, row := range Rows {
+		royalties += RowRoyalty(row, b.Rows[row])
+	}
+
+	return royalties
+}
+
+// RowRoyalty возвращает бонус за комбинацию cards в ряду row. В верхнем ряду бонус дают пары
+// от шестерок (1 очко за 66, 9 за AA) и тройки (10 очков за 222, 22 за AAA)
+func RowRoyalty(row Row, cards []*models.Card) int {
+	switch row {
+	case Top:
+		switch rank := rowRank(Top, cards); {
+		case rank.Value() == models.ThreeHand:
+			return 10 + int(rank.High()-models.Two)
+		case rank.Value() == models.PairHand && rank.High() >= models.Six:
+			return int(rank.High() - models.Five)
+		}
+
+		return 0
+	case Middle:
+		return middleRoyalties[models.RankCards(cards).Value()]
+	case Bottom:
+		return bottomRoyalties[models.RankCards(cards).Value()]
+	}
+
+	return 0
+}
+
+// Score возвращает очки раскладки one против раскладки other: по очку за каждый выигранный ряд,
+// ScoopBonus за выигрыш всех рядов и разницу бонусов за комбинации. Отрицательное значение - проигрыш.
+// Мертвая раскладка проигрывает FoulPenalty очков и бонусы соперника; две мертвые раскладки играют вничью
+func Score(one, other *Board) int {
+	switch oneFoul, otherFoul := one.IsFoul(), other.IsFoul(); {
+	case oneFoul && otherFoul:
+		return 0
+	case oneFoul:
+		return -FoulPenalty - other.Royalties()
+	case otherFoul:
+		return FoulPenalty + one.Royalties()
+	}
+
+	rows := 0
+
+	for _, row := range Rows {
+		switch r, o := one.Rank(row), other.Rank(row); {
+		case r > o:
+			rows++
+		case r < o:
+			rows--
+		}
+	}
+
+	switch rows {
+	case RowsNum:
+		rows += ScoopBonus
+	case -RowsNum:
+		rows -= ScoopBonus
+	}
+
+	return rows + one.Royalties() - other.Royalties()
+}
+
+// FantasylandCards возвращает количество карт, которые игрок с раскладкой b получит в следующей раздаче
+// в фантазии, или 0, если раскладка не дает фантазию. Фантазию дает пара дам или сильнее в верхнем ряду
+// живой раскладки. В пайнэппле количество карт растет с силой верхнего ряда: 14 за QQ, 15 за KK,
+// 16 за AA и 17 за тройку
+func FantasylandCards(b *Board, pineapple bool) int {
+	if !b.IsComplete() || b.IsFoul() {
+		return 0
+	}
+
+	top := b.Rank(Top)
+
+	switch {
+	case top.Value() == models.HighCardHand || top.Value() == models.PairHand && top.High() < models.Queen:
+		return 0
+	case !pineapple:
+		return FantasylandCardsNum
+	case top.Value() == models.ThreeHand:
+		return FantasylandCardsNum + 3
+	}
+
+	return FantasylandCardsNum + int(top.High()-models.Queen)
+}
+
+// StaysInFantasyland проверяет, остается ли игрок в фантазии на следующую раздачу: живая раскладка
+// с тройкой в верхнем ряду, фулл-хаусом или сильнее в среднем или каре или сильнее в нижнем
+func StaysInFantasyland(b *Board) bool {
+	if !b.IsComplete() || b.IsFoul() {
+		return false
+	}
+
+	return b.Rank(Top).Value() == models.ThreeHand ||
+		b.Rank(Middle).Value() >= models.FullHouseHand ||
+		b.Rank(Bottom).Value() >= models.FourHand
+}
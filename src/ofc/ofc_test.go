@@ -0,0 +1,187 @@
+package ofc
+
+import (
+	"testing"
+
+	"hands/src/models"
+)
+
+// newTestBoard раскладывает карты рядов top, middle и bottom
+func newTestBoard(t *testing.T, top, middle, bottom string) *Board {
+	t.Helper()
+
+	b := NewBoard()
+
+	for row, cards := range map[Row]string{Top: top, Middle: middle, Bottom: bottom} {
+		for _, c := range models.MustParse(cards) {
+			if err := b.Place(row, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	return b
+}
+
+func TestRowRoyalty(t *testing.T) {
+	tests := []struct {
+		row   Row
+		cards string
+		want  int
+	}{
+		{row: Top, cards: "5h 5d Ac", want: 0},
+		{row: Top, cards: "6h 6d 2c", want: 1},
+		{row: Top, cards: "Qh Qd 2c", want: 7},
+		{row: Top, cards: "Ah Ad Kc", want: 9},
+		{row: Top, cards: "2h 2d 2c", want: 10},
+		{row: Top, cards: "Ah Ad Ac", want: 22},
+		{row: Top, cards: "Qh Jh Th", want: 0},
+		{row: Middle, cards: "7h 7d 7c Ks 2h", want: 2},
+		{row: Middle, cards: "Ah 9h 7h 4h 2h", want: 8},
+		{row: Middle, cards: "Kh Kd 9c 8s 3h", want: 0},
+		{row: Bottom, cards: "7h 7d 7c Ks 2h", want: 0},
+		{row: Bottom, cards: "9h Td Jc Qs Kh", want: 2},
+		{row: Bottom, cards: "Ah Kh Qh Jh Th", want: 25},
+	}
+
+	for _, tt := range tests {
+		if got := RowRoyalty(tt.row, models.MustParse(tt.cards)); got != tt.want {
+			t.Errorf("RowRoyalty(%s, %s) = %d, want %d", tt.row, tt.cards, got, tt.want)
+		}
+	}
+}
+
+func TestIsFoul(t *testing.T) {
+	tests := []struct {
+		name                string
+		top, middle, bottom string
+		want                bool
+	}{
+		{name: "rows in order", top: "Qh Qd 2c", middle: "Kh Kd 9c 8s 3h", bottom: "Ah Ad As 4c 5d"},
+		{name: "middle beats bottom", top: "2h 3d 5c", middle: "Ah Ad As 4c 5d", bottom: "Kh Kd 9c 8s 3h", want: true},
+		{name: "top beats middle", top: "Jh Js 2s", middle: "Tc Td 7s 6s 4s", bottom: "9h 9d 9s 3c 3d", want: true},
+		// пара из трех карт слабее пары из пяти карт с теми же старшими картами
+		{name: "same pair, kickers in the middle", top: "Qh Qd 7c", middle: "Qs Qc 7d 5h 4h", bottom: "Ah Ad As 4c 5d"},
+		{name: "same pair, higher kicker on top", top: "Qh Qd Kc", middle: "Qs Qc 7d 5h 4h", bottom: "Ah Ad As 4c 5d", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTestBoard(t, tt.top, tt.middle, tt.bottom).IsFoul(); got != tt.want {
+				t.Errorf("IsFoul() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	incomplete := NewBoard()
+	if err := incomplete.Place(Top, models.MustParse("Ah")[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if incomplete.IsFoul() || incomplete.IsComplete() {
+		t.Error("incomplete board is foul or complete")
+	}
+}
+
+func TestScore(t *testing.T) {
+	// 7 очков бонуса за дам наверху
+	queens := newTestBoard(t, "Qh Qd 2c", "Kh Kd 9c 8s 3h", "Ah Ad As 4c 5d")
+	// 2 очка бонуса за стрит внизу
+	straight := newTestBoard(t, "2h 3d 4c", "5h 5c 7c 8h 9h", "Tc Jc Qs Kc 9d")
+	weak := newTestBoard(t, "7h 6d 2d", "Jh Jd 8c 6c 4h", "Tc Th 9s 9d 3c")
+	foul := newTestBoard(t, "Jh Js 2s", "Tc Td 7s 6s 4s", "9h 9d 9s 3c 3d")
+
+	tests := []struct {
+		name        string
+		one, other  *Board
+		want        int
+		wantReverse int
+	}{
+		{name: "two rows to one with royalties", one: queens, other: straight, want: 1 + 7 - 2},
+		{name: "scoop", one: queens, other: weak, want: 3 + ScoopBonus + 7},
+		{name: "foul pays penalty and royalties", one: queens, other: foul, want: FoulPenalty + 7},
+		{name: "two fouls", one: foul, other: foul, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(tt.one, tt.other); got != tt.want {
+				t.Errorf("Score() = %d, want %d", got, tt.want)
+			}
+
+			if got := Score(tt.other, tt.one); got != -tt.want {
+				t.Errorf("reverse Score() = %d, want %d", got, -tt.want)
+			}
+		})
+	}
+
+	if foul.Royalties() != 0 {
+		t.Errorf("foul board royalties = %d, want 0", foul.Royalties())
+	}
+}
+
+func TestFantasyland(t *testing.T) {
+	tests := []struct {
+		name                string
+		top, middle, bottom string
+		cards, pineapple    int
+		stays               bool
+	}{
+		{name: "jacks", top: "Jh Jd 2c", middle: "Kh Kd 9c 8s 3h", bottom: "Ah Ad As 4c 5d"},
+		{name: "queens", top: "Qh Qd 2c", middle: "Kh Kd 9c 8s 3h", bottom: "Ah Ad As 4c 5d", cards: 14, pineapple: 14},
+		{name: "kings", top: "Kh Kd 2c", middle: "Ah Ad 9c 8s 3h", bottom: "Qs Qc Qd 4c 5d", cards: 14, pineapple: 15},
+		{
+			name: "aces with quads", top: "Ah Ad 2c", middle: "Kh Kd Ks 8s 3h", bottom: "Qs Qc Qd Qh 5d",
+			cards: 14, pineapple: 16, stays: true,
+		},
+		{
+			name: "trips on top", top: "2h 2d 2c", middle: "3h 3d 3c 8s 8h", bottom: "4s 4c 4d 4h 5d",
+			cards: 14, pineapple: 17, stays: true,
+		},
+		{
+			name: "full house in the middle", top: "Qh Qd 2c", middle: "3h 3d 3c 8s 8h", bottom: "4s 4c 4d 5h 5d",
+			cards: 14, pineapple: 14, stays: true,
+		},
+		{name: "foul", top: "Ah Ad 2c", middle: "Kh Kd 9c 8s 3h", bottom: "Qs Qc Jd 4c 5d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBoard(t, tt.top, tt.middle, tt.bottom)
+
+			if got := FantasylandCards(b, false); got != tt.cards {
+				t.Errorf("FantasylandCards() = %d, want %d", got, tt.cards)
+			}
+
+			if got := FantasylandCards(b, true); got != tt.pineapple {
+				t.Errorf("pineapple FantasylandCards() = %d, want %d", got, tt.pineapple)
+			}
+
+			if got := StaysInFantasyland(b); got != tt.stays {
+				t.Errorf("StaysInFantasyland() = %v, want %v", got, tt.stays)
+			}
+		})
+	}
+}
+
+func TestPlace(t *testing.T) {
+	b := NewBoard()
+
+	for _, c := range models.MustParse("Ah Kd Qs") {
+		if err := b.Place(Top, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := b.Place(Top, models.MustParse("Jc")[0]); err == nil {
+		t.Error("card placed into full top row")
+	}
+
+	if err := b.Place(Row(3), models.MustParse("Jc")[0]); err == nil {
+		t.Error("card placed into unknown row")
+	}
+
+	if b.Free(Top) != 0 || b.Free(Middle) != models.HandSize || b.Len() != TopSize {
+		t.Errorf("free top %d, middle %d, len %d", b.Free(Top), b.Free(Middle), b.Len())
+	}
+}
@@ -0,0 +1,335 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"hands/src/bots"
+	"hands/src/models"
+)
+
+const (
+	// DefaultStackBB - стартовый стек ботов в больших блайндах
+	DefaultStackBB = 100
+	// confidenceZ - z-значение для 95% доверительного интервала
+	confidenceZ = 1.96
+)
+
+// BotFactory создает стратегию бота. Каждая горутина симулятора создает своих ботов со своим генератором r
+type BotFactory func(r *rand.Rand) bots.Strategy
+
+// Bot - участник симуляции
+type Bot struct {
+	Name string
+	New  BotFactory
+}
+
+// BotFactories - встроенные боты по названиям
+var BotFactories = map[string]BotFactory{
+	"random": func(r *rand.Rand) bots.Strategy {
+		return bots.NewRandomStrategy(r)
+	},
+	"tag": func(r *rand.Rand) bots.Strategy {
+		return bots.NewTightAggressiveStrategy()
+	},
+	"equity": func(r *rand.Rand) bots.Strategy {
+		return bots.NewEquityStrategy(bots.DefaultEquitySamples, r)
+	},
+}
+
+// Config - параметры симуляции
+type Config struct {
+	Bots []Bot
+	// Hands - общее количество раздач
+	Hands int
+	// Workers - количество горутин, каждая из которых играет за своим столом
+	Workers int
+	// Seed - начальное значение генераторов; горутина i использует Seed+i
+	Seed       int64
+	BigBlind   int
+	SmallBlind int
+	// StackBB - стек, до которого боты докупаются перед каждой раздачей, в больших блайндах
+	StackBB int
+}
+
+// Result - результат бота. BBPer100 - средний выигрыш в больших блайндах на 100 раздач,
+// CI95 - полуширина 95% доверительного интервала для BBPer100
+type Result struct {
+	Name     string
+	Hands    int
+	BBPer100 float64
+	CI95     float64
+}
+
+// accumulator накапливает выигрыши бота в больших блайндах за раздачу
+type accumulator struct {
+	hands int
+	sum   float64
+	sumSq float64
+}
+
+func (a *accumulator) add(bb float64) {
+	a.hands++
+	a.sum += bb
+	a.sumSq += bb * bb
+}
+
+func (a *accumulator) merge(other *accumulator) {
+	a.hands += other.hands
+	a.sum += other.sum
+	a.sumSq += other.sumSq
+}
+
+func (a *accumulator) result(name string) *Result {
+	r := &Result{Name: name, Hands: a.hands}
+
+	if a.hands == 0 {
+		return r
+	}
+
+	n := float64(a.hands)
+	mean := a.sum / n
+	r.BBPer100 = 100 * mean
+
+	if a.hands > 1 {
+		variance := (a.sumSq - n*mean*mean) / (n - 1)
+		r.CI95 = confidenceZ * 100 * math.Sqrt(math.Max(variance, 0)/n)
+	}
+
+	return r
+}
+
+func (c *Config) validate() error {
+	if len(c.Bots) < models.HeadsUpPlayersNum {
+		return errors.New("At least two bots are required")
+	}
+
+	if c.Hands < 1 {
+		return errors.New("Hands number must be positive")
+	}
+
+	if c.Workers < 1 {
+		c.Workers = 1
+	}
+
+	if c.StackBB < 1 {
+		c.StackBB = DefaultStackBB
+	}
+
+	if c.BigBlind < 1 {
+		return errors.New("Big blind must be positive")
+	}
+
+	return nil
+}
+
+// Run проводит симуляцию и возвращает результаты ботов в порядке Config.Bots.
+// Раздачи делятся между горутинами поровну; у каждой горутины свой стол и свой генератор,
+// поэтому горутины не конкурируют за блокировки, а результат при одинаковом Seed воспроизводим
+func Run(cfg Config) ([]*Result, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+
+	accs := make([][]*accumulator, cfg.Workers)
+	errs := make([]error, cfg.Workers)
+
+	for w := 0; w < cfg.Workers; w++ {
+		hands := cfg.Hands / cfg.Workers
+		if w < cfg.Hands%cfg.Workers {
+			hands++
+		}
+
+		wg.Add(1)
+
+		go func(w, hands int) {
+			defer wg.Done()
+
+			accs[w], errs[w] = newWorker(cfg, cfg.Seed+int64(w)).play(hands)
+		}(w, hands)
+	}
+
+	wg.Wait()
+
+	total := make([]*accumulator, len(cfg.Bots))
+	for i := range total {
+		total[i] = &accumulator{}
+	}
+
+	for w := range accs {
+		if errs[w] != nil {
+			return nil, errs[w]
+		}
+
+		for i, a := range accs[w] {
+			total[i].merge(a)
+		}
+	}
+
+	results := make([]*Result, len(cfg.Bots))
+	for i, bot := range cfg.Bots {
+		results[i] = total[i].result(bot.Name)
+	}
+
+	return results, nil
+}
+
+// worker играет раздачи за одним столом
+type worker struct {
+	table      *models.Table
+	players    []*models.Player
+	strategies map[string]bots.Strategy
+	stack      models.Chips
+	bigBlind   models.Chips
+}
+
+func newWorker(cfg Config, seed int64) *worker {
+	r := rand.New(rand.NewSource(seed))
+
+	w := &worker{
+		table: models.NewTable(
+			"sim", "sim", newSeqIdMaker(), models.CasheTableType,
+			len(cfg.Bots), cfg.BigBlind, cfg.SmallBlind,
+		).WithRandom(r),
+		players:    make([]*models.Player, len(cfg.Bots)),
+		strategies: make(map[string]bots.Strategy),
+		stack:      models.Chips(cfg.StackBB * cfg.BigBlind),
+		bigBlind:   models.Chips(cfg.BigBlind),
+	}
+
+	idMaker := newSeqIdMaker()
+
+	for i, bot := range cfg.Bots {
+		p := models.NewPlayer(bot.Name, idMaker, w.stack)
+
+		w.players[i] = p
+		w.strategies[p.ID] = bot.New(r)
+	}
+
+	return w
+}
+
+// rebuy возвращает стеки всех ботов к стартовому размеру
+func (w *worker) rebuy() {
+	for _, p := range w.players {
+		if diff := w.stack - p.GetCurrentChipsAmount(); diff > 0 {
+			p.AddChips(diff)
+		} else {
+			p.Post(-diff)
+		}
+	}
+}
+
+func (w *worker) play(hands int) ([]*accumulator, error) {
+	accs := make([]*accumulator, len(w.players))
+	for i := range accs {
+		accs[i] = &accumulator{}
+	}
+
+	for _, p := range w.players {
+		if err := w.table.Register(p); err != nil {
+			return nil, err
+		}
+	}
+
+	for h := 0; h < hands; h++ {
+		w.rebuy()
+
+		if err := w.playHand(); err != nil {
+			return nil, err
+		}
+
+		for i, p := range w.players {
+			accs[i].add(float64(p.GetCurrentChipsAmount()-w.stack) / float64(w.bigBlind))
+		}
+	}
+
+	return accs, nil
+}
+
+func (w *worker) playHand() error {
+	t := w.table
+
+	if _, err := t.NewHand(); err != nil {
+		return err
+	}
+
+	for _, deal := range t.GetDealFuncs() {
+		if _, err := deal(); err != nil {
+			return err
+		}
+
+		if err := bots.PlayRound(t, w.strategies); err != nil {
+			return err
+		}
+
+		if t.IsHandOver() {
+			break
+		}
+	}
+
+	runouts, err := t.Showdown()
+	if err != nil {
+		return err
+	}
+
+	return t.Award(t.SplitRunouts(runouts))
+}
+
+// seqIdMaker выдает последовательные ID, не расходуя системный генератор случайных чисел
+type seqIdMaker struct {
+	next int
+}
+
+func newSeqIdMaker() *seqIdMaker {
+	return &seqIdMaker{}
+}
+
+func (m *seqIdMaker) MakeID() string {
+	m.next++
+
+	return fmt.Sprintf("bot-%d", m.next)
+}
+
+// ParseBots возвращает ботов по названиям встроенных стратегий. Одинаковые боты нумеруются: tag, tag#2...
+func ParseBots(names []string) ([]Bot, error) {
+	result := make([]Bot, 0, len(names))
+	seen := make(map[string]int)
+
+	for _, name := range names {
+		factory, ok := BotFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("Unknown bot %q, available: %v", name, BotNames())
+		}
+
+		seen[name]++
+
+		label := name
+		if seen[name] > 1 {
+			label = fmt.Sprintf("%s#%d", name, seen[name])
+		}
+
+		result = append(result, Bot{Name: label, New: factory})
+	}
+
+	return result, nil
+}
+
+// BotNames возвращает названия встроенных ботов
+func BotNames() []string {
+	names := make([]string, 0, len(BotFactories))
+
+	for name := range BotFactories {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
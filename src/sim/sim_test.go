@@ -0,0 +1,136 @@
+package sim
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseBots(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  []string
+		err   bool
+	}{
+		{names: []string{"tag", "random"}, want: []string{"tag", "random"}},
+		{names: []string{"tag", "tag", "equity", "tag"}, want: []string{"tag", "tag#2", "equity", "tag#3"}},
+		{names: []string{"tag", "shark"}, err: true},
+	}
+
+	for _, tt := range tests {
+		bots, err := ParseBots(tt.names)
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseBots(%v) accepted", tt.names)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := make([]string, len(bots))
+		for i, b := range bots {
+			got[i] = b.Name
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseBots(%v) = %v, want %v", tt.names, got, tt.want)
+		}
+	}
+}
+
+func TestRunValidation(t *testing.T) {
+	bots, err := ParseBots([]string{"tag", "random"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "one bot", cfg: Config{Bots: bots[:1], Hands: 10, BigBlind: 2}},
+		{name: "no hands", cfg: Config{Bots: bots, BigBlind: 2}},
+		{name: "no big blind", cfg: Config{Bots: bots, Hands: 10}},
+	}
+
+	for _, tt := range tests {
+		if _, err := Run(tt.cfg); err == nil {
+			t.Errorf("%s: Run accepted the config", tt.name)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	bots, err := ParseBots([]string{"tag", "random", "random"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{Bots: bots, Hands: 301, Workers: 2, Seed: 3, BigBlind: 2, SmallBlind: 1}
+
+	first, err := Run(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := Run(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("runs with one seed differ: %v, %v", first, second)
+	}
+
+	// боты играют друг против друга без рейка, поэтому их выигрыши в сумме равны нулю
+	total := 0.0
+
+	for _, r := range first {
+		if r.Hands != cfg.Hands {
+			t.Errorf("%s played %d hands, want %d", r.Name, r.Hands, cfg.Hands)
+		}
+
+		total += r.BBPer100 * float64(r.Hands)
+	}
+
+	if math.Abs(total) > 1e-6 {
+		t.Errorf("bots won %f bb in total", total/100)
+	}
+}
+
+func TestAccumulatorResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []float64
+		want    Result
+	}{
+		{name: "no hands", want: Result{Name: "no hands"}},
+		{name: "one hand", results: []float64{2}, want: Result{Name: "one hand", Hands: 1, BBPer100: 200}},
+		{
+			name:    "symmetric",
+			results: []float64{1, -1, 3, -3},
+			// дисперсия 20/3, CI95 = 1.96 * 100 * sqrt(20/3/4)
+			want: Result{Name: "symmetric", Hands: 4, CI95: 196 * math.Sqrt(5.0/3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &accumulator{}
+			for _, bb := range tt.results {
+				a.add(bb)
+			}
+
+			got := a.result(tt.name)
+
+			if got.Hands != tt.want.Hands || math.Abs(got.BBPer100-tt.want.BBPer100) > 1e-9 ||
+				math.Abs(got.CI95-tt.want.CI95) > 1e-9 {
+				t.Errorf("result = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package stats
+
+import (
+	"sort"
+	"sync"
+
+	"hands/src/models"
+	"hands/src/storage"
+)
+
+// Filter - условия отбора раздач для подсчета статистики. Пустые поля не ограничивают выборку
+type Filter struct {
+	Positions []models.Position
+	// MinStackBB, MaxStackBB - границы стека игрока перед раздачей, в больших блайндах
+	MinStackBB float64
+	MaxStackBB float64
+}
+
+// match проверяет, подходит ли раздача r для подсчета статистики игрока playerID
+func (f Filter) match(r *models.HandRecord, playerID string) bool {
+	stack, ok := r.Stacks[playerID]
+	if !ok {
+		return false
+	}
+
+	if len(f.Positions) > 0 {
+		found := false
+
+		for _, p := range f.Positions {
+			found = found || r.Positions[playerID] == p
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if r.BigBlind > 0 {
+		depth := float64(stack) / float64(r.BigBlind)
+
+		if f.MinStackBB > 0 && depth < f.MinStackBB {
+			return false
+		}
+
+		if f.MaxStackBB > 0 && depth > f.MaxStackBB {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Counters - счетчики событий, из которых вычисляется статистика игрока
+type Counters struct {
+	Hands int
+	// VPIP - раздачи, в которых игрок добровольно вложил фишки на префлопе
+	VPIP int
+	// PFR - раздачи, в которых игрок повышал на префлопе
+	PFR                         int
+	ThreeBetOpportunities       int
+	ThreeBets                   int
+	FoldToThreeBetOpportunities int
+	FoldsToThreeBet             int
+	// Bets - беты и рейзы после флопа
+	Bets int
+	// Calls - коллы после флопа
+	Calls             int
+	SawFlop           int
+	WentToShowdown    int
+	WonAtShowdown     int
+	CBetOpportunities int
+	CBets             int
+}
+
+// Add прибавляет к счетчикам c счетчики other, например, при объединении статистики нескольких сессий
+func (c *Counters) Add(other Counters) {
+	c.Hands += other.Hands
+	c.VPIP += other.VPIP
+	c.PFR += other.PFR
+	c.ThreeBetOpportunities += other.ThreeBetOpportunities
+	c.ThreeBets += other.ThreeBets
+	c.FoldToThreeBetOpportunities += other.FoldToThreeBetOpportunities
+	c.FoldsToThreeBet += other.FoldsToThreeBet
+	c.Bets += other.Bets
+	c.Calls += other.Calls
+	c.SawFlop += other.SawFlop
+	c.WentToShowdown += other.WentToShowdown
+	c.WonAtShowdown += other.WonAtShowdown
+	c.CBetOpportunities += other.CBetOpportunities
+	c.CBets += other.CBets
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// CountHand подсчитывает события раздачи r для игрока playerID
+func CountHand(r *models.HandRecord, playerID string) Counters {
+	c := Counters{Hands: 1}
+
+	var (
+		opener, lastRaiser               string
+		raisesNum                        int
+		opened, facedThreeBet, foldedPre bool
+	)
+
+	vpip, pfr := false, false
+
+	for _, a := range r.Actions {
+		if a.Street != models.PreFlopStreet {
+			continue
+		}
+
+		if a.PlayerID == playerID {
+			// перед игроком ровно одно повышение, и это не его собственное
+			if raisesNum == 1 && opener != playerID {
+				c.ThreeBetOpportunities++
+				c.ThreeBets += boolToInt(a.Type == models.RaiseAction)
+			}
+
+			// игрок открылся, ему ответили 3-бетом, и это его первое действие после 3-бета
+			if opened && raisesNum == 2 && !facedThreeBet {
+				facedThreeBet = true
+				c.FoldToThreeBetOpportunities++
+				c.FoldsToThreeBet += boolToInt(a.Type == models.FallAction)
+			}
+
+			vpip = vpip || a.Type == models.CallAction || a.Type == models.RaiseAction
+			pfr = pfr || a.Type == models.RaiseAction
+			foldedPre = foldedPre || a.Type == models.FallAction
+		}
+
+		if a.Type == models.RaiseAction {
+			raisesNum++
+			lastRaiser = a.PlayerID
+
+			if raisesNum == 1 {
+				opener = a.PlayerID
+				opened = a.PlayerID == playerID
+			}
+		}
+	}
+
+	c.VPIP = boolToInt(vpip)
+	c.PFR = boolToInt(pfr)
+
+	sawFlop := len(r.Board) >= models.CardsOnFlopNumber && !foldedPre
+	c.SawFlop = boolToInt(sawFlop)
+
+	if !sawFlop {
+		return c
+	}
+
+	flopBetBefore, firstFlopAction := false, true
+
+	for _, a := range r.Actions {
+		if a.Street == models.PreFlopStreet {
+			continue
+		}
+
+		if a.PlayerID == playerID {
+			c.Bets += boolToInt(a.Type == models.RaiseAction)
+			c.Calls += boolToInt(a.Type == models.CallAction)
+
+			// контбет: повышавший последним на префлопе ставит первым на флопе
+			if a.Street == models.FlopStreet && firstFlopAction && lastRaiser == playerID && !flopBetBefore {
+				c.CBetOpportunities++
+				c.CBets += boolToInt(a.Type == models.RaiseAction)
+			}
+
+			if a.Street == models.FlopStreet {
+				firstFlopAction = false
+			}
+		} else if a.Street == models.FlopStreet && a.Type == models.RaiseAction {
+			flopBetBefore = true
+		}
+	}
+
+	for _, id := range r.Showdown {
+		if id == playerID {
+			c.WentToShowdown = 1
+			c.WonAtShowdown = boolToInt(r.Payouts[playerID] > 0)
+		}
+	}
+
+	return c
+}
+
+func percent(num, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(num) / float64(total)
+}
+
+// PlayerStats - статистика игрока. Все показатели, кроме AggressionFactor, в процентах
+type PlayerStats struct {
+	PlayerID string
+	Counters
+}
+
+func (s *PlayerStats) VPIP() float64 {
+	return percent(s.Counters.VPIP, s.Hands)
+}
+
+func (s *PlayerStats) PFR() float64 {
+	return percent(s.Counters.PFR, s.Hands)
+}
+
+func (s *PlayerStats) ThreeBet() float64 {
+	return percent(s.ThreeBets, s.ThreeBetOpportunities)
+}
+
+func (s *PlayerStats) FoldToThreeBet() float64 {
+	return percent(s.FoldsToThreeBet, s.FoldToThreeBetOpportunities)
+}
+
+// AggressionFactor - отношение бетов и рейзов к коллам после флопа
+func (s *PlayerStats) AggressionFactor() float64 {
+	if s.Calls == 0 {
+		return float64(s.Bets)
+	}
+
+	return float64(s.Bets) / float64(s.Calls)
+}
+
+// WTSD - доля раздач со вскрытием среди раздач, в которых игрок видел флоп
+func (s *PlayerStats) WTSD() float64 {
+	return percent(s.WentToShowdown, s.SawFlop)
+}
+
+// WSD - доля выигранных вскрытий
+func (s *PlayerStats) WSD() float64 {
+	return percent(s.WonAtShowdown, s.WentToShowdown)
+}
+
+func (s *PlayerStats) CBet() float64 {
+	return percent(s.CBets, s.CBetOpportunities)
+}
+
+// Tracker накапливает записи о раздачах и считает по ним статистику игроков
+type Tracker struct {
+	records []*models.HandRecord
+	sync.RWMutex
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		records: make([]*models.HandRecord, 0),
+		RWMutex: sync.RWMutex{},
+	}
+}
+
+// Add добавляет записи о раздачах
+func (t *Tracker) Add(records ...*models.HandRecord) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.records = append(t.records, records...)
+}
+
+// Load добавляет записи о раздачах из хранилища
+func (t *Tracker) Load(s storage.Storage, query storage.HandQuery) error {
+	records, err := s.Hands(query)
+	if err != nil {
+		return err
+	}
+
+	t.Add(records...)
+
+	return nil
+}
+
+// Stats возвращает статистику игрока по раздачам, подходящим под filter
+func (t *Tracker) Stats(playerID string, filter Filter) *PlayerStats {
+	t.RLock()
+	defer t.RUnlock()
+
+	s := &PlayerStats{PlayerID: playerID}
+
+	for _, r := range t.records {
+		if filter.match(r, playerID) {
+			s.Add(CountHand(r, playerID))
+		}
+	}
+
+	return s
+}
+
+// All возвращает статистику всех игроков, упорядоченную по ID
+func (t *Tracker) All(filter Filter) []*PlayerStats {
+	t.RLock()
+	ids := make(map[string]struct{})
+
+	for _, r := range t.records {
+		for _, id := range r.PlayerIDs() {
+			ids[id] = struct{}{}
+		}
+	}
+	t.RUnlock()
+
+	all := make([]*PlayerStats, 0, len(ids))
+
+	for id := range ids {
+		all = append(all, t.Stats(id, filter))
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].PlayerID < all[j].PlayerID
+	})
+
+	return all
+}
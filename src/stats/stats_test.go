@@ -0,0 +1,219 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"hands/src/models"
+)
+
+const (
+	hero    = "hero"
+	villain = "villain"
+	fish    = "fish"
+)
+
+func act(playerID string, actionType models.ActionType, street models.Street) models.Action {
+	return models.Action{PlayerID: playerID, Type: actionType, Street: street}
+}
+
+// scriptedHands возвращает три раздачи с известными событиями для каждого игрока
+func scriptedHands() []*models.HandRecord {
+	pre, flop, turn, river := models.PreFlopStreet, models.FlopStreet, models.TurnStreet, models.RiverStreet
+
+	return []*models.HandRecord{
+		// hero открывается с баттона, villain делает 3-бет, hero коллирует; villain ставит контбет,
+		// hero коллирует, а на терне villain сбрасывает карты на ставку hero
+		{
+			ID:       "h1",
+			BigBlind: 10,
+			Board:    []string{"Ah", "7d", "2c", "Ks"},
+			Stacks:   map[string]models.Chips{hero: 1000, villain: 1000, fish: 1000},
+			Payouts:  map[string]models.Chips{hero: 250},
+			Positions: map[string]models.Position{
+				hero: models.ButtonPosition, villain: models.SmallBlindPosition, fish: models.BigBlindPosition,
+			},
+			Actions: []models.Action{
+				act(hero, models.RaiseAction, pre),
+				act(villain, models.RaiseAction, pre),
+				act(fish, models.FallAction, pre),
+				act(hero, models.CallAction, pre),
+				act(villain, models.RaiseAction, flop),
+				act(hero, models.CallAction, flop),
+				act(villain, models.CheckAction, turn),
+				act(hero, models.RaiseAction, turn),
+				act(villain, models.FallAction, turn),
+			},
+		},
+		// hero открывается из CO, оба соперника коллируют; hero пропускает контбет и проигрывает вскрытие
+		{
+			ID:       "h2",
+			BigBlind: 10,
+			Board:    []string{"Qh", "8d", "3c", "5s", "9h"},
+			Stacks:   map[string]models.Chips{hero: 300, villain: 1000, fish: 1000},
+			Payouts:  map[string]models.Chips{villain: 180},
+			Positions: map[string]models.Position{
+				hero: models.CutoffPosition, villain: models.ButtonPosition, fish: models.BigBlindPosition,
+			},
+			Actions: []models.Action{
+				act(hero, models.RaiseAction, pre),
+				act(villain, models.CallAction, pre),
+				act(fish, models.CallAction, pre),
+				act(fish, models.CheckAction, flop),
+				act(hero, models.CheckAction, flop),
+				act(villain, models.RaiseAction, flop),
+				act(fish, models.FallAction, flop),
+				act(hero, models.CallAction, flop),
+				act(hero, models.CheckAction, turn),
+				act(villain, models.CheckAction, turn),
+				act(hero, models.CheckAction, river),
+				act(villain, models.CheckAction, river),
+			},
+			Showdown: []string{hero, villain},
+		},
+		// hero открывается с малого блайнда и сбрасывает карты на 3-бет villain
+		{
+			ID:       "h3",
+			BigBlind: 10,
+			Stacks:   map[string]models.Chips{hero: 500, villain: 1000, fish: 1000},
+			Payouts:  map[string]models.Chips{villain: 70},
+			Positions: map[string]models.Position{
+				hero: models.SmallBlindPosition, villain: models.BigBlindPosition, fish: models.ButtonPosition,
+			},
+			Actions: []models.Action{
+				act(fish, models.FallAction, pre),
+				act(hero, models.RaiseAction, pre),
+				act(villain, models.RaiseAction, pre),
+				act(hero, models.FallAction, pre),
+			},
+		},
+	}
+}
+
+func newScriptedTracker() *Tracker {
+	tracker := NewTracker()
+	tracker.Add(scriptedHands()...)
+
+	return tracker
+}
+
+func closeTo(got, want float64) bool {
+	return math.Abs(got-want) < 0.01
+}
+
+func TestStats(t *testing.T) {
+	tracker := newScriptedTracker()
+
+	tests := []struct {
+		player string
+		name   string
+		got    func(s *PlayerStats) float64
+		want   float64
+	}{
+		{player: hero, name: "VPIP", got: (*PlayerStats).VPIP, want: 100},
+		{player: hero, name: "PFR", got: (*PlayerStats).PFR, want: 100},
+		{player: hero, name: "3-bet", got: (*PlayerStats).ThreeBet, want: 0},
+		{player: hero, name: "fold to 3-bet", got: (*PlayerStats).FoldToThreeBet, want: 50},
+		{player: hero, name: "AF", got: (*PlayerStats).AggressionFactor, want: 0.5},
+		{player: hero, name: "WTSD", got: (*PlayerStats).WTSD, want: 50},
+		{player: hero, name: "W$SD", got: (*PlayerStats).WSD, want: 0},
+		{player: hero, name: "c-bet", got: (*PlayerStats).CBet, want: 0},
+
+		{player: villain, name: "VPIP", got: (*PlayerStats).VPIP, want: 100},
+		{player: villain, name: "PFR", got: (*PlayerStats).PFR, want: 200.0 / 3},
+		{player: villain, name: "3-bet", got: (*PlayerStats).ThreeBet, want: 200.0 / 3},
+		{player: villain, name: "fold to 3-bet", got: (*PlayerStats).FoldToThreeBet, want: 0},
+		// коллов после флопа нет, поэтому AF равен количеству бетов и рейзов
+		{player: villain, name: "AF", got: (*PlayerStats).AggressionFactor, want: 2},
+		{player: villain, name: "WTSD", got: (*PlayerStats).WTSD, want: 50},
+		{player: villain, name: "W$SD", got: (*PlayerStats).WSD, want: 100},
+		{player: villain, name: "c-bet", got: (*PlayerStats).CBet, want: 100},
+
+		{player: fish, name: "VPIP", got: (*PlayerStats).VPIP, want: 100.0 / 3},
+		{player: fish, name: "PFR", got: (*PlayerStats).PFR, want: 0},
+		{player: fish, name: "3-bet", got: (*PlayerStats).ThreeBet, want: 0},
+		{player: fish, name: "WTSD", got: (*PlayerStats).WTSD, want: 0},
+	}
+
+	for _, tt := range tests {
+		s := tracker.Stats(tt.player, Filter{})
+
+		if got := tt.got(s); !closeTo(got, tt.want) {
+			t.Errorf("%s %s = %.2f, want %.2f", tt.player, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCountHand(t *testing.T) {
+	hands := scriptedHands()
+
+	tests := []struct {
+		hand   int
+		player string
+		want   Counters
+	}{
+		{hand: 0, player: hero, want: Counters{
+			Hands: 1, VPIP: 1, PFR: 1, FoldToThreeBetOpportunities: 1, Bets: 1, Calls: 1, SawFlop: 1,
+		}},
+		{hand: 0, player: villain, want: Counters{
+			Hands: 1, VPIP: 1, PFR: 1, ThreeBetOpportunities: 1, ThreeBets: 1, Bets: 1, SawFlop: 1,
+			CBetOpportunities: 1, CBets: 1,
+		}},
+		{hand: 1, player: hero, want: Counters{
+			Hands: 1, VPIP: 1, PFR: 1, Calls: 1, SawFlop: 1, WentToShowdown: 1, CBetOpportunities: 1,
+		}},
+		{hand: 1, player: villain, want: Counters{
+			Hands: 1, VPIP: 1, ThreeBetOpportunities: 1, Bets: 1, SawFlop: 1, WentToShowdown: 1, WonAtShowdown: 1,
+		}},
+		{hand: 2, player: hero, want: Counters{
+			Hands: 1, VPIP: 1, PFR: 1, FoldToThreeBetOpportunities: 1, FoldsToThreeBet: 1,
+		}},
+		{hand: 2, player: fish, want: Counters{Hands: 1}},
+	}
+
+	for _, tt := range tests {
+		if got := CountHand(hands[tt.hand], tt.player); got != tt.want {
+			t.Errorf("CountHand(%s, %s) = %+v, want %+v", hands[tt.hand].ID, tt.player, got, tt.want)
+		}
+	}
+}
+
+func TestStatsFilter(t *testing.T) {
+	tracker := newScriptedTracker()
+
+	tests := []struct {
+		name   string
+		player string
+		filter Filter
+		want   int
+	}{
+		{name: "no filter", player: hero, filter: Filter{}, want: 3},
+		{name: "button", player: hero, filter: Filter{Positions: []models.Position{models.ButtonPosition}}, want: 1},
+		{
+			name: "blinds", player: hero,
+			filter: Filter{Positions: []models.Position{models.SmallBlindPosition, models.BigBlindPosition}},
+			want:   1,
+		},
+		{name: "villain on button", player: villain, filter: Filter{Positions: []models.Position{models.ButtonPosition}}, want: 1},
+		{name: "deep", player: hero, filter: Filter{MinStackBB: 40}, want: 2},
+		{name: "short", player: hero, filter: Filter{MaxStackBB: 40}, want: 1},
+		{name: "middle", player: hero, filter: Filter{MinStackBB: 40, MaxStackBB: 60}, want: 1},
+		{name: "unknown player", player: "nobody", filter: Filter{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tracker.Stats(tt.player, tt.filter).Hands; got != tt.want {
+			t.Errorf("%s: Stats(%s).Hands = %d, want %d", tt.name, tt.player, got, tt.want)
+		}
+	}
+
+	all := tracker.All(Filter{MaxStackBB: 40})
+
+	if len(all) != 3 || all[0].PlayerID != fish || all[1].PlayerID != hero || all[2].PlayerID != villain {
+		t.Fatalf("All() returned %d players in wrong order", len(all))
+	}
+
+	if all[1].Hands != 1 || all[0].Hands != 0 {
+		t.Errorf("All() counted %d hands for hero and %d for fish, want 1 and 0", all[1].Hands, all[0].Hands)
+	}
+}
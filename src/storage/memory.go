@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"hands/src/models"
+)
+
+// MemoryStorage - хранилище в памяти процесса. Объекты хранятся в виде JSON,
+// поэтому изменения сохраненных объектов не влияют на хранилище
+type MemoryStorage struct {
+	tables map[string][]byte
+	hands  [][]byte
+	sync.RWMutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		tables:  make(map[string][]byte),
+		hands:   make([][]byte, 0),
+		RWMutex: sync.RWMutex{},
+	}
+}
+
+func (s *MemoryStorage) SaveTable(snapshot *models.TableSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.tables[snapshot.ID] = data
+
+	return nil
+}
+
+func (s *MemoryStorage) LoadTable(id string) (*models.TableSnapshot, error) {
+	s.RLock()
+	data, ok := s.tables[id]
+	s.RUnlock()
+
+	if !ok {
+		return nil, NewNotFoundError(id)
+	}
+
+	snapshot := &models.TableSnapshot{}
+
+	return snapshot, json.Unmarshal(data, snapshot)
+}
+
+func (s *MemoryStorage) DeleteTable(id string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.tables[id]; !ok {
+		return NewNotFoundError(id)
+	}
+
+	delete(s.tables, id)
+
+	return nil
+}
+
+func (s *MemoryStorage) TableIDs() ([]string, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	ids := make([]string, 0, len(s.tables))
+
+	for id := range s.tables {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+func (s *MemoryStorage) AppendHand(record *models.HandRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.hands = append(s.hands, data)
+
+	return nil
+}
+
+func (s *MemoryStorage) Hands(query HandQuery) ([]*models.HandRecord, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	records := make([]*models.HandRecord, 0)
+
+	for _, data := range s.hands {
+		r := &models.HandRecord{}
+
+		if err := json.Unmarshal(data, r); err != nil {
+			return nil, err
+		}
+
+		if !query.match(r) {
+			continue
+		}
+
+		records = append(records, r)
+
+		if query.Limit > 0 && len(records) == query.Limit {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+func (s *MemoryStorage) Close() error {
+	return nil
+}
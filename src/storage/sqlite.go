@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"hands/src/models"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS tables (
+	id         TEXT PRIMARY KEY,
+	data       TEXT NOT NULL,
+	updated_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS hands (
+	seq         INTEGER PRIMARY KEY AUTOINCREMENT,
+	id          TEXT NOT NULL UNIQUE,
+	table_id    TEXT NOT NULL,
+	finished_at INTEGER NOT NULL,
+	data        TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS hands_table_id ON hands (table_id, finished_at);
+
+CREATE TABLE IF NOT EXISTS hand_players (
+	hand_id   TEXT NOT NULL,
+	player_id TEXT NOT NULL,
+	PRIMARY KEY (hand_id, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS hand_players_player_id ON hand_players (player_id);
+`
+
+// SQLiteStorage - хранилище в базе данных SQLite. Снимки столов и записи о раздачах хранятся в виде JSON
+type SQLiteStorage struct {
+	db *sql.DB
+}
+
+// NewSQLiteStorage открывает (или создает) базу данных по пути path. ":memory:" - база в памяти
+func NewSQLiteStorage(path string) (*SQLiteStorage, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// база в памяти существует только в рамках одного соединения
+	if path == ":memory:" {
+		db.SetMaxOpenConns(1)
+	}
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return &SQLiteStorage{db: db}, nil
+}
+
+func (s *SQLiteStorage) SaveTable(snapshot *models.TableSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO tables (id, data, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`,
+		snapshot.ID, string(data), time.Now().UnixNano(),
+	)
+
+	return err
+}
+
+func (s *SQLiteStorage) LoadTable(id string) (*models.TableSnapshot, error) {
+	var data string
+
+	err := s.db.QueryRow(`SELECT data FROM tables WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, NewNotFoundError(id)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &models.TableSnapshot{}
+
+	return snapshot, json.Unmarshal([]byte(data), snapshot)
+}
+
+func (s *SQLiteStorage) DeleteTable(id string) error {
+	res, err := s.db.Exec(`DELETE FROM tables WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return NewNotFoundError(id)
+	}
+
+	return err
+}
+
+func (s *SQLiteStorage) TableIDs() ([]string, error) {
+	rows, err := s.db.Query(`SELECT id FROM tables ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+
+	for rows.Next() {
+		var id string
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (s *SQLiteStorage) AppendHand(record *models.HandRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(
+		`INSERT INTO hands (id, table_id, finished_at, data) VALUES (?, ?, ?, ?)`,
+		record.ID, record.TableID, record.FinishedAt.UnixNano(), string(data),
+	); err != nil {
+		return err
+	}
+
+	for _, playerID := range record.PlayerIDs() {
+		if _, err := tx.Exec(
+			`INSERT INTO hand_players (hand_id, player_id) VALUES (?, ?)`,
+			record.ID, playerID,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteStorage) Hands(query HandQuery) ([]*models.HandRecord, error) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if query.TableID != "" {
+		conditions = append(conditions, "table_id = ?")
+		args = append(args, query.TableID)
+	}
+
+	if query.PlayerID != "" {
+		conditions = append(conditions, "id IN (SELECT hand_id FROM hand_players WHERE player_id = ?)")
+		args = append(args, query.PlayerID)
+	}
+
+	if !query.From.IsZero() {
+		conditions = append(conditions, "finished_at >= ?")
+		args = append(args, query.From.UnixNano())
+	}
+
+	if !query.To.IsZero() {
+		conditions = append(conditions, "finished_at < ?")
+		args = append(args, query.To.UnixNano())
+	}
+
+	q := `SELECT data FROM hands`
+
+	if len(conditions) > 0 {
+		q += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	q += ` ORDER BY seq`
+
+	if query.Limit > 0 {
+		q += ` LIMIT ?`
+		args = append(args, query.Limit)
+	}
+
+	rows, err := s.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*models.HandRecord, 0)
+
+	for rows.Next() {
+		var data string
+
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		r := &models.HandRecord{}
+
+		if err := json.Unmarshal([]byte(data), r); err != nil {
+			return nil, err
+		}
+
+		records = append(records, r)
+	}
+
+	return records, rows.Err()
+}
+
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
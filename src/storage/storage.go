@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"hands/src/models"
+)
+
+// NotFoundError - ошибка, возникающая при попытке загрузить отсутствующий в хранилище объект
+type NotFoundError struct {
+	ID string
+}
+
+func NewNotFoundError(id string) NotFoundError {
+	return NotFoundError{ID: id}
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("Object with ID %s not found", e.ID)
+}
+
+// HandQuery - условия выборки завершенных раздач. Пустые поля не ограничивают выборку
+type HandQuery struct {
+	TableID  string
+	PlayerID string
+	From     time.Time
+	To       time.Time
+	// Limit - максимальное количество записей, 0 - без ограничения
+	Limit int
+}
+
+// match проверяет, удовлетворяет ли запись условиям выборки (без учета Limit)
+func (q HandQuery) match(r *models.HandRecord) bool {
+	if q.TableID != "" && r.TableID != q.TableID {
+		return false
+	}
+
+	if q.PlayerID != "" {
+		if _, ok := r.Stacks[q.PlayerID]; !ok {
+			return false
+		}
+	}
+
+	if !q.From.IsZero() && r.FinishedAt.Before(q.From) {
+		return false
+	}
+
+	if !q.To.IsZero() && !r.FinishedAt.Before(q.To) {
+		return false
+	}
+
+	return true
+}
+
+// Storage - хранилище состояний столов и истории раздач
+type Storage interface {
+	// SaveTable сохраняет снимок стола, заменяя предыдущий снимок с тем же ID
+	SaveTable(snapshot *models.TableSnapshot) error
+	// LoadTable загружает снимок стола. Возвращает NotFoundError, если стола нет
+	LoadTable(id string) (*models.TableSnapshot, error)
+	// DeleteTable удаляет снимок стола
+	DeleteTable(id string) error
+	// TableIDs возвращает ID всех сохраненных столов
+	TableIDs() ([]string, error)
+	// AppendHand добавляет запись о завершенной раздаче
+	AppendHand(record *models.HandRecord) error
+	// Hands возвращает записи о раздачах, удовлетворяющие query, в порядке завершения
+	Hands(query HandQuery) ([]*models.HandRecord, error)
+	Close() error
+}
+
+// SaveTable сохраняет в s текущее состояние стола t
+func SaveTable(s Storage, t *models.Table) error {
+	return s.SaveTable(t.Snapshot())
+}
+
+// LoadTable восстанавливает из s стол с ID id
+func LoadTable(s Storage, id string) (*models.Table, error) {
+	snapshot, err := s.LoadTable(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.RestoreTable(snapshot)
+}
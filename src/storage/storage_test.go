@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"hands/src/helpers"
+	"hands/src/models"
+)
+
+func newStorages(t *testing.T) map[string]Storage {
+	t.Helper()
+
+	sqlite, err := NewSQLiteStorage(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storages := map[string]Storage{
+		"memory": NewMemoryStorage(),
+		"sqlite": sqlite,
+	}
+
+	t.Cleanup(func() {
+		for _, s := range storages {
+			s.Close()
+		}
+	})
+
+	return storages
+}
+
+func newStorageTable(t *testing.T) *models.Table {
+	t.Helper()
+
+	table := models.NewTable("test", "t", helpers.NewDefaultIdGenerator(), models.CasheTableType, 3, 10, 5).
+		WithRandom(rand.New(rand.NewSource(1)))
+
+	for _, name := range []string{"first", "second", "third"} {
+		if err := table.Register(models.NewPlayer(name, helpers.NewDefaultIdGenerator(), 100)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := table.NewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	return table
+}
+
+func TestStorageTables(t *testing.T) {
+	for name, s := range newStorages(t) {
+		t.Run(name, func(t *testing.T) {
+			table := newStorageTable(t)
+
+			if err := SaveTable(s, table); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, err := LoadTable(s, table.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(loaded.Snapshot(), table.Snapshot()) {
+				t.Error("loaded table differs from the saved one")
+			}
+
+			// повторное сохранение заменяет снимок
+			table.Name = "renamed"
+
+			if err := SaveTable(s, table); err != nil {
+				t.Fatal(err)
+			}
+
+			snapshot, err := s.LoadTable(table.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if snapshot.Name != "renamed" {
+				t.Errorf("LoadTable() name = %s, want renamed", snapshot.Name)
+			}
+
+			ids, err := s.TableIDs()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(ids, []string{table.ID}) {
+				t.Errorf("TableIDs() = %v, want [%s]", ids, table.ID)
+			}
+
+			if err := s.DeleteTable(table.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			var notFound NotFoundError
+
+			if _, err := s.LoadTable(table.ID); !errors.As(err, &notFound) || notFound.ID != table.ID {
+				t.Errorf("LoadTable() of a deleted table returned %v", err)
+			}
+
+			if err := s.DeleteTable(table.ID); !errors.As(err, &notFound) {
+				t.Errorf("DeleteTable() of a deleted table returned %v", err)
+			}
+		})
+	}
+}
+
+func TestStorageHands(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	records := []*models.HandRecord{
+		{ID: "h1", TableID: "t1", FinishedAt: start, Stacks: map[string]models.Chips{"a": 100, "b": 100}},
+		{ID: "h2", TableID: "t2", FinishedAt: start.Add(time.Minute), Stacks: map[string]models.Chips{"a": 90, "c": 100}},
+		{ID: "h3", TableID: "t1", FinishedAt: start.Add(2 * time.Minute), Stacks: map[string]models.Chips{"b": 110}},
+		{ID: "h4", TableID: "t1", FinishedAt: start.Add(3 * time.Minute), Stacks: map[string]models.Chips{"a": 80, "b": 120}},
+	}
+
+	tests := []struct {
+		name  string
+		query HandQuery
+		want  []string
+	}{
+		{name: "all", query: HandQuery{}, want: []string{"h1", "h2", "h3", "h4"}},
+		{name: "table", query: HandQuery{TableID: "t1"}, want: []string{"h1", "h3", "h4"}},
+		{name: "player", query: HandQuery{PlayerID: "a"}, want: []string{"h1", "h2", "h4"}},
+		{name: "table and player", query: HandQuery{TableID: "t1", PlayerID: "a"}, want: []string{"h1", "h4"}},
+		{name: "from", query: HandQuery{From: start.Add(time.Minute)}, want: []string{"h2", "h3", "h4"}},
+		{name: "to", query: HandQuery{To: start.Add(2 * time.Minute)}, want: []string{"h1", "h2"}},
+		{name: "limit", query: HandQuery{PlayerID: "b", Limit: 2}, want: []string{"h1", "h3"}},
+		{name: "unknown player", query: HandQuery{PlayerID: "z"}, want: []string{}},
+	}
+
+	for name, s := range newStorages(t) {
+		for _, r := range records {
+			if err := s.AppendHand(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				got, err := s.Hands(tt.query)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				ids := make([]string, len(got))
+				for i, r := range got {
+					ids[i] = r.ID
+				}
+
+				if !reflect.DeepEqual(ids, tt.want) {
+					t.Errorf("Hands(%+v) = %v, want %v", tt.query, ids, tt.want)
+				}
+			})
+		}
+
+		got, err := s.Hands(HandQuery{TableID: "t2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 1 || !got[0].FinishedAt.Equal(records[1].FinishedAt) || got[0].Stacks["a"] != 90 {
+			t.Errorf("%s: Hands() did not restore the record fields: %+v", name, got)
+		}
+	}
+}
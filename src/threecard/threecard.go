@@ -0,0 +1,210 @@
+// Package threecard реализует казино-игру Three Card Poker: игрок играет против дилера руками из трех карт.
+// Игрок ставит анте и, по желанию, побочную ставку Pair Plus, смотрит свои карты и либо сбрасывает их,
+// теряя ставки, либо делает ставку Play, равную анте
+package threecard
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"hands/src/models"
+)
+
+// DealerQualifier - дилер играет со старшей дамой или сильнее, иначе анте выплачивается, а Play возвращается
+const DealerQualifier = models.Queen
+
+// Rules - таблицы выплат: сколько фишек выплачивается за фишку ставки в зависимости от руки игрока
+type Rules struct {
+	// AnteBonus - бонус к анте за сильную руку, выплачивается независимо от руки дилера
+	AnteBonus map[models.ThreeCardValue]models.Chips
+	// PairPlus - выплаты побочной ставки Pair Plus за пару или сильнее
+	PairPlus map[models.ThreeCardValue]models.Chips
+}
+
+// DefaultRules возвращает распространенные таблицы выплат
+func DefaultRules() Rules {
+	return Rules{
+		AnteBonus: map[models.ThreeCardValue]models.Chips{
+			models.ThreeCardStraight:      1,
+			models.ThreeCardTrips:         4,
+			models.ThreeCardStraightFlush: 5,
+		},
+		PairPlus: map[models.ThreeCardValue]models.Chips{
+			models.ThreeCardPair:          1,
+			models.ThreeCardFlush:         4,
+			models.ThreeCardStraight:      6,
+			models.ThreeCardTrips:         30,
+			models.ThreeCardStraightFlush: 40,
+		},
+	}
+}
+
+// Hand - раздача одного игрока против дилера
+type Hand struct {
+	Player *models.Player
+	Cards  []*models.Card
+	Ante   models.Chips
+	// PairPlus - побочная ставка, 0 - игрок ее не делал
+	PairPlus models.Chips
+
+	dealer []*models.Card
+	done   bool
+}
+
+// Result - итог раздачи. Выигрыши по ставкам указаны без учета самой ставки, проигрыши - отрицательными
+type Result struct {
+	PlayerRank models.ThreeCardRank
+	DealerRank models.ThreeCardRank
+	Dealer     []*models.Card
+	// Folded - игрок сбросил карты
+	Folded bool
+	// DealerQualifies - у дилера старшая дама или сильнее
+	DealerQualifies bool
+	Ante            models.Chips
+	Play            models.Chips
+	AnteBonus       models.Chips
+	PairPlus        models.Chips
+}
+
+// Net возвращает чистый выигрыш игрока за раздачу
+func (r *Result) Net() models.Chips {
+	return r.Ante + r.Play + r.AnteBonus + r.PairPlus
+}
+
+// Game - стол Three Card Poker. Каждая раздача играется с новой колодой
+type Game struct {
+	ID    string
+	Rules Rules
+
+	deck   *models.Deck
+	m      sync.Mutex
+	random *rand.Rand
+}
+
+// NewGame создает стол Three Card Poker с таблицами выплат rules
+func NewGame(idMaker models.IdMaker, rules Rules) *Game {
+	return &Game{
+		ID:    idMaker.MakeID(),
+		Rules: rules,
+		deck:  models.NewDeck(),
+	}
+}
+
+// WithRandom устанавливает генератор случайных чисел для тасования колоды. Возвращает g
+func (g *Game) WithRandom(r *rand.Rand) *Game {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	g.random = r
+
+	return g
+}
+
+// Deal списывает со стека игрока анте и ставку Pair Plus и раздает по три карты игроку и дилеру.
+// Для ставки Play у игрока должно остаться не меньше анте
+func (g *Game) Deal(player *models.Player, ante, pairPlus models.Chips) (*Hand, error) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if ante <= 0 || pairPlus < 0 {
+		return nil, fmt.Errorf("Wrong bets: ante %d, pair plus %d", ante, pairPlus)
+	}
+
+	if player.GetCurrentChipsAmount() < 2*ante+pairPlus {
+		return nil, fmt.Errorf("Player with ID %s has not enough chips for ante %d and pair plus %d",
+			player.ID, ante, pairPlus)
+	}
+
+	if g.random != nil {
+		g.deck.ShuffleWithRandom(g.random)
+	} else {
+		g.deck.Shuffle()
+	}
+
+	h := &Hand{
+		Player:   player,
+		Ante:     player.Post(ante),
+		PairPlus: player.Post(pairPlus),
+	}
+
+	for i := 0; i < 2*models.ThreeCardSize; i++ {
+		card, err := g.deck.Card()
+		if err != nil {
+			return nil, err
+		}
+
+		if i%2 == 0 {
+			h.Cards = append(h.Cards, card)
+		} else {
+			h.dealer = append(h.dealer, card)
+		}
+	}
+
+	return h, nil
+}
+
+// Resolve завершает раздачу h: если play истинно, игрок ставит Play в размере анте, иначе сбрасывает
+// карты и теряет все ставки. Выигрыш вместе с возвращаемыми ставками зачисляется в стек игрока
+func (g *Game) Resolve(h *Hand, play bool) (*Result, error) {
+	if h.done {
+		return nil, fmt.Errorf("Hand of player with ID %s is resolved already", h.Player.ID)
+	}
+
+	player, err := models.RankThreeCardPoker(h.Cards)
+	if err != nil {
+		return nil, err
+	}
+
+	dealer, err := models.RankThreeCardPoker(h.dealer)
+	if err != nil {
+		return nil, err
+	}
+
+	h.done = true
+
+	r := &Result{
+		PlayerRank:      player,
+		DealerRank:      dealer,
+		Dealer:          append(make([]*models.Card, 0, models.ThreeCardSize), h.dealer...),
+		Folded:          !play,
+		DealerQualifies: dealer.Value() > models.ThreeCardHighCard || dealer.High() >= DealerQualifier,
+	}
+
+	if !play {
+		r.Ante, r.PairPlus = -h.Ante, -h.PairPlus
+
+		return r, nil
+	}
+
+	bet := h.Player.Post(h.Ante)
+
+	r.AnteBonus = h.Ante * g.Rules.AnteBonus[player.Value()]
+
+	switch cmp := player.Compare(dealer); {
+	case !r.DealerQualifies:
+		r.Ante = h.Ante
+	case cmp > 0:
+		r.Ante, r.Play = h.Ante, bet
+	case cmp < 0:
+		r.Ante, r.Play = -h.Ante, -bet
+	}
+
+	r.PairPlus = -h.PairPlus
+	if pays, ok := g.Rules.PairPlus[player.Value()]; ok {
+		r.PairPlus = h.PairPlus * pays
+	}
+
+	// возвращаются ставки, которые игрок не проиграл, вместе с выигрышем
+	returned := r.AnteBonus
+
+	for _, pair := range [][2]models.Chips{{h.Ante, r.Ante}, {bet, r.Play}, {h.PairPlus, r.PairPlus}} {
+		if pair[1] >= 0 {
+			returned += pair[0] + pair[1]
+		}
+	}
+
+	h.Player.AddChips(returned)
+
+	return r, nil
+}
@@ -0,0 +1,108 @@
+package threecard
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"hands/src/helpers"
+	"hands/src/models"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name   string
+		player string
+		dealer string
+		play   bool
+		want   Result
+	}{
+		{
+			name:   "fold loses ante and pair plus",
+			player: "Kh 8d 3c",
+			dealer: "Jh 9d 2c",
+			want:   Result{Folded: true, Ante: -10, PairPlus: -5},
+		},
+		{
+			name:   "dealer does not qualify",
+			player: "Qh Qd 3c",
+			dealer: "Jh 9d 2c",
+			play:   true,
+			want:   Result{Ante: 10, PairPlus: 5},
+		},
+		{
+			name:   "straight beats qualifying dealer",
+			player: "9h Td Jc",
+			dealer: "Qs 8h 2d",
+			play:   true,
+			want:   Result{DealerQualifies: true, Ante: 10, Play: 10, AnteBonus: 10, PairPlus: 30},
+		},
+		{
+			name:   "dealer pair wins",
+			player: "Kh 8d 3c",
+			dealer: "5s 5h 2d",
+			play:   true,
+			want:   Result{DealerQualifies: true, Ante: -10, Play: -10, PairPlus: -5},
+		},
+		{
+			name:   "tie pushes ante and play",
+			player: "Kh 8d 3c",
+			dealer: "Ks 8h 3d",
+			play:   true,
+			want:   Result{DealerQualifies: true, PairPlus: -5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame(helpers.NewDefaultIdGenerator(), DefaultRules()).WithRandom(rand.New(rand.NewSource(1)))
+			player := models.NewPlayer("p", helpers.NewDefaultIdGenerator(), 100)
+
+			h, err := game.Deal(player, 10, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h.Cards, h.dealer = models.MustParse(tt.player), models.MustParse(tt.dealer)
+
+			r, err := game.Resolve(h, tt.play)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := Result{
+				Folded:          r.Folded,
+				DealerQualifies: r.DealerQualifies,
+				Ante:            r.Ante,
+				Play:            r.Play,
+				AnteBonus:       r.AnteBonus,
+				PairPlus:        r.PairPlus,
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+
+			if stack := player.GetCurrentChipsAmount(); stack != 100+r.Net() {
+				t.Errorf("stack = %d, want %d", stack, 100+r.Net())
+			}
+
+			if _, err := game.Resolve(h, tt.play); err == nil {
+				t.Error("hand resolved twice")
+			}
+		})
+	}
+}
+
+func TestDealNotEnoughChips(t *testing.T) {
+	game := NewGame(helpers.NewDefaultIdGenerator(), DefaultRules())
+	player := models.NewPlayer("p", helpers.NewDefaultIdGenerator(), 24)
+
+	if _, err := game.Deal(player, 10, 5); err == nil {
+		t.Error("deal accepted without chips for play")
+	}
+
+	if player.GetCurrentChipsAmount() != 24 {
+		t.Errorf("stack changed to %d", player.GetCurrentChipsAmount())
+	}
+}